@@ -0,0 +1,133 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"reef-asia/internal/feeds"
+)
+
+func weatherCommand(fs *flag.FlagSet) runFunc {
+	all := fs.Bool("all", false, "fetch every supported country")
+	city := fs.String("city", "", "gazetteer city instead of the reference city")
+	lang := fs.String("lang", "", "locale of the summary, e.g. ja")
+	model := fs.String("model", "", "Open-Meteo weather model, e.g. jma_seamless")
+	return func(ctx context.Context, c *feeds.Client, args []string, out *output) error {
+		countries, err := targets(args, *all, *city)
+		if err != nil {
+			return err
+		}
+		ctx = feeds.ContextWithModel(feeds.ContextWithLocale(ctx, *lang), *model)
+		results := fetchAll(ctx, countries, func(ctx context.Context, country string) (*feeds.WeatherData, error) {
+			if *city != "" {
+				return c.FetchWeatherForCity(ctx, country, *city)
+			}
+			return c.FetchWeather(ctx, country)
+		})
+		header := []string{"COUNTRY", "CITY", "TEMP", "FEELS LIKE", "HUMIDITY", "WIND", "UV", "SUMMARY"}
+		return writeResults(out, results, !*all && len(countries) == 1, header, func(w *feeds.WeatherData) []string {
+			return []string{
+				cmp.Or(w.City, "-"),
+				fmt.Sprintf("%.1f°C", w.TemperatureC),
+				fmt.Sprintf("%.1f°C", w.FeelsLikeC),
+				fmt.Sprintf("%.0f%%", w.RelativeHumidity),
+				fmt.Sprintf("%.1f %s", w.WindSpeed, w.WindSpeedUnit),
+				fmt.Sprintf("%.1f", w.UVIndex),
+				w.Summary,
+			}
+		})
+	}
+}
+
+func airQualityCommand(fs *flag.FlagSet) runFunc {
+	all := fs.Bool("all", false, "fetch every supported country")
+	city := fs.String("city", "", "gazetteer city instead of the reference city")
+	return func(ctx context.Context, c *feeds.Client, args []string, out *output) error {
+		countries, err := targets(args, *all, *city)
+		if err != nil {
+			return err
+		}
+		results := fetchAll(ctx, countries, func(ctx context.Context, country string) (*feeds.AirQualityData, error) {
+			if *city != "" {
+				return c.FetchAirQualityForCity(ctx, country, *city)
+			}
+			return c.FetchAirQuality(ctx, country)
+		})
+		header := []string{"COUNTRY", "CITY", "AQI", "BAND", "PM2.5", "PM10", "OZONE"}
+		return writeResults(out, results, !*all && len(countries) == 1, header, func(aq *feeds.AirQualityData) []string {
+			return []string{
+				cmp.Or(aq.City, "-"),
+				fmt.Sprint(aq.AQI),
+				aq.Band,
+				fmt.Sprintf("%.1f", aq.PM25),
+				fmt.Sprintf("%.1f", aq.PM10),
+				fmt.Sprintf("%.1f", aq.Ozone),
+			}
+		})
+	}
+}
+
+// countryInfo is one line of reef countries
+type countryInfo struct {
+	Code      string   `json:"code"`
+	Timezone  string   `json:"timezone"`
+	UTCOffset string   `json:"utcOffset"`
+	Cities    []string `json:"cities"`
+}
+
+func countriesCommand(*flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *feeds.Client, args []string, out *output) error {
+		if len(args) > 0 {
+			return fmt.Errorf("%w: countries takes no arguments", errUsage)
+		}
+		clocks, err := c.LocalClocks(ctx)
+		if err != nil {
+			return err
+		}
+		var countries []countryInfo
+		for _, code := range feeds.SupportedCountries() {
+			info := countryInfo{Code: code, Cities: feeds.Cities(code)}
+			if clock, ok := clocks[code]; ok {
+				info.Timezone, info.UTCOffset = clock.Timezone, clock.UTCOffset
+			}
+			countries = append(countries, info)
+		}
+		if out.json {
+			return out.writeJSON(countries)
+		}
+		tw := out.table("CODE", "TIMEZONE", "UTC OFFSET", "CITIES")
+		for _, info := range countries {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.Code, info.Timezone, info.UTCOffset, strings.Join(info.Cities, ", "))
+		}
+		return tw.Flush()
+	}
+}
+
+func feedCommand(*flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *feeds.Client, args []string, out *output) error {
+		if len(args) == 0 {
+			return fmt.Errorf("%w: give a feed name, one of %s", errUsage, strings.Join(feeds.NewRegistry(c).Names(), ", "))
+		}
+		params := feeds.Params{}
+		for _, arg := range args[1:] {
+			k, v, ok := strings.Cut(arg, "=")
+			if !ok {
+				return fmt.Errorf("%w: feed parameter %q is not KEY=VALUE", errUsage, arg)
+			}
+			params[k] = v
+		}
+		value, err := feeds.NewRegistry(c).Fetch(ctx, args[0], params)
+		if err != nil && value == nil {
+			return err
+		}
+		if err != nil {
+			// Batch feeds such as markets return partial results alongside
+			// the errors of the items that failed
+			out.fail(args[0], err)
+		}
+		return out.writeJSON(value)
+	}
+}
@@ -0,0 +1,187 @@
+// Command reef queries the feeds from the terminal, for ops debugging and
+// cron jobs:
+//
+//	reef weather JP SG              current weather as a table
+//	reef weather --all --json       every supported country as JSON
+//	reef aqi SG --city Singapore    air quality of a gazetteer city
+//	reef countries                  supported countries, timezones and cities
+//	reef feed holidays country=JP   any registered feed as JSON
+//
+// Flags may come before or after arguments. The exit status is 0 on
+// success, 1 when any fetch failed and 2 for usage errors such as unknown
+// countries, so scripts can tell bad input from upstream outages.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/logger"
+)
+
+// Exit statuses
+const (
+	exitOK     = 0
+	exitFailed = 1
+	exitUsage  = 2
+)
+
+// errUsage marks errors in how reef was invoked
+var errUsage = errors.New("usage")
+
+// command is a reef subcommand
+type command struct {
+	summary string
+	// setup declares the command's own flags on fs and returns its body,
+	// which runs once fs is parsed
+	setup func(fs *flag.FlagSet) runFunc
+}
+
+// runFunc runs a command on its positional arguments
+type runFunc func(ctx context.Context, c *feeds.Client, args []string, out *output) error
+
+var commands = map[string]command{
+	"weather":   {summary: "current weather: reef weather [--city NAME] [--lang TAG] [--model NAME] COUNTRY... | --all", setup: weatherCommand},
+	"aqi":       {summary: "current air quality: reef aqi [--city NAME] COUNTRY... | --all", setup: airQualityCommand},
+	"countries": {summary: "supported countries: reef countries", setup: countriesCommand},
+	"feed":      {summary: "any registered feed as JSON: reef feed NAME [KEY=VALUE...]", setup: feedCommand},
+}
+
+// commandOrder is the order of commands in the usage message
+var commandOrder = []string{"weather", "aqi", "countries", "feed"}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "reef: unknown command %q\n", args[0])
+		usage(stderr)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("reef "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	timeout := fs.Duration("timeout", 30*time.Second, "give up after this long")
+	verbose := fs.Bool("verbose", false, "log upstream requests to stderr")
+	runCmd := cmd.setup(fs)
+	positional, err := parseInterleaved(fs, args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		return exitUsage
+	}
+
+	// The feeds log every request at debug level; keep stdout for results
+	logger.SetLevel("error")
+	if *verbose {
+		logger.SetLevel("debug")
+	}
+
+	client, err := feeds.NewClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "reef: %v\n", err)
+		return exitFailed
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	out := &output{stdout: stdout, stderr: stderr, json: *asJSON}
+	if err := runCmd(ctx, client, positional, out); err != nil {
+		fmt.Fprintf(stderr, "reef: %v\n", err)
+		return exitCode(err)
+	}
+	return out.status
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: reef COMMAND [--json] [--timeout 30s] [--verbose] ARGS...")
+	fmt.Fprintln(w)
+	for _, name := range commandOrder {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
+
+// parseInterleaved parses fs from args, accepting flags after positional
+// arguments too, and returns the positional arguments
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// exitCode is exitUsage for errors in the input, such as unknown countries
+// or cities, and exitFailed for everything else
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, errUsage),
+		errors.Is(err, feeds.ErrUnknownFeed),
+		errors.Is(err, feeds.ErrUnsupportedCountry),
+		errors.Is(err, feeds.ErrUnknownCity),
+		errors.Is(err, feeds.ErrUnknownRegion),
+		errors.Is(err, feeds.ErrNoRegions),
+		errors.Is(err, feeds.ErrInvalidParams),
+		errors.Is(err, feeds.ErrInvalidForecastDays),
+		errors.Is(err, feeds.ErrInvalidNowcastWindow),
+		errors.Is(err, feeds.ErrUnknownCurrency),
+		errors.Is(err, feeds.ErrInvalidHistoricalDate),
+		errors.Is(err, feeds.ErrUnknownModel),
+		errors.As(err, new(*feeds.ErrInvalidCoordinates)):
+		return exitUsage
+	default:
+		return exitFailed
+	}
+}
+
+// targets returns the countries a weather or aqi invocation asks for,
+// normalized, checking them before anything is fetched
+func targets(args []string, all bool, city string) ([]string, error) {
+	switch {
+	case all && len(args) > 0:
+		return nil, fmt.Errorf("%w: --all takes no countries", errUsage)
+	case all && city != "":
+		return nil, fmt.Errorf("%w: --city needs a single country, not --all", errUsage)
+	case all:
+		return feeds.SupportedCountries(), nil
+	case len(args) == 0:
+		return nil, fmt.Errorf("%w: give at least one country, or --all", errUsage)
+	case city != "" && len(args) > 1:
+		return nil, fmt.Errorf("%w: --city needs a single country", errUsage)
+	}
+	countries := make([]string, 0, len(args))
+	for _, arg := range args {
+		country := strings.ToUpper(strings.TrimSpace(arg))
+		if !feeds.IsSupportedCountry(country) {
+			return nil, fmt.Errorf("%w: %q (see reef countries)", feeds.ErrUnsupportedCountry, arg)
+		}
+		countries = append(countries, country)
+	}
+	return countries, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// output writes results as a table or JSON, and tracks the exit status of
+// fetches that failed without stopping the rest
+type output struct {
+	stdout, stderr io.Writer
+	json           bool
+	status         int
+}
+
+// fail reports a failed fetch of what, a country or feed, on stderr
+func (o *output) fail(what string, err error) {
+	fmt.Fprintf(o.stderr, "reef: %s: %v\n", what, err)
+	o.status = max(o.status, exitCode(err))
+}
+
+// writeJSON writes v as indented JSON
+func (o *output) writeJSON(v any) error {
+	enc := json.NewEncoder(o.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table returns a writer of tab-separated rows, aligned on Flush, with
+// header already written
+func (o *output) table(header ...string) *tabwriter.Writer {
+	tw := tabwriter.NewWriter(o.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	return tw
+}
+
+// result is the outcome of fetching one country
+type result[T any] struct {
+	country string
+	value   T
+	err     error
+}
+
+// fetchAll fetches every country concurrently, returning results in the
+// order of countries
+func fetchAll[T any](ctx context.Context, countries []string, fetch func(ctx context.Context, country string) (T, error)) []result[T] {
+	results := make([]result[T], len(countries))
+	var wg sync.WaitGroup
+	for i, country := range countries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := fetch(ctx, country)
+			results[i] = result[T]{country: country, value: value, err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// writeResults reports failed countries on stderr and writes the rest: as
+// a table with one row per country, or as JSON keyed by country. A single
+// requested country is written as JSON on its own, without the key.
+func writeResults[T any](out *output, results []result[T], single bool, header []string, row func(T) []string) error {
+	succeeded := make(map[string]T, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			out.fail(r.country, r.err)
+			continue
+		}
+		succeeded[r.country] = r.value
+	}
+	if len(succeeded) == 0 {
+		return nil
+	}
+	if out.json {
+		if single {
+			return out.writeJSON(succeeded[results[0].country])
+		}
+		return out.writeJSON(succeeded)
+	}
+	tw := out.table(header...)
+	for _, r := range results {
+		if r.err == nil {
+			fmt.Fprintln(tw, strings.Join(append([]string{r.country}, row(r.value)...), "\t"))
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,327 @@
+// Package alerts notifies webhooks when refreshed feed values cross
+// user-defined thresholds, such as AQI above 150 in ID or any typhoon
+// warning in PH
+package alerts
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/logger"
+)
+
+var (
+	// ErrInvalidRule is returned by AddRule for incomplete or malformed rules
+	ErrInvalidRule = errors.New("invalid alert rule")
+	// ErrUnknownRule is returned for rule IDs that aren't registered
+	ErrUnknownRule = errors.New("unknown alert rule")
+)
+
+// Op compares a feed field with a rule's threshold
+type Op string
+
+const (
+	Above   Op = ">"
+	AtLeast Op = ">="
+	Below   Op = "<"
+	AtMost  Op = "<="
+	// Present holds while the field is set to a non-zero value, or with no
+	// field while a list feed such as typhoons has entries
+	Present Op = "present"
+)
+
+// Rule is a threshold on a refreshed feed. On a feed whose value is a list,
+// such as typhoons, the rule is breached when any entry breaches it.
+type Rule struct {
+	// ID is assigned by AddRule, whatever the caller sets
+	ID   string `json:"id"`
+	Feed string `json:"feed"`
+	// Country limits the rule to one country; empty matches every country
+	Country string `json:"country,omitempty"`
+	// Field is a top-level JSON field of the feed value, e.g. "aqi" or
+	// "temperatureC"; it may only be empty with Present
+	Field     string  `json:"field,omitempty"`
+	Op        Op      `json:"op"`
+	Threshold float64 `json:"threshold,omitempty"`
+	// WebhookURL receives a POST of a Notification when the rule is breached
+	WebhookURL string `json:"webhookUrl"`
+	// Secret is the HMAC-SHA256 key signing each notification
+	Secret string `json:"secret,omitempty"`
+}
+
+// Alerter evaluates rules against refresher updates and notifies their
+// webhooks when a threshold is crossed. A rule notifies once per crossing:
+// it fires when a value breaches it and re-arms only once a later value
+// doesn't. Rules are kept in memory.
+type Alerter struct {
+	refresher *feeds.Refresher
+	client    *http.Client
+	// webhookHosts, when set, are the only hosts webhooks may point at
+	webhookHosts map[string]bool
+
+	mu    sync.Mutex
+	rules map[string]Rule
+	// breached holds the rules and countries whose last value breached them
+	breached map[breachKey]bool
+
+	// deliveries tracks webhook deliveries in flight, which Run waits for
+	deliveries sync.WaitGroup
+}
+
+// breachKey identifies a rule's state for one country
+type breachKey struct {
+	rule, country string
+}
+
+type Option func(*Alerter)
+
+// WithHTTPClient sets the client delivering webhooks (default: 10s timeout,
+// no redirects, and no connections to private addresses unless
+// WithWebhookHosts allows them). A client set here makes its own dialing
+// decisions; AddRule still checks each webhook's host.
+func WithHTTPClient(c *http.Client) Option {
+	return func(a *Alerter) {
+		a.client = c
+	}
+}
+
+// New creates an Alerter for updates of refresher. Nothing is evaluated
+// until Run.
+func New(refresher *feeds.Refresher, options ...Option) *Alerter {
+	a := &Alerter{
+		refresher: refresher,
+		rules:     make(map[string]Rule),
+		breached:  make(map[breachKey]bool),
+	}
+	for _, fn := range options {
+		fn(a)
+	}
+	if a.client == nil {
+		a.client = newWebhookClient(len(a.webhookHosts) > 0)
+	}
+	return a
+}
+
+// AddRule validates and registers r under a new random ID, and returns it
+// with the ID assigned. A caller's ID is ignored, so no client can replace
+// another's rule or its alert state.
+func (a *Alerter) AddRule(r Rule) (Rule, error) {
+	r.Country = strings.ToUpper(strings.TrimSpace(r.Country))
+	if err := r.validate(); err != nil {
+		return Rule{}, err
+	}
+	if err := a.checkWebhook(r.WebhookURL); err != nil {
+		return Rule{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for r.ID = newRuleID(); ; r.ID = newRuleID() {
+		if _, taken := a.rules[r.ID]; !taken {
+			break
+		}
+	}
+	a.rules[r.ID] = r
+	a.resetLocked(r.ID)
+	return r, nil
+}
+
+// RemoveRule unregisters a rule
+func (a *Alerter) RemoveRule(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.rules[id]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownRule, id)
+	}
+	delete(a.rules, id)
+	a.resetLocked(id)
+	return nil
+}
+
+// Rules returns the registered rules sorted by ID
+func (a *Alerter) Rules() []Rule {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.SortedFunc(maps.Values(a.rules), func(x, y Rule) int {
+		return strings.Compare(x.ID, y.ID)
+	})
+}
+
+// resetLocked forgets the breach state of a rule, so a replaced rule is
+// judged afresh
+func (a *Alerter) resetLocked(id string) {
+	for key := range a.breached {
+		if key.rule == id {
+			delete(a.breached, key)
+		}
+	}
+}
+
+// Run evaluates rules on every refresher update until ctx is done. The
+// current snapshots are evaluated first, so thresholds already breached
+// notify at startup. Notifications already fired are still delivered once
+// ctx is done, and Run returns when they have been.
+func (a *Alerter) Run(ctx context.Context) {
+	updates, cancel := a.refresher.Subscribe()
+	defer cancel()
+	defer a.deliveries.Wait()
+	for {
+		select {
+		case u := <-updates:
+			a.evaluate(ctx, u)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// evaluate checks an update against the matching rules, notifying those
+// that crossed their threshold
+func (a *Alerter) evaluate(ctx context.Context, u feeds.Update) {
+	// Rules address fields by their JSON names, as the REST API serves them
+	data, err := json.Marshal(u.Value)
+	if err != nil {
+		logger.Warnf("[alerts] %s %s: %v", u.Feed, u.Country, err)
+		return
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		logger.Warnf("[alerts] %s %s: %v", u.Feed, u.Country, err)
+		return
+	}
+
+	a.mu.Lock()
+	var fired []Notification
+	for _, r := range a.rules {
+		if r.Feed != u.Feed || r.Country != "" && r.Country != u.Country {
+			continue
+		}
+		key := breachKey{rule: r.ID, country: u.Country}
+		breached, observed := r.check(doc)
+		if breached && !a.breached[key] {
+			fired = append(fired, newNotification(r, u, observed, data))
+		}
+		a.breached[key] = breached
+	}
+	a.mu.Unlock()
+
+	// A crossing notifies only once, so its delivery outlives ctx rather
+	// than be lost to a shutdown; the webhook client's timeout bounds it
+	for _, n := range fired {
+		a.deliveries.Add(1)
+		go func() {
+			defer a.deliveries.Done()
+			a.deliver(context.WithoutCancel(ctx), n)
+		}()
+	}
+}
+
+// check reports whether doc, a feed value decoded from JSON, breaches r,
+// with the breaching field value for numeric comparisons
+func (r Rule) check(doc any) (bool, *float64) {
+	items, ok := doc.([]any)
+	if !ok {
+		items = []any{doc}
+	}
+	for _, item := range items {
+		if r.Field == "" {
+			// Present on the value itself: any entry will do
+			return true, nil
+		}
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		v := obj[r.Field]
+		if r.Op == Present {
+			if present(v) {
+				return true, nil
+			}
+			continue
+		}
+		if n, ok := v.(float64); ok && r.compare(n) {
+			return true, &n
+		}
+	}
+	return false, nil
+}
+
+func (r Rule) compare(v float64) bool {
+	switch r.Op {
+	case Above:
+		return v > r.Threshold
+	case AtLeast:
+		return v >= r.Threshold
+	case Below:
+		return v < r.Threshold
+	case AtMost:
+		return v <= r.Threshold
+	}
+	return false
+}
+
+// present reports whether a JSON value is set to something other than its
+// zero value
+func present(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	return true
+}
+
+func (r Rule) validate() error {
+	var errs []error
+	if r.Feed == "" {
+		errs = append(errs, errors.New("feed is required"))
+	}
+	if r.Country != "" && !feeds.IsSupportedCountry(r.Country) {
+		errs = append(errs, fmt.Errorf("%w: %q", feeds.ErrUnsupportedCountry, r.Country))
+	}
+	switch r.Op {
+	case Above, AtLeast, Below, AtMost:
+		if r.Field == "" {
+			errs = append(errs, fmt.Errorf("op %q needs a field", r.Op))
+		}
+	case Present:
+	default:
+		errs = append(errs, fmt.Errorf("op %q is not one of >, >=, <, <= or present", r.Op))
+	}
+	if u, err := url.Parse(r.WebhookURL); err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("webhookUrl %q is not an http(s) URL", r.WebhookURL))
+	}
+	if r.Secret == "" {
+		errs = append(errs, errors.New("secret is required to sign notifications"))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidRule, errors.Join(errs...))
+	}
+	return nil
+}
+
+// newRuleID returns a random rule ID
+func newRuleID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
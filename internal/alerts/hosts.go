@@ -0,0 +1,117 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// resolveTimeout bounds the lookup of a webhook host in AddRule
+const resolveTimeout = 5 * time.Second
+
+// nonPublicPrefixes are the ranges netip's predicates don't cover that
+// webhooks mustn't reach: "this network", shared address space (carrier
+// NAT), IETF protocol assignments and benchmarking
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// WithWebhookHosts limits webhooks to these host names, matched without
+// case. Listed hosts may be internal ones, which are otherwise refused.
+func WithWebhookHosts(hosts ...string) Option {
+	return func(a *Alerter) {
+		for _, h := range hosts {
+			if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+				if a.webhookHosts == nil {
+					a.webhookHosts = make(map[string]bool)
+				}
+				a.webhookHosts[h] = true
+			}
+		}
+	}
+}
+
+// checkWebhook refuses webhook URLs the Alerter mustn't POST to: with
+// WithWebhookHosts, hosts not listed, and otherwise hosts resolving to a
+// loopback, private, link-local or other non-public address, so rules
+// can't make the service reach into its own network
+func (a *Alerter) checkWebhook(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("%w: webhookUrl: %w", ErrInvalidRule, err)
+	}
+	host := strings.ToLower(u.Hostname())
+	if len(a.webhookHosts) > 0 {
+		if !a.webhookHosts[host] {
+			return fmt.Errorf("%w: webhook host %q is not allowed", ErrInvalidRule, host)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: webhook host %q: %w", ErrInvalidRule, host, err)
+	}
+	for _, addr := range addrs {
+		if addr = addr.Unmap(); !isPublic(addr) {
+			return fmt.Errorf("%w: webhook host %q resolves to non-public address %s", ErrInvalidRule, host, addr)
+		}
+	}
+	return nil
+}
+
+// isPublic reports whether addr is a globally reachable unicast address
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsMulticast() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// newWebhookClient returns the default webhook client. It follows no
+// redirects and uses no proxy, so a delivery reaches only the host AddRule
+// checked. Unless hosts are allowlisted it also refuses to connect to
+// non-public addresses, catching hosts whose DNS changed after AddRule.
+func newWebhookClient(allowlisted bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowlisted {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublic(ap.Addr()) {
+				return fmt.Errorf("webhook address %s is not public", ap.Addr())
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/logger"
+)
+
+// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the request
+// body keyed by the rule's Secret. Receivers should recompute it and compare
+// with hmac.Equal before trusting a notification.
+const SignatureHeader = "X-Reef-Signature"
+
+// deliveryAttempts is how often a webhook is tried before giving up, backing
+// off a second longer each time
+const deliveryAttempts = 3
+
+// Notification is the JSON body POSTed to a rule's webhook
+type Notification struct {
+	// Rule is the breached rule, without its Secret
+	Rule    Rule   `json:"rule"`
+	Feed    string `json:"feed"`
+	Country string `json:"country,omitempty"`
+	// Value is the field value that crossed the threshold, absent for Present
+	Value *float64 `json:"value,omitempty"`
+	// Data is the whole feed value the rule was evaluated on
+	Data        json.RawMessage `json:"data"`
+	TriggeredAt time.Time       `json:"triggeredAt"`
+
+	// secret signs the notification
+	secret string
+}
+
+func newNotification(r Rule, u feeds.Update, value *float64, data []byte) Notification {
+	secret := r.Secret
+	r.Secret = ""
+	return Notification{
+		Rule:        r,
+		Feed:        u.Feed,
+		Country:     u.Country,
+		Value:       value,
+		Data:        data,
+		TriggeredAt: time.Now().UTC(),
+		secret:      secret,
+	}
+}
+
+// Sign returns the SignatureHeader value of body under secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver POSTs n to its webhook, retrying network errors and 5xx
+// responses, and logs when delivery fails
+func (a *Alerter) deliver(ctx context.Context, n Notification) {
+	body, err := json.Marshal(n)
+	if err != nil {
+		logger.Warnf("[alerts] rule %s: %v", n.Rule.ID, err)
+		return
+	}
+	for attempt := 1; ; attempt++ {
+		retry, err := a.post(ctx, n.Rule.WebhookURL, n.secret, body)
+		if err == nil {
+			logger.Infof("[alerts] rule %s fired for %s %s", n.Rule.ID, n.Feed, n.Country)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if !retry || attempt == deliveryAttempts {
+			logger.Warnf("[alerts] rule %s webhook failed after %d attempts: %v", n.Rule.ID, attempt, err)
+			return
+		}
+		select {
+		case <-time.After(time.Duration(attempt) * time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// post sends one delivery attempt, reporting whether a failure is worth
+// retrying
+func (a *Alerter) post(ctx context.Context, webhookURL, secret string, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "reef-asia-alerts")
+	req.Header.Set(SignatureHeader, Sign(secret, body))
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode >= 500, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return false, nil
+}
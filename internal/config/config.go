@@ -0,0 +1,334 @@
+// Package config loads deployment settings from a YAML or TOML file, with
+// environment variable overrides, so feeds can be tuned without code changes
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"reef-asia/internal/feeds"
+)
+
+// ErrInvalid is returned by Load for unreadable or inconsistent settings
+var ErrInvalid = errors.New("invalid config")
+
+// globalFeeds are the built-in feeds refreshed without a country, as they
+// cover every country at once
+var globalFeeds = []string{"clock", "earthquakes", "fx", "markets"}
+
+// Config is the deployment configuration. Durations are written like "5m".
+type Config struct {
+	// Feeds are the registry feeds served; empty serves every built-in feed
+	Feeds []string `yaml:"feeds" toml:"feeds"`
+	// Countries are refreshed in the background; empty refreshes every
+	// supported country
+	Countries []string `yaml:"countries" toml:"countries"`
+	// Refresh maps feed names to their background refresh interval, adding
+	// to the defaults; an interval of zero turns a default refresh off. Feeds
+	// not listed are fetched on demand only, and disabled ones not at all.
+	Refresh map[string]time.Duration `yaml:"refresh" toml:"refresh"`
+
+	Cache     CacheConfig     `yaml:"cache" toml:"cache"`
+	Providers ProvidersConfig `yaml:"providers" toml:"providers"`
+	// Timeout bounds each upstream call; zero keeps the client default
+	Timeout time.Duration `yaml:"timeout" toml:"timeout"`
+	// WeatherFields are optional current weather fields to fetch, Open-Meteo
+	// variable names such as "surface_pressure"; see feeds.AllWeatherFields
+	WeatherFields []string `yaml:"weatherFields" toml:"weatherFields"`
+	// Narratives adds a day's outlook and a sentence summing it up to
+	// current weather, at the cost of an hourly forecast in every request
+	Narratives bool `yaml:"narratives" toml:"narratives"`
+	// SchemaVersion is the JSON schema served to HTTP clients that don't ask
+	// for one; 1 keeps clients written before versioning unchanged, and
+	// zero serves the newest
+	SchemaVersion int `yaml:"schemaVersion" toml:"schemaVersion"`
+	// ShutdownTimeout bounds draining requests and stopping the background
+	// components on SIGTERM; keep it under the orchestrator's grace period,
+	// such as Kubernetes' terminationGracePeriodSeconds
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" toml:"shutdownTimeout"`
+
+	Export ExportConfig `yaml:"export" toml:"export"`
+	Admin  AdminConfig  `yaml:"admin" toml:"admin"`
+	Alerts AlertsConfig `yaml:"alerts" toml:"alerts"`
+}
+
+// AdminConfig guards the operator endpoints
+type AdminConfig struct {
+	// Addr is where the admin listener serves /debug/feeds, kept off the
+	// public port; empty turns it off
+	Addr string `yaml:"addr" toml:"addr"`
+	// Token is the bearer token the /v1/alerts endpoints require; empty
+	// turns them off
+	Token string `yaml:"token" toml:"token"`
+}
+
+// AlertsConfig limits where alert rules may send notifications
+type AlertsConfig struct {
+	// WebhookHosts, when set, are the only hosts webhooks may point at,
+	// internal ones included; otherwise they must resolve to public addresses
+	WebhookHosts []string `yaml:"webhookHosts" toml:"webhookHosts"`
+}
+
+// ExportConfig schedules snapshot exports of the refreshed feeds
+type ExportConfig struct {
+	// Dir receives the export files; empty turns exports off
+	Dir string `yaml:"dir" toml:"dir"`
+	// Interval is the time between exports
+	Interval time.Duration `yaml:"interval" toml:"interval"`
+	// Formats are "json" and/or "csv"; empty writes both
+	Formats []string `yaml:"formats" toml:"formats"`
+}
+
+// CacheConfig tunes the client cache
+type CacheConfig struct {
+	// TTL is how long results are reused; zero disables caching
+	TTL time.Duration `yaml:"ttl" toml:"ttl"`
+	// StaleWhileRevalidate serves results this much past TTL while they're
+	// refreshed in the background; zero turns it off
+	StaleWhileRevalidate time.Duration `yaml:"staleWhileRevalidate" toml:"staleWhileRevalidate"`
+	// MaxStaleness serves the last good weather up to this old when a fetch
+	// fails; zero returns the error
+	MaxStaleness time.Duration `yaml:"maxStaleness" toml:"maxStaleness"`
+	// Redis, a URL like "redis://:password@host:6379/0" or "rediss://" for
+	// TLS, shares the weather cache and refreshed feeds between replicas, one
+	// of which refreshes each feed; empty keeps everything per replica
+	Redis string `yaml:"redis" toml:"redis"`
+}
+
+// ProvidersConfig holds upstream provider settings
+type ProvidersConfig struct {
+	// NewsAPIKey adds NewsAPI top headlines to the news feed
+	NewsAPIKey string `yaml:"newsApiKey" toml:"newsApiKey"`
+	// OpenMeteoAPIKey switches to Open-Meteo's commercial customer endpoints
+	OpenMeteoAPIKey string `yaml:"openMeteoApiKey" toml:"openMeteoApiKey"`
+	// Model is the Open-Meteo weather model, e.g. "jma_seamless"
+	Model string `yaml:"model" toml:"model"`
+}
+
+// Default returns the settings used without a config file: every feed,
+// every country, weather, air quality and typhoons kept warm, a 5 minute
+// cache, narratives, 25 seconds to shut down and the admin listener on localhost:8081
+func Default() *Config {
+	return &Config{
+		Refresh: map[string]time.Duration{
+			"weather":    5 * time.Minute,
+			"airquality": 15 * time.Minute,
+			"typhoons":   30 * time.Minute,
+		},
+		Cache:           CacheConfig{TTL: 5 * time.Minute},
+		Narratives:      true,
+		ShutdownTimeout: 25 * time.Second,
+		Export:          ExportConfig{Interval: time.Hour},
+		Admin:           AdminConfig{Addr: "localhost:8081"},
+	}
+}
+
+// Load returns Default overlaid with the file at path, YAML or TOML by its
+// extension, and then the environment (see env.go). An empty path skips the
+// file.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+	}
+	if err := cfg.applyEnv(os.Environ()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	for i, country := range cfg.Countries {
+		cfg.Countries[i] = strings.ToUpper(strings.TrimSpace(country))
+	}
+	maps.DeleteFunc(cfg.Refresh, func(_ string, interval time.Duration) bool { return interval == 0 })
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	// Decoding onto the current settings keeps everything the file leaves
+	// out, including refresh entries it doesn't mention
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), c)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("%s: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported config format %q, want .yaml, .yml or .toml", path, ext)
+	}
+	return nil
+}
+
+func (c *Config) validate() error {
+	var errs []error
+	known := feeds.FeedNames()
+	for _, name := range c.Feeds {
+		if !slices.Contains(known, name) {
+			errs = append(errs, fmt.Errorf("feeds: %w: %q", feeds.ErrUnknownFeed, name))
+		}
+	}
+	for _, country := range c.Countries {
+		if !feeds.IsSupportedCountry(country) {
+			errs = append(errs, fmt.Errorf("countries: %w: %q", feeds.ErrUnsupportedCountry, country))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Refresh)) {
+		switch {
+		case !slices.Contains(known, name):
+			errs = append(errs, fmt.Errorf("refresh: %w: %q", feeds.ErrUnknownFeed, name))
+		case c.Refresh[name] < 0:
+			errs = append(errs, fmt.Errorf("refresh: %s interval must not be negative, got %v", name, c.Refresh[name]))
+		}
+	}
+	for _, f := range c.WeatherFields {
+		if !slices.Contains(feeds.AllWeatherFields, feeds.WeatherField(f)) {
+			errs = append(errs, fmt.Errorf("weatherFields: %w: %q", feeds.ErrUnknownWeatherField, f))
+		}
+	}
+	for _, f := range c.Export.Formats {
+		if !slices.Contains(feeds.ExportFormats, feeds.ExportFormat(f)) {
+			errs = append(errs, fmt.Errorf("export: %w: %q", feeds.ErrUnknownExportFormat, f))
+		}
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("shutdownTimeout: must be positive, got %v", c.ShutdownTimeout))
+	}
+	if c.Export.Dir != "" && c.Export.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("export: interval must be positive, got %v", c.Export.Interval))
+	}
+	if c.Cache.Redis != "" {
+		if _, err := feeds.NewRedisCache(c.Cache.Redis); err != nil {
+			errs = append(errs, fmt.Errorf("cache: %w", err))
+		}
+	}
+	if c.SchemaVersion != 0 && !slices.Contains(feeds.SchemaVersions, feeds.SchemaVersion(c.SchemaVersion)) {
+		errs = append(errs, fmt.Errorf("schemaVersion: %w: %d", feeds.ErrUnknownSchemaVersion, c.SchemaVersion))
+	}
+	if c.Cache.TTL < 0 || c.Cache.StaleWhileRevalidate < 0 || c.Cache.MaxStaleness < 0 || c.Timeout < 0 {
+		errs = append(errs, errors.New("durations must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// ClientOptions returns the feeds.Client options the config asks for
+func (c *Config) ClientOptions() []feeds.Option {
+	options := []feeds.Option{feeds.WithCacheTTL(c.Cache.TTL)}
+	if c.Cache.StaleWhileRevalidate > 0 {
+		options = append(options, feeds.WithStaleWhileRevalidate(c.Cache.StaleWhileRevalidate))
+	}
+	if c.Cache.MaxStaleness > 0 {
+		options = append(options, feeds.WithMaxStaleness(c.Cache.MaxStaleness))
+	}
+	if c.Providers.NewsAPIKey != "" {
+		options = append(options, feeds.WithNewsAPIKey(c.Providers.NewsAPIKey))
+	}
+	if c.Providers.OpenMeteoAPIKey != "" {
+		options = append(options, feeds.WithOpenMeteoAPIKey(c.Providers.OpenMeteoAPIKey))
+	}
+	if c.Providers.Model != "" {
+		options = append(options, feeds.WithModel(c.Providers.Model))
+	}
+	if c.Timeout > 0 {
+		options = append(options, feeds.WithTimeout(c.Timeout))
+	}
+	if len(c.WeatherFields) > 0 {
+		fields := make([]feeds.WeatherField, len(c.WeatherFields))
+		for i, f := range c.WeatherFields {
+			fields[i] = feeds.WeatherField(f)
+		}
+		options = append(options, feeds.WithWeatherFields(fields...))
+	}
+	if c.Narratives {
+		options = append(options, feeds.WithNarratives())
+	}
+	return options
+}
+
+// Registry returns a Registry of client's built-in feeds, limited to the
+// enabled ones
+func (c *Config) Registry(client *feeds.Client) *feeds.Registry {
+	registry := feeds.NewRegistry(client)
+	if len(c.Feeds) == 0 {
+		return registry
+	}
+	for _, name := range registry.Names() {
+		if !slices.Contains(c.Feeds, name) {
+			_ = registry.Unregister(name)
+		}
+	}
+	return registry
+}
+
+// RedisCache returns the Redis cache to share between replicas, or nil when
+// none is configured
+func (c *Config) RedisCache() (*feeds.RedisCache, error) {
+	if c.Cache.Redis == "" {
+		return nil, nil
+	}
+	return feeds.NewRedisCache(c.Cache.Redis)
+}
+
+// ExportFormats returns the configured export formats
+func (c *Config) ExportFormats() []feeds.ExportFormat {
+	formats := make([]feeds.ExportFormat, 0, len(c.Export.Formats))
+	for _, f := range c.Export.Formats {
+		formats = append(formats, feeds.ExportFormat(f))
+	}
+	return formats
+}
+
+// Schema returns the configured schema version, feeds.CurrentSchema when
+// unset
+func (c *Config) Schema() feeds.SchemaVersion {
+	if c.SchemaVersion == 0 {
+		return feeds.CurrentSchema
+	}
+	return feeds.SchemaVersion(c.SchemaVersion)
+}
+
+// RefreshSpecs returns one background refresh per Refresh entry of an
+// enabled feed, per country except for feeds that cover every country at once
+func (c *Config) RefreshSpecs() []feeds.RefreshSpec {
+	countries := c.Countries
+	if len(countries) == 0 {
+		countries = feeds.SupportedCountries()
+	}
+	var specs []feeds.RefreshSpec
+	for _, name := range slices.Sorted(maps.Keys(c.Refresh)) {
+		if len(c.Feeds) > 0 && !slices.Contains(c.Feeds, name) {
+			continue
+		}
+		spec := feeds.RefreshSpec{Feed: name, Interval: c.Refresh[name]}
+		if !slices.Contains(globalFeeds, name) {
+			spec.Countries = countries
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"reef-asia/internal/feeds"
+)
+
+// Environment variables, applied over the config file. Lists are comma
+// separated and durations written like "5m".
+const (
+	// EnvFile names the config file to load; it's read by the caller of Load
+	EnvFile          = "REEF_CONFIG"
+	EnvFeeds         = "REEF_FEEDS"
+	EnvCountries     = "REEF_COUNTRIES"
+	EnvCacheTTL      = "REEF_CACHE_TTL"
+	EnvStaleWindow   = "REEF_CACHE_STALE_WHILE_REVALIDATE"
+	EnvMaxStaleness  = "REEF_CACHE_MAX_STALENESS"
+	EnvRedis         = "REEF_REDIS_URL"
+	EnvNewsAPIKey    = "REEF_NEWSAPI_KEY"
+	EnvOpenMeteoKey  = "REEF_OPENMETEO_API_KEY"
+	EnvModel         = "REEF_MODEL"
+	EnvTimeout       = "REEF_TIMEOUT"
+	EnvWeatherFields = "REEF_WEATHER_FIELDS"
+	EnvNarratives    = "REEF_NARRATIVES"
+	EnvExportDir     = "REEF_EXPORT_DIR"
+	EnvExportEvery   = "REEF_EXPORT_INTERVAL"
+	EnvSchemaVersion = "REEF_SCHEMA_VERSION"
+	EnvShutdown      = "REEF_SHUTDOWN_TIMEOUT"
+	EnvAdminAddr     = "REEF_ADMIN_ADDR"
+	EnvAdminToken    = "REEF_ADMIN_TOKEN"
+	EnvWebhookHosts  = "REEF_ALERT_WEBHOOK_HOSTS"
+	// EnvRefreshPrefix followed by a feed name in capitals sets the feed's
+	// refresh interval, e.g. REEF_REFRESH_AIRQUALITY=10m
+	EnvRefreshPrefix = "REEF_REFRESH_"
+)
+
+// applyEnv overrides settings from env, a list of KEY=VALUE pairs as
+// returned by os.Environ
+func (c *Config) applyEnv(env []string) error {
+	var errs []error
+	duration := func(key, value string, dst *time.Duration) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			return
+		}
+		*dst = d
+	}
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		switch {
+		case key == EnvFeeds:
+			c.Feeds = splitList(value)
+		case key == EnvCountries:
+			c.Countries = splitList(value)
+		case key == EnvCacheTTL:
+			duration(key, value, &c.Cache.TTL)
+		case key == EnvStaleWindow:
+			duration(key, value, &c.Cache.StaleWhileRevalidate)
+		case key == EnvMaxStaleness:
+			duration(key, value, &c.Cache.MaxStaleness)
+		case key == EnvRedis:
+			c.Cache.Redis = value
+		case key == EnvNewsAPIKey:
+			c.Providers.NewsAPIKey = value
+		case key == EnvOpenMeteoKey:
+			c.Providers.OpenMeteoAPIKey = value
+		case key == EnvModel:
+			c.Providers.Model = value
+		case key == EnvTimeout:
+			duration(key, value, &c.Timeout)
+		case key == EnvWeatherFields:
+			c.WeatherFields = splitList(value)
+		case key == EnvNarratives:
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			c.Narratives = v
+		case key == EnvExportDir:
+			c.Export.Dir = value
+		case key == EnvExportEvery:
+			duration(key, value, &c.Export.Interval)
+		case key == EnvSchemaVersion:
+			v, err := feeds.ParseSchemaVersion(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			c.SchemaVersion = int(v)
+		case key == EnvShutdown:
+			duration(key, value, &c.ShutdownTimeout)
+		case key == EnvAdminAddr:
+			c.Admin.Addr = value
+		case key == EnvAdminToken:
+			c.Admin.Token = value
+		case key == EnvWebhookHosts:
+			c.Alerts.WebhookHosts = splitList(value)
+		case strings.HasPrefix(key, EnvRefreshPrefix):
+			var d time.Duration
+			duration(key, value, &d)
+			if c.Refresh == nil {
+				c.Refresh = make(map[string]time.Duration)
+			}
+			c.Refresh[strings.ToLower(strings.TrimPrefix(key, EnvRefreshPrefix))] = d
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// splitList splits a comma-separated list, dropping blank entries
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,139 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// airQualityEndpoint is the Open-Meteo air quality API
+const airQualityEndpoint = "https://air-quality-api.open-meteo.com/v1/air-quality"
+
+// AirQualityData represents current air quality, in µg/m³ for pollutants
+type AirQualityData struct {
+	PM25  float64 `json:"pm25"`
+	PM10  float64 `json:"pm10"`
+	Ozone float64 `json:"ozone"`
+	// AQI is the US EPA air quality index and Band its category, e.g. "Moderate"
+	AQI  int    `json:"aqi"`
+	Band string `json:"band"`
+	// Advice is health guidance by the country's AQI standard, the US EPA's
+	// unless WithAQIStandard or a national default such as India's NAQI
+	// applies; its index can differ from AQI under other standards
+	Advice AirQualityAdvice `json:"advice" schema:"2"`
+
+	// Approximate and City mean the same as on WeatherData
+	Approximate bool   `json:"approximate,omitempty"`
+	City        string `json:"city,omitempty"`
+
+	Provenance
+}
+
+// airQualityResponse represents the current block of an Open-Meteo air quality response
+type airQualityResponse struct {
+	Current struct {
+		// Time is in UTC, as no timezone is requested
+		Time  string   `json:"time"`
+		PM25  *float64 `json:"pm2_5"`
+		PM10  *float64 `json:"pm10"`
+		Ozone *float64 `json:"ozone"`
+		USAQI *float64 `json:"us_aqi"`
+	} `json:"current"`
+}
+
+// aqiBands are the US EPA category upper bounds (inclusive), cleanest first
+var aqiBands = []struct {
+	max  int
+	band string
+}{
+	{50, "Good"},
+	{100, "Moderate"},
+	{150, "Unhealthy for Sensitive Groups"},
+	{200, "Unhealthy"},
+	{300, "Very Unhealthy"},
+}
+
+// AQIBand returns the US EPA category for an AQI value
+func AQIBand(aqi int) string {
+	for _, b := range aqiBands {
+		if aqi <= b.max {
+			return b.band
+		}
+	}
+	return "Hazardous"
+}
+
+// FetchAirQuality calls FetchAirQuality on the default Client
+func FetchAirQuality(ctx context.Context, country string) (*AirQualityData, error) {
+	return defaultClient.FetchAirQuality(ctx, country)
+}
+
+// FetchAirQuality fetches current air quality for a given country's
+// representative city
+func (c *Client) FetchAirQuality(ctx context.Context, country string) (*AirQualityData, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchAirQuality(ctx, coords, country)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, c.cityFor(country)
+	return data, nil
+}
+
+// FetchAirQualityForCity calls FetchAirQualityForCity on the default Client
+func FetchAirQualityForCity(ctx context.Context, country, city string) (*AirQualityData, error) {
+	return defaultClient.FetchAirQualityForCity(ctx, country, city)
+}
+
+// FetchAirQualityForCity fetches current air quality for a city in the
+// bundled gazetteer, as FetchWeatherForCity does for weather
+func (c *Client) FetchAirQualityForCity(ctx context.Context, country, city string) (*AirQualityData, error) {
+	entry, err := lookupCity(country, city)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchAirQuality(ctx, entry.coords, country)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, entry.name
+	return data, nil
+}
+
+// fetchAirQuality fetches current air quality at the given coordinates,
+// advising by country's standard
+func (c *Client) fetchAirQuality(ctx context.Context, coords Coordinates, country string) (*AirQualityData, error) {
+	url := fmt.Sprintf("%s?latitude=%.4f&longitude=%.4f&current=pm2_5,pm10,ozone,us_aqi", airQualityEndpoint, coords.Lat, coords.Lon)
+
+	var apiResp airQualityResponse
+	if err := c.getJSON(ctx, url, c.maxCurrentBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	cur := apiResp.Current
+	if cur.USAQI == nil {
+		return nil, fmt.Errorf("%w: no us_aqi in air quality response", ErrDecode)
+	}
+
+	observedAt, _ := time.Parse(openMeteoTimeLayout, cur.Time)
+	data := &AirQualityData{AQI: int(*cur.USAQI + 0.5), Provenance: c.provenance(sourceOpenMeteo, observedAt)}
+	data.Band = AQIBand(data.AQI)
+	// Only reported pollutants are assessed, rather than missing ones as zero
+	concentrations := make(map[Pollutant]float64)
+	if cur.PM25 != nil {
+		data.PM25 = *cur.PM25
+		concentrations[PollutantPM25] = *cur.PM25
+	}
+	if cur.PM10 != nil {
+		data.PM10 = *cur.PM10
+		concentrations[PollutantPM10] = *cur.PM10
+	}
+	if cur.Ozone != nil {
+		data.Ozone = *cur.Ozone
+		concentrations[PollutantOzone] = *cur.Ozone
+	}
+	data.Advice = c.aqiStandardFor(country).Assess(concentrations)
+	return data, nil
+}
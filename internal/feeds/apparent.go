@@ -0,0 +1,49 @@
+package feeds
+
+import "math"
+
+// Regime boundaries for the computed feels-like temperature
+const (
+	heatIndexMinC     = 27.0
+	windChillMaxC     = 10.0
+	windChillMinWindK = 4.8 // km/h; below this wind chill isn't defined
+)
+
+// apparentTemperature estimates the feels-like temperature in °C from air
+// temperature (°C), relative humidity (%) and wind speed (km/h). It uses the
+// NWS heat index when hot, the North American wind chill index when cold and
+// windy, and the air temperature otherwise. A NaN humidity means it's
+// unknown, in which case no heat index is applied.
+func apparentTemperature(tempC, humidity, windKmh float64) float64 {
+	switch {
+	case tempC >= heatIndexMinC && !math.IsNaN(humidity):
+		return heatIndexC(tempC, humidity)
+	case tempC <= windChillMaxC && windKmh >= windChillMinWindK:
+		return windChillC(tempC, windKmh)
+	default:
+		return tempC
+	}
+}
+
+// heatIndexC implements the NWS Rothfusz regression with its low/high humidity adjustments
+func heatIndexC(tempC, rh float64) float64 {
+	t := tempC*9/5 + 32
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh -
+		0.22475541*t*rh - 0.00683783*t*t -
+		0.05481717*rh*rh + 0.00122874*t*t*rh +
+		0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+
+	switch {
+	case rh < 13 && t >= 80 && t <= 112:
+		hi -= ((13 - rh) / 4) * math.Sqrt((17-math.Abs(t-95))/17)
+	case rh > 85 && t >= 80 && t <= 87:
+		hi += ((rh - 85) / 10) * ((87 - t) / 5)
+	}
+	return (hi - 32) * 5 / 9
+}
+
+// windChillC implements the Environment Canada / NWS wind chill index
+func windChillC(tempC, windKmh float64) float64 {
+	v := math.Pow(windKmh, 0.16)
+	return 13.12 + 0.6215*tempC - 11.37*v + 0.3965*tempC*v
+}
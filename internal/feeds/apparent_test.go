@@ -0,0 +1,54 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestApparentTemperature(t *testing.T) {
+	tests := []struct {
+		name              string
+		tempC, rh, windKh float64
+		// want are NWS heat index and wind chill chart values
+		want float64
+	}{
+		{name: "hot and humid", tempC: 32, rh: 70, windKh: 10, want: 40.5},
+		{name: "hot with unknown humidity", tempC: 32, rh: math.NaN(), windKh: 10, want: 32},
+		{name: "cold and windy", tempC: -10, rh: 50, windKh: 20, want: -18},
+		{name: "cold and calm", tempC: 5, rh: 50, windKh: 2, want: 5},
+		{name: "neutral", tempC: 20, rh: 90, windKh: 30, want: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := apparentTemperature(tt.tempC, tt.rh, tt.windKh); math.Abs(got-tt.want) > 0.5 {
+				t.Errorf("apparentTemperature(%v, %v, %v) = %.2f, want about %v", tt.tempC, tt.rh, tt.windKh, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCurrentComputesFeelsLike(t *testing.T) {
+	c, err := NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		apparent     string
+		want         float64
+		wantComputed bool
+	}{
+		{apparent: `,"apparent_temperature":35.5`, want: 35.5},
+		{apparent: "", want: apparentTemperature(32, 70, 10), wantComputed: true},
+	} {
+		raw := fmt.Sprintf(`{"current":{"time":"2025-01-15T12:00","temperature_2m":32,"relative_humidity_2m":70,"wind_speed_10m":10%s}}`, tt.apparent)
+		data, err := c.decodeCurrent(context.Background(), []byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.FeelsLikeC != tt.want || data.FeelsLikeComputed != tt.wantComputed {
+			t.Errorf("%s: FeelsLikeC = %v, computed %v, want %v, %v", raw, data.FeelsLikeC, data.FeelsLikeComputed, tt.want, tt.wantComputed)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrInvalidAQIStandard is returned for AQI standards whose tables aren't
+// ascending or don't fit their categories
+var ErrInvalidAQIStandard = errors.New("invalid AQI standard")
+
+// Pollutant names a pollutant of AQI breakpoint tables
+type Pollutant string
+
+const (
+	PollutantPM25  Pollutant = "pm25"
+	PollutantPM10  Pollutant = "pm10"
+	PollutantOzone Pollutant = "ozone"
+)
+
+// particulates are the pollutants masks filter
+var particulates = []Pollutant{PollutantPM25, PollutantPM10}
+
+// Breakpoint maps concentrations from Low to High, in µg/m³, linearly onto
+// index values from IndexLow to IndexHigh
+type Breakpoint struct {
+	Low, High           float64
+	IndexLow, IndexHigh int
+}
+
+// AQICategory is a band of index values up to Max (inclusive) and what to
+// do in it
+type AQICategory struct {
+	Max  int    `json:"max"`
+	Name string `json:"name"`
+	// General is advice for everyone and SensitiveGroups for people with
+	// heart or lung disease, children and older adults; either may be empty
+	General         string `json:"general,omitempty"`
+	SensitiveGroups string `json:"sensitiveGroups,omitempty"`
+	// SensitiveGroupWarning, LimitOutdoorExercise and MaskAdvisory set the
+	// flags of AirQualityAdvice; masks are only advised against particulates
+	SensitiveGroupWarning bool `json:"sensitiveGroupWarning,omitempty"`
+	LimitOutdoorExercise  bool `json:"limitOutdoorExercise,omitempty"`
+	MaskAdvisory          bool `json:"maskAdvisory,omitempty"`
+}
+
+// AQIStandard is an air quality index as data: breakpoint tables per
+// pollutant and the categories the index falls into, so national standards
+// can be added or tuned without code. Concentrations are in µg/m³, as
+// Open-Meteo reports them, so standards written in ppb need converting.
+type AQIStandard struct {
+	Name        string
+	Breakpoints map[Pollutant][]Breakpoint
+	// Categories are ordered cleanest first; the last covers every index
+	// up to the highest IndexHigh
+	Categories []AQICategory
+	// MaskGuidance is the recommendation added when a mask is advised
+	MaskGuidance string
+}
+
+// AirQualityAdvice is what an AQIStandard makes of measured concentrations
+type AirQualityAdvice struct {
+	Standard string `json:"standard"`
+	// Index is the highest pollutant sub-index, from DominantPollutant
+	Index             int               `json:"index"`
+	Category          string            `json:"category"`
+	DominantPollutant Pollutant         `json:"dominantPollutant,omitempty"`
+	SubIndices        map[Pollutant]int `json:"subIndices"`
+
+	SensitiveGroupWarning bool `json:"sensitiveGroupWarning"`
+	LimitOutdoorExercise  bool `json:"limitOutdoorExercise"`
+	MaskAdvisory          bool `json:"maskAdvisory"`
+	// Recommendations are the category's guidance as sentences, general
+	// advice first
+	Recommendations []string `json:"recommendations"`
+}
+
+// USEPAStandard is the US EPA AQI, with the 2024 PM2.5 breakpoints and the
+// 8-hour ozone table converted from ppb at 1.96 µg/m³ per ppb, which tops
+// out at Very Unhealthy
+var USEPAStandard = AQIStandard{
+	Name: "US EPA",
+	Breakpoints: map[Pollutant][]Breakpoint{
+		PollutantPM25: {
+			{0, 9.0, 0, 50},
+			{9.1, 35.4, 51, 100},
+			{35.5, 55.4, 101, 150},
+			{55.5, 125.4, 151, 200},
+			{125.5, 225.4, 201, 300},
+			{225.5, 325.4, 301, 500},
+		},
+		PollutantPM10: {
+			{0, 54, 0, 50},
+			{55, 154, 51, 100},
+			{155, 254, 101, 150},
+			{255, 354, 151, 200},
+			{355, 424, 201, 300},
+			{425, 604, 301, 500},
+		},
+		PollutantOzone: {
+			{0, 106, 0, 50},
+			{108, 137, 51, 100},
+			{139, 167, 101, 150},
+			{169, 206, 151, 200},
+			{208, 392, 201, 300},
+		},
+	},
+	Categories: []AQICategory{
+		{Max: 50, Name: "Good", General: "Air quality is good; enjoy outdoor activities."},
+		{Max: 100, Name: "Moderate",
+			SensitiveGroups: "Unusually sensitive people should consider reducing prolonged or heavy exertion outdoors."},
+		{Max: 150, Name: "Unhealthy for Sensitive Groups",
+			General:               "It's fine to be active outside.",
+			SensitiveGroups:       "Sensitive groups should reduce prolonged or heavy exertion outdoors and take more breaks.",
+			SensitiveGroupWarning: true},
+		{Max: 200, Name: "Unhealthy",
+			General:               "Reduce prolonged or heavy exertion outdoors and take more breaks.",
+			SensitiveGroups:       "Sensitive groups should avoid prolonged or heavy exertion; move activities indoors or reschedule.",
+			SensitiveGroupWarning: true, LimitOutdoorExercise: true, MaskAdvisory: true},
+		{Max: 300, Name: "Very Unhealthy",
+			General:               "Avoid prolonged or heavy exertion outdoors; consider moving activities indoors.",
+			SensitiveGroups:       "Sensitive groups should avoid all physical activity outdoors.",
+			SensitiveGroupWarning: true, LimitOutdoorExercise: true, MaskAdvisory: true},
+		{Max: 500, Name: "Hazardous",
+			General:               "Avoid all physical activity outdoors.",
+			SensitiveGroups:       "Sensitive groups should stay indoors and keep activity levels low.",
+			SensitiveGroupWarning: true, LimitOutdoorExercise: true, MaskAdvisory: true},
+	},
+	MaskGuidance: "Wear a well-fitting N95 or KN95 mask outdoors.",
+}
+
+// IndiaNAQIStandard is India's National Air Quality Index (CPCB). Severe
+// has no upper concentration; its table ends where CPCB's calculator stops,
+// and anything above scores 500.
+var IndiaNAQIStandard = AQIStandard{
+	Name: "India NAQI",
+	Breakpoints: map[Pollutant][]Breakpoint{
+		PollutantPM25: {
+			{0, 30, 0, 50},
+			{31, 60, 51, 100},
+			{61, 90, 101, 200},
+			{91, 120, 201, 300},
+			{121, 250, 301, 400},
+			{251, 380, 401, 500},
+		},
+		PollutantPM10: {
+			{0, 50, 0, 50},
+			{51, 100, 51, 100},
+			{101, 250, 101, 200},
+			{251, 350, 201, 300},
+			{351, 430, 301, 400},
+			{431, 510, 401, 500},
+		},
+		PollutantOzone: {
+			{0, 50, 0, 50},
+			{51, 100, 51, 100},
+			{101, 168, 101, 200},
+			{169, 208, 201, 300},
+			{209, 748, 301, 400},
+			{749, 1000, 401, 500},
+		},
+	},
+	Categories: []AQICategory{
+		{Max: 50, Name: "Good", General: "Minimal impact; enjoy outdoor activities."},
+		{Max: 100, Name: "Satisfactory",
+			SensitiveGroups: "Sensitive people may feel minor breathing discomfort."},
+		{Max: 200, Name: "Moderately Polluted",
+			SensitiveGroups:       "People with asthma or heart disease, children and older adults should limit prolonged exertion outdoors.",
+			SensitiveGroupWarning: true},
+		{Max: 300, Name: "Poor",
+			General:               "Most people may feel breathing discomfort on prolonged exposure; limit exertion outdoors.",
+			SensitiveGroups:       "Sensitive groups should avoid outdoor exertion.",
+			SensitiveGroupWarning: true, LimitOutdoorExercise: true, MaskAdvisory: true},
+		{Max: 400, Name: "Very Poor",
+			General:               "Prolonged exposure may cause respiratory illness; avoid exertion outdoors.",
+			SensitiveGroups:       "Sensitive groups should stay indoors.",
+			SensitiveGroupWarning: true, LimitOutdoorExercise: true, MaskAdvisory: true},
+		{Max: 500, Name: "Severe",
+			General:               "Affects healthy people; avoid all outdoor activity.",
+			SensitiveGroups:       "Sensitive groups should stay indoors and keep activity levels low.",
+			SensitiveGroupWarning: true, LimitOutdoorExercise: true, MaskAdvisory: true},
+	},
+	MaskGuidance: "Wear a well-fitting N95 mask outdoors.",
+}
+
+// defaultAQIStandards are the national standards used by country; others
+// use USEPAStandard
+var defaultAQIStandards = map[string]AQIStandard{
+	"IN": IndiaNAQIStandard,
+}
+
+// WithAQIStandard assesses air quality in the given countries with std, or
+// in every country without its own standard when none are given. An
+// invalid std fails NewClient with ErrInvalidConfig wrapping
+// ErrInvalidAQIStandard.
+func WithAQIStandard(std AQIStandard, countries ...string) Option {
+	return func(c *Client) {
+		if len(countries) == 0 {
+			c.aqiStandard = &std
+			return
+		}
+		if c.aqiStandards == nil {
+			c.aqiStandards = maps.Clone(defaultAQIStandards)
+		}
+		for _, country := range countries {
+			c.aqiStandards[normalizeCountry(country)] = std
+		}
+	}
+}
+
+// aqiStandardFor returns the standard air quality in country is assessed by
+func (c *Client) aqiStandardFor(country string) AQIStandard {
+	standards := c.aqiStandards
+	if standards == nil {
+		standards = defaultAQIStandards
+	}
+	if std, ok := standards[normalizeCountry(country)]; ok {
+		return std
+	}
+	if c.aqiStandard != nil {
+		return *c.aqiStandard
+	}
+	return USEPAStandard
+}
+
+// checkAQIStandards validates the configured standards
+func (c *Client) checkAQIStandards() error {
+	var errs []error
+	if c.aqiStandard != nil {
+		errs = append(errs, c.aqiStandard.Validate())
+	}
+	for _, country := range slices.Sorted(maps.Keys(c.aqiStandards)) {
+		if err := c.aqiStandards[country].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", country, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate reports tables that aren't ascending, indices no category
+// covers and a missing name or categories, wrapping ErrInvalidAQIStandard
+func (s AQIStandard) Validate() error {
+	var errs []error
+	if s.Name == "" {
+		errs = append(errs, errors.New("no name"))
+	}
+	if len(s.Categories) == 0 {
+		errs = append(errs, errors.New("no categories"))
+	}
+	for i := 1; i < len(s.Categories); i++ {
+		if s.Categories[i].Max <= s.Categories[i-1].Max {
+			errs = append(errs, fmt.Errorf("category %q max %d not above %d", s.Categories[i].Name, s.Categories[i].Max, s.Categories[i-1].Max))
+		}
+	}
+	for _, p := range slices.Sorted(maps.Keys(s.Breakpoints)) {
+		table := s.Breakpoints[p]
+		if len(table) == 0 {
+			errs = append(errs, fmt.Errorf("%s: empty table", p))
+		}
+		for i, bp := range table {
+			if bp.High < bp.Low || bp.IndexHigh < bp.IndexLow {
+				errs = append(errs, fmt.Errorf("%s: breakpoint %d decreasing", p, i))
+			}
+			if i > 0 && (bp.Low < table[i-1].High || bp.IndexLow < table[i-1].IndexHigh) {
+				errs = append(errs, fmt.Errorf("%s: breakpoint %d overlaps the one before", p, i))
+			}
+			if len(s.Categories) > 0 && bp.IndexHigh > s.Categories[len(s.Categories)-1].Max {
+				errs = append(errs, fmt.Errorf("%s: index %d above the last category", p, bp.IndexHigh))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w %q: %w", ErrInvalidAQIStandard, s.Name, errors.Join(errs...))
+	}
+	return nil
+}
+
+// Assess rates concentrations in µg/m³ by the standard. Pollutants without
+// a table are ignored, and concentrations above a table score its top index.
+func (s AQIStandard) Assess(concentrations map[Pollutant]float64) AirQualityAdvice {
+	advice := AirQualityAdvice{Standard: s.Name, SubIndices: make(map[Pollutant]int)}
+	for _, p := range slices.Sorted(maps.Keys(concentrations)) {
+		table, ok := s.Breakpoints[p]
+		if !ok || len(table) == 0 {
+			continue
+		}
+		index := subIndex(table, concentrations[p])
+		advice.SubIndices[p] = index
+		if advice.DominantPollutant == "" || index > advice.Index {
+			advice.Index, advice.DominantPollutant = index, p
+		}
+	}
+
+	category := s.categoryFor(advice.Index)
+	advice.Category = category.Name
+	advice.SensitiveGroupWarning = category.SensitiveGroupWarning
+	advice.LimitOutdoorExercise = category.LimitOutdoorExercise
+	advice.MaskAdvisory = category.MaskAdvisory && slices.Contains(particulates, advice.DominantPollutant)
+	for _, r := range []string{category.General, category.SensitiveGroups} {
+		if r != "" {
+			advice.Recommendations = append(advice.Recommendations, r)
+		}
+	}
+	if advice.MaskAdvisory && s.MaskGuidance != "" {
+		advice.Recommendations = append(advice.Recommendations, s.MaskGuidance)
+	}
+	return advice
+}
+
+// subIndex interpolates a concentration in its breakpoint. Values in the
+// gap between one breakpoint's High and the next's Low count as the next's
+// Low, as the EPA truncates concentrations before looking them up.
+func subIndex(table []Breakpoint, concentration float64) int {
+	for _, bp := range table {
+		if concentration > bp.High {
+			continue
+		}
+		c := max(concentration, bp.Low)
+		if bp.High == bp.Low {
+			return bp.IndexHigh
+		}
+		return int(float64(bp.IndexHigh-bp.IndexLow)/(bp.High-bp.Low)*(c-bp.Low) + float64(bp.IndexLow) + 0.5)
+	}
+	return table[len(table)-1].IndexHigh
+}
+
+// categoryFor returns the category of an index, the last for indices above
+// every Max
+func (s AQIStandard) categoryFor(index int) AQICategory {
+	if len(s.Categories) == 0 {
+		return AQICategory{}
+	}
+	for _, c := range s.Categories {
+		if index <= c.Max {
+			return c
+		}
+	}
+	return s.Categories[len(s.Categories)-1]
+}
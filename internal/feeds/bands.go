@@ -0,0 +1,91 @@
+package feeds
+
+import (
+	"context"
+	"time"
+)
+
+// feelsLikeBands are the lower bounds (inclusive, °C) of each feels-like
+// band, warmest first. They're tuned for Asian climates, where the high 20s
+// are ordinary and 35°C and above is oppressive:
+//
+//	Sweltering  >= 35
+//	Hot         29 to 35
+//	Warm        24 to 29
+//	Mild        18 to 24
+//	Cool        10 to 18
+//	Cold         0 to 10
+//	Freezing     < 0
+var feelsLikeBands = []struct {
+	min  float64
+	band string
+}{
+	{35, "Sweltering"},
+	{29, "Hot"},
+	{24, "Warm"},
+	{18, "Mild"},
+	{10, "Cool"},
+	{0, "Cold"},
+}
+
+// FeelsLikeBand describes FeelsLikeC in words, from "Freezing" to "Sweltering"
+func (w *WeatherData) FeelsLikeBand() string {
+	return feelsLikeBand(w.FeelsLikeC)
+}
+
+// feelsLikeBand returns the band containing a feels-like temperature in °C
+func feelsLikeBand(celsius float64) string {
+	for _, b := range feelsLikeBands {
+		if celsius >= b.min {
+			return b.band
+		}
+	}
+	return "Freezing"
+}
+
+// ComfortTransition is a change of feels-like band during the day
+type ComfortTransition struct {
+	// Time is the local start of the first hour in the new band
+	Time time.Time `json:"time"`
+	From string    `json:"from"`
+	Band string    `json:"band"`
+}
+
+// ComfortTransitions calls ComfortTransitions on the default Client
+func ComfortTransitions(ctx context.Context, country string) ([]ComfortTransition, error) {
+	return defaultClient.ComfortTransitions(ctx, country)
+}
+
+// ComfortTransitions returns the local times today when the hourly feels-like
+// temperature moves into a different FeelsLikeBand, e.g. from "Warm" to "Hot"
+func (c *Client) ComfortTransitions(ctx context.Context, country string) ([]ComfortTransition, error) {
+	apiResp, err := c.fetchHourly(ctx, country)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := apiResp.entries(c.forecastLimits)
+	if err != nil {
+		return nil, err
+	}
+	return comfortTransitions(entries, time.Now()), nil
+}
+
+// comfortTransitions walks the entries on the local day containing now
+func comfortTransitions(entries []hourlyEntry, now time.Time) []ComfortTransition {
+	loc := entries[0].time.Location()
+	y, m, d := now.In(loc).Date()
+
+	var transitions []ComfortTransition
+	prev := ""
+	for _, e := range entries {
+		if ey, em, ed := e.time.Date(); ey != y || em != m || ed != d {
+			continue
+		}
+		band := feelsLikeBand(e.feelsLikeC)
+		if prev != "" && band != prev {
+			transitions = append(transitions, ComfortTransition{Time: e.time, From: prev, Band: band})
+		}
+		prev = band
+	}
+	return transitions
+}
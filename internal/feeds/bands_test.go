@@ -0,0 +1,93 @@
+package feeds_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestFeelsLikeBand(t *testing.T) {
+	tests := []struct {
+		feelsLike float64
+		want      string
+	}{
+		{feelsLike: -10, want: "Freezing"},
+		{feelsLike: -0.1, want: "Freezing"},
+		{feelsLike: 0, want: "Cold"},
+		{feelsLike: 9.9, want: "Cold"},
+		{feelsLike: 10, want: "Cool"},
+		{feelsLike: 17.9, want: "Cool"},
+		{feelsLike: 18, want: "Mild"},
+		{feelsLike: 23.9, want: "Mild"},
+		{feelsLike: 24, want: "Warm"},
+		{feelsLike: 28.9, want: "Warm"},
+		{feelsLike: 29, want: "Hot"},
+		{feelsLike: 34.9, want: "Hot"},
+		{feelsLike: 35, want: "Sweltering"},
+		{feelsLike: 45, want: "Sweltering"},
+	}
+	for _, tt := range tests {
+		w := &feeds.WeatherData{FeelsLikeC: tt.feelsLike}
+		if got := w.FeelsLikeBand(); got != tt.want {
+			t.Errorf("FeelsLikeBand at %v°C = %q, want %q", tt.feelsLike, got, tt.want)
+		}
+	}
+}
+
+func TestComfortTransitions(t *testing.T) {
+	// A warming day in Bangkok, after a cool hour the night before that
+	// must not count as a transition
+	bangkok := time.FixedZone("+07", 7*60*60)
+	y, m, d := time.Now().In(bangkok).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, bangkok)
+
+	times := []string{today.Add(-time.Hour).Format("2006-01-02T15:04")}
+	feelsLike := []float64{12}
+	for h := range 24 {
+		times = append(times, today.Add(time.Duration(h)*time.Hour).Format("2006-01-02T15:04"))
+		switch {
+		case h < 9:
+			feelsLike = append(feelsLike, 20)
+		case h < 12:
+			feelsLike = append(feelsLike, 25)
+		case h < 16:
+			feelsLike = append(feelsLike, 30)
+		case h < 18:
+			feelsLike = append(feelsLike, 36)
+		default:
+			feelsLike = append(feelsLike, 26)
+		}
+	}
+	codes := make([]int, len(times))
+	hourly, _ := json.Marshal(map[string]any{
+		"time": times, "temperature_2m": feelsLike, "apparent_temperature": feelsLike, "weather_code": codes,
+	})
+	body := `{"timezone":"Asia/Bangkok","timezone_abbreviation":"+07","utc_offset_seconds":25200,"hourly":` + string(hourly) + `}`
+
+	got, err := forecastServer(t, body).Client(t).ComfortTransitions(context.Background(), "TH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		hour       int
+		from, band string
+	}{
+		{hour: 9, from: "Mild", band: "Warm"},
+		{hour: 12, from: "Warm", band: "Hot"},
+		{hour: 16, from: "Hot", band: "Sweltering"},
+		{hour: 18, from: "Sweltering", band: "Warm"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d transitions %+v, want %d", len(got), got, len(want))
+	}
+	for i, w := range want {
+		at := today.Add(time.Duration(w.hour) * time.Hour)
+		if !got[i].Time.Equal(at) || got[i].From != w.from || got[i].Band != w.band {
+			t.Errorf("transition %d = %s %s to %s, want %s %s to %s", i,
+				got[i].Time.Format(time.Kitchen), got[i].From, got[i].Band, at.Format(time.Kitchen), w.from, w.band)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+var (
+	// ErrAllFailed is returned by batch fetches when no country succeeded
+	ErrAllFailed = errors.New("all weather fetches failed")
+	// ErrPartialFailure is returned by batch fetches when some, but not all,
+	// countries failed; the successful results are still returned
+	ErrPartialFailure = errors.New("some weather fetches failed")
+)
+
+// FetchWeatherMulti calls FetchWeatherMulti on the default Client
+func FetchWeatherMulti(ctx context.Context, countries []string) (map[string]*WeatherData, error) {
+	return defaultClient.FetchWeatherMulti(ctx, countries)
+}
+
+// FetchWeatherMulti fetches weather for several countries concurrently,
+// keyed by the country codes as given. The error is nil when every fetch
+// succeeded, wraps ErrPartialFailure when only some failed and
+// ErrAllFailed when all did; either way it also wraps each per-country error.
+func (c *Client) FetchWeatherMulti(ctx context.Context, countries []string) (map[string]*WeatherData, error) {
+	results := make(map[string]*WeatherData, len(countries))
+	var errs []error
+	for res := range c.FetchWeatherStream(ctx, countries) {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.Country, res.Err))
+			continue
+		}
+		results[res.Country] = res.Data
+	}
+	return results, batchError(len(results), errs)
+}
+
+// FetchAllSupported calls FetchAllSupported on the default Client
+func FetchAllSupported(ctx context.Context) (map[string]*WeatherData, error) {
+	return defaultClient.FetchAllSupported(ctx)
+}
+
+// FetchAllSupported fetches every supported country concurrently, as
+// FetchWeatherMulti does for SupportedCountries. It's the intended way to
+// populate a regional overview.
+func (c *Client) FetchAllSupported(ctx context.Context) (map[string]*WeatherData, error) {
+	return c.FetchWeatherMulti(ctx, SupportedCountries())
+}
+
+// OrderedResults returns batch results sorted by country code, for stable
+// rendering of a FetchWeatherMulti or FetchAllSupported map
+func OrderedResults(results map[string]*WeatherData) []WeatherResult {
+	ordered := make([]WeatherResult, 0, len(results))
+	for _, country := range slices.Sorted(maps.Keys(results)) {
+		ordered = append(ordered, WeatherResult{Country: country, Data: results[country]})
+	}
+	return ordered
+}
+
+// batchError classifies per-item failures as all-failed or partial
+func batchError(succeeded int, errs []error) error {
+	switch {
+	case len(errs) == 0:
+		return nil
+	case succeeded == 0:
+		return fmt.Errorf("%w: %w", ErrAllFailed, errors.Join(errs...))
+	default:
+		return fmt.Errorf("%w: %w", ErrPartialFailure, errors.Join(errs...))
+	}
+}
@@ -0,0 +1,115 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestFetchWeatherMulti(t *testing.T) {
+	ok := countryWeather{TempC: 30, Code: 1}
+	failed := countryWeather{Status: http.StatusServiceUnavailable}
+	tests := []struct {
+		name    string
+		weather map[string]countryWeather
+		wantOK  []string
+		wantErr error
+	}{
+		{
+			name:    "all succeed",
+			weather: map[string]countryWeather{"JP": ok, "SG": ok, "TH": ok},
+			wantOK:  []string{"JP", "SG", "TH"},
+		},
+		{
+			name:    "some fail",
+			weather: map[string]countryWeather{"JP": ok, "SG": failed, "TH": ok},
+			wantOK:  []string{"JP", "TH"},
+			wantErr: feeds.ErrPartialFailure,
+		},
+		{
+			name:    "all fail",
+			weather: map[string]countryWeather{"JP": failed, "SG": failed, "TH": failed},
+			wantErr: feeds.ErrAllFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := stubClient(t, countryTransport(t, tt.weather))
+
+			results, err := c.FetchWeatherMulti(context.Background(), []string{"JP", "SG", "TH"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, feeds.ErrProviderUnavailable) {
+				t.Errorf("err = %v, want it to wrap the per-country errors", err)
+			}
+			if len(results) != len(tt.wantOK) {
+				t.Errorf("got %d results, want %v", len(results), tt.wantOK)
+			}
+			for _, country := range tt.wantOK {
+				if data := results[country]; data == nil || data.TemperatureC != 30 {
+					t.Errorf("%s: got %+v, want 30°C", country, data)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchAllSupported(t *testing.T) {
+	countries := feeds.SupportedCountries()
+	weather := make(map[string]countryWeather, len(countries))
+	for _, country := range countries {
+		weather[country] = countryWeather{TempC: 30, Code: 1}
+	}
+	c := stubClient(t, countryTransport(t, weather))
+
+	results, err := c.FetchAllSupported(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(countries) {
+		t.Errorf("got %d results, want all %d supported countries", len(results), len(countries))
+	}
+	for _, country := range countries {
+		if data := results[country]; data == nil || data.TemperatureC != 30 {
+			t.Errorf("%s: got %+v, want 30°C", country, data)
+		}
+	}
+
+	// One failing country is reported without losing the rest
+	weather["JP"] = countryWeather{Status: http.StatusServiceUnavailable}
+	c = stubClient(t, countryTransport(t, weather))
+	results, err = c.FetchAllSupported(context.Background())
+	if !errors.Is(err, feeds.ErrPartialFailure) {
+		t.Fatalf("err = %v, want ErrPartialFailure", err)
+	}
+	if _, ok := results["JP"]; ok || len(results) != len(countries)-1 {
+		t.Errorf("got %d results including JP = %v, want every country but JP", len(results), ok)
+	}
+}
+
+func TestOrderedResults(t *testing.T) {
+	results := map[string]*feeds.WeatherData{
+		"VN": {TemperatureC: 28},
+		"JP": {TemperatureC: 9},
+		"SG": {TemperatureC: 31},
+		"KR": {TemperatureC: 2},
+	}
+	ordered := feeds.OrderedResults(results)
+
+	want := []string{"JP", "KR", "SG", "VN"}
+	if len(ordered) != len(want) {
+		t.Fatalf("got %d results, want %d", len(ordered), len(want))
+	}
+	for i, res := range ordered {
+		if res.Country != want[i] || res.Data != results[want[i]] {
+			t.Errorf("result %d = %s %+v, want %s with its data", i, res.Country, res.Data, want[i])
+		}
+	}
+	if got := feeds.OrderedResults(nil); len(got) != 0 {
+		t.Errorf("OrderedResults(nil) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,153 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting upstream while a provider's
+// circuit breaker is open
+var ErrCircuitOpen = fmt.Errorf("circuit breaker open: %w", ErrProviderUnavailable)
+
+// BreakerSettings controls the per-provider circuit breaker. After
+// FailureThreshold consecutive failed requests to a host the breaker opens and
+// requests fail fast with ErrCircuitOpen; after OpenDuration one probe request
+// is let through (half-open), which closes the breaker on success and reopens
+// it on failure. A FailureThreshold of zero disables the breaker.
+type BreakerSettings struct {
+	FailureThreshold int
+	OpenDuration     time.Duration
+}
+
+// DefaultBreakerSettings opens after 5 consecutive failures for 30 seconds
+var DefaultBreakerSettings = BreakerSettings{FailureThreshold: 5, OpenDuration: 30 * time.Second}
+
+// WithCircuitBreaker replaces DefaultBreakerSettings
+func WithCircuitBreaker(s BreakerSettings) Option {
+	return func(c *Client) {
+		c.breakers.settings = s
+	}
+}
+
+// breakerState is the state of one provider's breaker
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// String returns the state as reported by Client.Health
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// breaker tracks consecutive failures for one host
+type breaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// breakerSet holds a breaker per upstream host, so an outage of one provider
+// doesn't block the others
+type breakerSet struct {
+	mu       sync.Mutex
+	settings BreakerSettings
+	hosts    map[string]*breaker
+}
+
+func newBreakerSet() *breakerSet {
+	return &breakerSet{settings: DefaultBreakerSettings, hosts: make(map[string]*breaker)}
+}
+
+// allow reports whether a request to host may proceed
+func (s *breakerSet) allow(host string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.hosts[host]
+	if b == nil || b.state == breakerClosed {
+		return nil
+	}
+	if b.state == breakerOpen && time.Since(b.openedAt) >= s.settings.OpenDuration {
+		b.state = breakerHalfOpen
+		return nil
+	}
+	// Open, or half-open with the probe still in flight
+	return fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+}
+
+// record updates host's breaker with a request outcome; requests that ended
+// for reasons unrelated to upstream health only release a half-open probe
+func (s *breakerSet) record(host string, failed, neutral bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.hosts[host]
+	if b == nil {
+		b = &breaker{}
+		s.hosts[host] = b
+	}
+	switch {
+	case neutral:
+		if b.state == breakerHalfOpen {
+			b.state = breakerOpen
+		}
+	case !failed:
+		b.state, b.failures = breakerClosed, 0
+	case b.state == breakerHalfOpen:
+		b.state, b.openedAt = breakerOpen, time.Now()
+	default:
+		b.failures++
+		if b.failures >= s.settings.FailureThreshold {
+			b.state, b.openedAt = breakerOpen, time.Now()
+		}
+	}
+}
+
+// states copies every host's breaker
+func (s *breakerSet) states() map[string]breaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make(map[string]breaker, len(s.hosts))
+	for host, b := range s.hosts {
+		states[host] = *b
+	}
+	return states
+}
+
+// fetchBodyGuarded calls fetchBodyRetrying unless the host's breaker is
+// open. Network errors and retryable statuses count as failures once retries
+// are exhausted; other errors mean the provider is up, and cancellations and
+// local rate limiting say nothing either way.
+func (c *Client) fetchBodyGuarded(ctx context.Context, rawURL string, maxBytes int64) ([]byte, error) {
+	if c.breakers.settings.FailureThreshold <= 0 {
+		return c.fetchBodyRetrying(ctx, rawURL, maxBytes)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build weather request: %w", err)
+	}
+	if err := c.breakers.allow(u.Host); err != nil {
+		return nil, err
+	}
+
+	body, err := c.fetchBodyRetrying(ctx, rawURL, maxBytes)
+	neutral := err != nil && (ctx.Err() != nil || errors.Is(err, ErrRateLimited) && !errors.As(err, new(*ProviderError)))
+	c.breakers.record(u.Host, err != nil && c.retryable(ctx, err), neutral)
+	return body, err
+}
@@ -0,0 +1,37 @@
+package feeds
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer is the largest read buffer kept for reuse; the odd bigger
+// one, from a long forecast, is left to the garbage collector
+const maxPooledBuffer = 1 << 20
+
+// bodyBuffers recycles the buffers response bodies are read into, so a
+// refresh of every country doesn't grow a fresh one per request
+var bodyBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// readBody reads at most limit bytes of r through a pooled buffer, presized
+// from sizeHint, a Content-Length or -1 when unknown. The body returned is
+// its own copy, sized exactly, so callers may keep it.
+func readBody(r io.Reader, sizeHint, limit int64) ([]byte, error) {
+	buf := bodyBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			bodyBuffers.Put(buf)
+		}
+	}()
+
+	if sizeHint > 0 && sizeHint <= limit {
+		// ReadFrom wants MinRead spare bytes to read EOF without growing
+		buf.Grow(int(sizeHint) + bytes.MinRead)
+	}
+	if _, err := buf.ReadFrom(io.LimitReader(r, limit)); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
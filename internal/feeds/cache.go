@@ -0,0 +1,198 @@
+package feeds
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// ErrCache wraps cache backend errors surfaced under WithStrictCache
+var ErrCache = errors.New("weather cache error")
+
+// Cache stores current-conditions results between fetches. Keys are
+// "weather:" followed by the full Open-Meteo request URL, so they're stable
+// across processes and differ whenever the request would. A miss is
+// (nil, false, nil); errors are for backend failures such as an unreachable
+// Redis, and are logged and skipped unless WithStrictCache is set.
+type Cache interface {
+	Get(key string) (*WeatherData, bool, error)
+	Set(key string, data *WeatherData, ttl time.Duration) error
+}
+
+// WasCacheHit reports whether data came from the Client's Cache rather than
+// a fetch, for callers caching results themselves
+func WasCacheHit(data *WeatherData) bool {
+	return data != nil && data.cacheHit
+}
+
+// freshKey is the context key marking a FetchWeatherFresh call
+type freshKey struct{}
+
+// FetchWeatherFresh calls FetchWeatherFresh on the default Client
+func FetchWeatherFresh(ctx context.Context, country string) (*WeatherData, error) {
+	return defaultClient.FetchWeatherFresh(ctx, country)
+}
+
+// FetchWeatherFresh is FetchWeather without the cache read, for a manual
+// refresh: it always fetches upstream, and the result replaces the cached
+// entry
+func (c *Client) FetchWeatherFresh(ctx context.Context, country string) (*WeatherData, error) {
+	return c.FetchWeather(context.WithValue(ctx, freshKey{}, true), country)
+}
+
+// defaultCacheTTL is how long results are reused by default
+const defaultCacheTTL = 5 * time.Minute
+
+// defaultCacheSize is how many entries the default in-memory cache keeps
+const defaultCacheSize = 256
+
+// WithCache replaces the default in-memory cache, e.g. with a shared
+// Redis-backed one; a nil cache fails NewClient
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithCacheTTL sets how long results are cached (default 5 minutes); zero disables caching
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cacheTTL = ttl
+	}
+}
+
+// WithStrictCache makes cache backend errors fail the fetch, wrapped in
+// ErrCache, instead of being logged and bypassed
+func WithStrictCache() Option {
+	return func(c *Client) {
+		c.strictCache = true
+	}
+}
+
+// WithStaleWhileRevalidate keeps cached results for window past the cache
+// TTL. A result in that window is returned at once marked Stale, while a
+// background fetch replaces it; concurrent stale reads share that fetch.
+// Combined with a persistent cache such as NewFileCache, a restarted service
+// serves its last-known data immediately.
+func WithStaleWhileRevalidate(window time.Duration) Option {
+	return func(c *Client) {
+		c.staleWindow = window
+	}
+}
+
+// isStale reports whether a cached result is past the TTL and so only being
+// served under WithStaleWhileRevalidate
+func (c *Client) isStale(data *WeatherData) bool {
+	return c.staleWindow > 0 && !data.FetchedAt.IsZero() && time.Since(data.FetchedAt) > c.cacheTTL
+}
+
+// Flush waits until background refreshes of stale cache entries have
+// written their results to the cache, or ctx is done. Call it on shutdown,
+// once nothing fetches through the Client any more, so a persistent or
+// shared cache keeps those results.
+func (c *Client) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		c.revalidations.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("flush cache: %w", ctx.Err())
+	}
+}
+
+// revalidate refreshes a stale cache entry, detached from the cancellation
+// of the request that found it
+func (c *Client) revalidate(ctx context.Context, key, url string) {
+	defer c.revalidations.Done()
+	ctx = context.WithoutCancel(ctx)
+	if _, err := c.fetchCurrentURL(ctx, key, url); err != nil {
+		logger.Warnf("%srevalidating stale cache entry failed: %v", logPrefix(ctx), err)
+	}
+}
+
+// cacheKey returns the documented cache key for a request URL
+func cacheKey(url string) string {
+	return "weather:" + url
+}
+
+// lruEntry is a cached value with its expiry
+type lruEntry struct {
+	key     string
+	data    *WeatherData
+	expires time.Time
+}
+
+// lruCache is the default in-memory Cache, evicting the least recently used
+// entry once full
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// newLRUCache creates an in-memory cache holding at most size entries
+func newLRUCache(size int) *lruCache {
+	return &lruCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns a copy of the cached value so callers can't modify the cache
+func (l *lruCache) Get(key string) (*WeatherData, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		l.order.Remove(el)
+		delete(l.entries, key)
+		return nil, false, nil
+	}
+	l.order.MoveToFront(el)
+	data := *entry.data
+	return &data, true, nil
+}
+
+func (l *lruCache) Set(key string, data *WeatherData, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stored := *data
+	entry := &lruEntry{key: key, data: &stored, expires: time.Now().Add(ttl)}
+	if el, ok := l.entries[key]; ok {
+		el.Value = entry
+		l.order.MoveToFront(el)
+		return nil
+	}
+	l.entries[key] = l.order.PushFront(entry)
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Len counts the cached entries, expired ones included until looked up or
+// evicted
+func (l *lruCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
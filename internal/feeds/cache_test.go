@@ -0,0 +1,184 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestCacheMissThenHit(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	cache := &fakeCache{}
+	c := srv.Client(t, feeds.WithCache(cache))
+	ctx := context.Background()
+
+	if _, err := c.FetchWeather(ctx, "JP"); err != nil {
+		t.Fatal(err)
+	}
+	if gets, hits, sets := cache.counts(); gets != 1 || hits != 0 || sets != 1 {
+		t.Errorf("after a miss: %d gets, %d hits, %d sets, want 1, 0, 1", gets, hits, sets)
+	}
+	for key := range cache.entries {
+		if !strings.HasPrefix(key, "weather:https://api.open-meteo.com/v1/forecast?") {
+			t.Errorf("cache key %q, want weather: and the request URL", key)
+		}
+	}
+
+	data, err := c.FetchWeather(ctx, "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gets, hits, sets := cache.counts(); gets != 2 || hits != 1 || sets != 1 {
+		t.Errorf("after a hit: %d gets, %d hits, %d sets, want 2, 1, 1", gets, hits, sets)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("%d upstream requests, want 1", n)
+	}
+	if data.TemperatureC != 9.4 {
+		t.Errorf("cached TemperatureC = %v, want 9.4", data.TemperatureC)
+	}
+}
+
+func TestWasCacheHit(t *testing.T) {
+	c := feedstest.NewServer(t).Client(t)
+	for i, want := range []bool{false, true} {
+		data, err := c.FetchWeather(context.Background(), "JP")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := feeds.WasCacheHit(data); got != want {
+			t.Errorf("call %d: WasCacheHit = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestFetchWeatherFresh(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	cache := &fakeCache{}
+	c := srv.Client(t, feeds.WithCache(cache))
+	ctx := context.Background()
+
+	if _, err := c.FetchWeather(ctx, "JP"); err != nil {
+		t.Fatal(err)
+	}
+	srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, []byte(
+		`{"current":{"time":"2025-01-15T13:00","temperature_2m":11.5,"apparent_temperature":10,"weather_code":3}}`))
+	data, err := c.FetchWeatherFresh(ctx, "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("%d upstream requests, want the fresh fetch to make one despite the cached entry", n)
+	}
+	if data.TemperatureC != 11.5 || feeds.WasCacheHit(data) {
+		t.Errorf("fresh TemperatureC = %v, cache hit %v, want 11.5 fetched", data.TemperatureC, feeds.WasCacheHit(data))
+	}
+	if gets, _, sets := cache.counts(); gets != 1 || sets != 2 {
+		t.Errorf("%d gets and %d sets, want the fresh fetch to skip Get but still Set", gets, sets)
+	}
+
+	cached, err := c.FetchWeather(ctx, "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.TemperatureC != 11.5 || !feeds.WasCacheHit(cached) {
+		t.Errorf("next TemperatureC = %v, cache hit %v, want the fresh result from the cache", cached.TemperatureC, feeds.WasCacheHit(cached))
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	cache := &fakeCache{}
+	c := srv.Client(t, feeds.WithCache(cache), feeds.WithCacheTTL(0))
+
+	for range 2 {
+		if _, err := c.FetchWeather(context.Background(), "JP"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gets, _, sets := cache.counts(); gets != 0 || sets != 0 {
+		t.Errorf("%d gets and %d sets with caching off, want none", gets, sets)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("%d upstream requests, want 2", n)
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	cache := &fakeCache{}
+	c := srv.Client(t, feeds.WithCache(cache), feeds.WithStaleWhileRevalidate(time.Hour))
+	ctx := context.Background()
+
+	if _, err := c.FetchWeather(ctx, "JP"); err != nil {
+		t.Fatal(err)
+	}
+	// Age the entry past the 5 minute TTL
+	cache.mu.Lock()
+	for key, data := range cache.entries {
+		data.FetchedAt = time.Now().Add(-10 * time.Minute)
+		cache.entries[key] = data
+	}
+	cache.mu.Unlock()
+
+	data, err := c.FetchWeather(ctx, "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !data.Stale || data.AgeSeconds < 600 {
+		t.Errorf("Stale = %v, AgeSeconds = %v, want a stale result about 600s old", data.Stale, data.AgeSeconds)
+	}
+	if err := c.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, sets := cache.counts(); sets != 2 {
+		t.Errorf("%d sets after Flush, want the revalidated entry written", sets)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("%d upstream requests, want 2", n)
+	}
+}
+
+func TestFailingCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []feeds.Option
+		wantErr bool
+	}{
+		{name: "lenient"},
+		{name: "strict", options: []feeds.Option{feeds.WithStrictCache()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			cache := &fakeCache{failing: true}
+			c := srv.Client(t, append(tt.options, feeds.WithCache(cache))...)
+
+			data, err := c.FetchWeather(context.Background(), "JP")
+			if tt.wantErr {
+				if !errors.Is(err, feeds.ErrCache) || !errors.Is(err, errBackend) {
+					t.Fatalf("err = %v, want ErrCache wrapping the backend error", err)
+				}
+				if n := len(srv.Requests()); n != 0 {
+					t.Errorf("%d upstream requests after the cache failed, want 0", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v, want the cache bypassed", err)
+			}
+			if data.TemperatureC != 9.4 {
+				t.Errorf("TemperatureC = %v, want 9.4", data.TemperatureC)
+			}
+			if gets, _, sets := cache.counts(); gets != 1 || sets != 1 {
+				t.Errorf("%d gets and %d sets, want both tried once", gets, sets)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// Country card sections, the keys of CountryCard.Errors
+const (
+	WeatherSection    = "weather"
+	AirQualitySection = "airQuality"
+	FXSection         = "fx"
+	ClockSection      = "clock"
+	HolidaysSection   = "holidays"
+)
+
+// CountryCard is everything a per-country dashboard card shows, from one
+// Compose call. A section that failed is left empty and its error recorded.
+type CountryCard struct {
+	Country    string          `json:"country"`
+	Weather    *WeatherData    `json:"weather,omitempty"`
+	AirQuality *AirQualityData `json:"airQuality,omitempty"`
+	FX         *CurrencyRate   `json:"fx,omitempty"`
+	Clock      *ClockData      `json:"clock,omitempty"`
+	// Holidays are the upcoming national holidays
+	Holidays []Holiday `json:"holidays,omitempty"`
+
+	// Errors holds the error of each failed section. In JSON they become
+	// "errors", describing only the kind of failure, as upstream details
+	// aren't for end users.
+	Errors map[string]error `json:"-"`
+}
+
+// CurrencyRate is a country's currency against the US dollar
+type CurrencyRate struct {
+	Currency string `json:"currency"`
+	// PerUSD is units of Currency per US dollar
+	PerUSD float64   `json:"perUsd"`
+	Date   time.Time `json:"date"`
+
+	Provenance
+}
+
+// MarshalJSON adds "errors", mapping failed sections to the kind of failure
+func (c CountryCard) MarshalJSON() ([]byte, error) {
+	type plain CountryCard
+	errs := make(map[string]string, len(c.Errors))
+	for section, err := range c.Errors {
+		errs[section] = failureKind(err)
+	}
+	return json.Marshal(struct {
+		plain
+		Errors map[string]string `json:"errors,omitempty"`
+	}{plain: plain(c), Errors: errs})
+}
+
+// failureKind describes an error in the classes of errors.go
+func failureKind(err error) string {
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return "rate limited"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timed out"
+	case errors.Is(err, ErrProviderUnavailable):
+		return "provider unavailable"
+	case errors.Is(err, ErrDecode), errors.Is(err, ErrInvalidUpstreamData):
+		return "invalid provider data"
+	default:
+		return "unavailable"
+	}
+}
+
+// Compose calls Compose on the default Client
+func Compose(ctx context.Context, country string) (*CountryCard, error) {
+	return defaultClient.Compose(ctx, country)
+}
+
+// Compose fetches a country's weather, air quality, exchange rate, local
+// time and upcoming holidays concurrently into one CountryCard, so clients
+// make one call per country. Failed sections are recorded on Errors rather
+// than failing the card; only unsupported countries and a card with every
+// section failed return an error.
+func (c *Client) Compose(ctx context.Context, country string) (*CountryCard, error) {
+	code := normalizeCountry(country)
+	if !IsSupportedCountry(code) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+
+	card := &CountryCard{Country: code}
+	sections := map[string]func(context.Context) error{
+		WeatherSection: func(ctx context.Context) (err error) {
+			card.Weather, err = c.FetchWeather(ctx, code)
+			return err
+		},
+		AirQualitySection: func(ctx context.Context) (err error) {
+			card.AirQuality, err = c.FetchAirQuality(ctx, code)
+			return err
+		},
+		FXSection: func(ctx context.Context) (err error) {
+			card.FX, err = c.currencyRate(ctx, code)
+			return err
+		},
+		ClockSection: func(ctx context.Context) (err error) {
+			card.Clock, err = c.LocalClock(ctx, code)
+			return err
+		},
+		HolidaysSection: func(ctx context.Context) (err error) {
+			card.Holidays, err = c.UpcomingHolidays(ctx, code)
+			return err
+		},
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for section, fetch := range sections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each section sets only its own field
+			if err := fetch(ctx); err != nil {
+				logger.Warnf("%scard %s %s failed: %v", logPrefix(ctx), code, section, err)
+				mu.Lock()
+				defer mu.Unlock()
+				if card.Errors == nil {
+					card.Errors = make(map[string]error)
+				}
+				card.Errors[section] = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(card.Errors) == len(sections) {
+		errs := make([]error, 0, len(card.Errors))
+		for section, err := range card.Errors {
+			errs = append(errs, fmt.Errorf("%s: %w", section, err))
+		}
+		return nil, errors.Join(errs...)
+	}
+	return card, nil
+}
+
+// currencyRate returns a country's currency against the US dollar, from the
+// USD rates shared by every country
+func (c *Client) currencyRate(ctx context.Context, country string) (*CurrencyRate, error) {
+	currency := countryCurrencies[country]
+	rates, err := c.FetchExchangeRates(ctx, "USD")
+	if err != nil {
+		return nil, err
+	}
+	rate := &CurrencyRate{Currency: currency, PerUSD: 1, Date: rates.Date, Provenance: rates.Provenance}
+	if currency != "USD" {
+		perUSD, ok := rates.Rates[currency]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+		}
+		rate.PerUSD = perUSD
+	}
+	return rate, nil
+}
@@ -0,0 +1,301 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownCity is returned for cities outside the bundled gazetteer
+var ErrUnknownCity = errors.New("unknown city")
+
+// cityEntry is one city in the bundled gazetteer
+type cityEntry struct {
+	name   string
+	coords Coordinates
+}
+
+// asiaCityGazetteer lists major cities per supported country, capitals first
+var asiaCityGazetteer = map[string][]cityEntry{
+	"JP": {
+		{"Tokyo", Coordinates{Lat: 35.6762, Lon: 139.6503}},
+		{"Osaka", Coordinates{Lat: 34.6937, Lon: 135.5023}},
+		{"Kyoto", Coordinates{Lat: 35.0116, Lon: 135.7681}},
+		{"Nagoya", Coordinates{Lat: 35.1815, Lon: 136.9066}},
+		{"Sapporo", Coordinates{Lat: 43.0618, Lon: 141.3545}},
+		{"Fukuoka", Coordinates{Lat: 33.5904, Lon: 130.4017}},
+		{"Naha", Coordinates{Lat: 26.2124, Lon: 127.6809}},
+	},
+	"CN": {
+		{"Beijing", Coordinates{Lat: 39.9042, Lon: 116.4074}},
+		{"Shanghai", Coordinates{Lat: 31.2304, Lon: 121.4737}},
+		{"Guangzhou", Coordinates{Lat: 23.1291, Lon: 113.2644}},
+		{"Shenzhen", Coordinates{Lat: 22.5431, Lon: 114.0579}},
+		{"Chengdu", Coordinates{Lat: 30.5728, Lon: 104.0668}},
+		{"Wuhan", Coordinates{Lat: 30.5928, Lon: 114.3055}},
+	},
+	"IN": {
+		{"New Delhi", Coordinates{Lat: 28.6139, Lon: 77.2090}},
+		{"Mumbai", Coordinates{Lat: 19.0760, Lon: 72.8777}},
+		{"Bengaluru", Coordinates{Lat: 12.9716, Lon: 77.5946}},
+		{"Chennai", Coordinates{Lat: 13.0827, Lon: 80.2707}},
+		{"Kolkata", Coordinates{Lat: 22.5726, Lon: 88.3639}},
+		{"Hyderabad", Coordinates{Lat: 17.3850, Lon: 78.4867}},
+	},
+	"SG": {
+		{"Singapore", Coordinates{Lat: 1.3521, Lon: 103.8198}},
+	},
+	"HK": {
+		{"Hong Kong", Coordinates{Lat: 22.3193, Lon: 114.1694}},
+	},
+	"KR": {
+		{"Seoul", Coordinates{Lat: 37.5665, Lon: 126.9780}},
+		{"Busan", Coordinates{Lat: 35.1796, Lon: 129.0756}},
+		{"Incheon", Coordinates{Lat: 37.4563, Lon: 126.7052}},
+		{"Jeju", Coordinates{Lat: 33.4996, Lon: 126.5312}},
+	},
+	"TH": {
+		{"Bangkok", Coordinates{Lat: 13.7563, Lon: 100.5018}},
+		{"Chiang Mai", Coordinates{Lat: 18.7883, Lon: 98.9853}},
+		{"Phuket", Coordinates{Lat: 7.8804, Lon: 98.3923}},
+	},
+	"ID": {
+		{"Jakarta", Coordinates{Lat: -6.2088, Lon: 106.8456}},
+		{"Surabaya", Coordinates{Lat: -7.2575, Lon: 112.7521}},
+		{"Bandung", Coordinates{Lat: -6.9175, Lon: 107.6191}},
+		{"Denpasar", Coordinates{Lat: -8.6705, Lon: 115.2126}},
+	},
+	"MY": {
+		{"Kuala Lumpur", Coordinates{Lat: 3.1390, Lon: 101.6869}},
+		{"George Town", Coordinates{Lat: 5.4141, Lon: 100.3288}},
+		{"Johor Bahru", Coordinates{Lat: 1.4927, Lon: 103.7414}},
+		{"Kota Kinabalu", Coordinates{Lat: 5.9804, Lon: 116.0735}},
+	},
+	"PH": {
+		{"Manila", Coordinates{Lat: 14.5995, Lon: 120.9842}},
+		{"Quezon City", Coordinates{Lat: 14.6760, Lon: 121.0437}},
+		{"Cebu", Coordinates{Lat: 10.3157, Lon: 123.8854}},
+		{"Davao", Coordinates{Lat: 7.1907, Lon: 125.4553}},
+	},
+	"VN": {
+		{"Hanoi", Coordinates{Lat: 21.0278, Lon: 105.8342}},
+		{"Ho Chi Minh City", Coordinates{Lat: 10.8231, Lon: 106.6297}},
+		{"Da Nang", Coordinates{Lat: 16.0544, Lon: 108.2022}},
+	},
+	"TW": {
+		{"Taipei", Coordinates{Lat: 25.0330, Lon: 121.5654}},
+		{"Kaohsiung", Coordinates{Lat: 22.6273, Lon: 120.3014}},
+		{"Taichung", Coordinates{Lat: 24.1477, Lon: 120.6736}},
+	},
+	"BD": {
+		{"Dhaka", Coordinates{Lat: 23.8103, Lon: 90.4125}},
+		{"Chittagong", Coordinates{Lat: 22.3569, Lon: 91.7832}},
+		{"Khulna", Coordinates{Lat: 22.8456, Lon: 89.5403}},
+		{"Sylhet", Coordinates{Lat: 24.8949, Lon: 91.8687}},
+	},
+	"PK": {
+		{"Islamabad", Coordinates{Lat: 33.6844, Lon: 73.0479}},
+		{"Karachi", Coordinates{Lat: 24.8607, Lon: 67.0011}},
+		{"Lahore", Coordinates{Lat: 31.5204, Lon: 74.3587}},
+		{"Peshawar", Coordinates{Lat: 34.0151, Lon: 71.5249}},
+	},
+	"LK": {
+		{"Colombo", Coordinates{Lat: 6.9271, Lon: 79.8612}},
+		{"Kandy", Coordinates{Lat: 7.2906, Lon: 80.6337}},
+		{"Galle", Coordinates{Lat: 6.0535, Lon: 80.2210}},
+	},
+	"NP": {
+		{"Kathmandu", Coordinates{Lat: 27.7172, Lon: 85.3240}},
+		{"Pokhara", Coordinates{Lat: 28.2096, Lon: 83.9856}},
+	},
+	"BT": {
+		{"Thimphu", Coordinates{Lat: 27.4728, Lon: 89.6390}},
+		{"Paro", Coordinates{Lat: 27.4305, Lon: 89.4133}},
+	},
+	"MV": {
+		{"Malé", Coordinates{Lat: 4.1755, Lon: 73.5093}},
+	},
+	"MM": {
+		{"Naypyidaw", Coordinates{Lat: 19.7633, Lon: 96.0785}},
+		{"Yangon", Coordinates{Lat: 16.8409, Lon: 96.1735}},
+		{"Mandalay", Coordinates{Lat: 21.9588, Lon: 96.0891}},
+	},
+	"KH": {
+		{"Phnom Penh", Coordinates{Lat: 11.5564, Lon: 104.9282}},
+		{"Siem Reap", Coordinates{Lat: 13.3671, Lon: 103.8448}},
+		{"Sihanoukville", Coordinates{Lat: 10.6253, Lon: 103.5234}},
+	},
+	"LA": {
+		{"Vientiane", Coordinates{Lat: 17.9757, Lon: 102.6331}},
+		{"Luang Prabang", Coordinates{Lat: 19.8834, Lon: 102.1347}},
+		{"Pakse", Coordinates{Lat: 15.1202, Lon: 105.7990}},
+	},
+	"BN": {
+		{"Bandar Seri Begawan", Coordinates{Lat: 4.9031, Lon: 114.9398}},
+	},
+	"TL": {
+		{"Dili", Coordinates{Lat: -8.5569, Lon: 125.5603}},
+	},
+	"MN": {
+		{"Ulaanbaatar", Coordinates{Lat: 47.8864, Lon: 106.9057}},
+		{"Erdenet", Coordinates{Lat: 49.0278, Lon: 104.0446}},
+	},
+	"KZ": {
+		{"Astana", Coordinates{Lat: 51.1694, Lon: 71.4491}},
+		{"Almaty", Coordinates{Lat: 43.2220, Lon: 76.8512}},
+		{"Shymkent", Coordinates{Lat: 42.3417, Lon: 69.5901}},
+	},
+	"UZ": {
+		{"Tashkent", Coordinates{Lat: 41.2995, Lon: 69.2401}},
+		{"Samarkand", Coordinates{Lat: 39.6542, Lon: 66.9597}},
+		{"Bukhara", Coordinates{Lat: 39.7681, Lon: 64.4556}},
+	},
+	"KG": {
+		{"Bishkek", Coordinates{Lat: 42.8746, Lon: 74.5698}},
+		{"Osh", Coordinates{Lat: 40.5283, Lon: 72.7985}},
+	},
+	"TJ": {
+		{"Dushanbe", Coordinates{Lat: 38.5598, Lon: 68.7870}},
+		{"Khujand", Coordinates{Lat: 40.2826, Lon: 69.6220}},
+	},
+	"TM": {
+		{"Ashgabat", Coordinates{Lat: 37.9601, Lon: 58.3261}},
+		{"Türkmenabat", Coordinates{Lat: 39.0733, Lon: 63.5786}},
+	},
+	"AF": {
+		{"Kabul", Coordinates{Lat: 34.5553, Lon: 69.2075}},
+		{"Kandahar", Coordinates{Lat: 31.6133, Lon: 65.7101}},
+		{"Herat", Coordinates{Lat: 34.3529, Lon: 62.2040}},
+	},
+	"IR": {
+		{"Tehran", Coordinates{Lat: 35.6892, Lon: 51.3890}},
+		{"Mashhad", Coordinates{Lat: 36.2605, Lon: 59.6168}},
+		{"Isfahan", Coordinates{Lat: 32.6546, Lon: 51.6680}},
+	},
+	"IQ": {
+		{"Baghdad", Coordinates{Lat: 33.3152, Lon: 44.3661}},
+		{"Basra", Coordinates{Lat: 30.5085, Lon: 47.7804}},
+		{"Erbil", Coordinates{Lat: 36.1911, Lon: 44.0092}},
+	},
+	"AE": {
+		{"Abu Dhabi", Coordinates{Lat: 24.4539, Lon: 54.3773}},
+		{"Dubai", Coordinates{Lat: 25.2048, Lon: 55.2708}},
+		{"Sharjah", Coordinates{Lat: 25.3463, Lon: 55.4209}},
+	},
+	"SA": {
+		{"Riyadh", Coordinates{Lat: 24.7136, Lon: 46.6753}},
+		{"Jeddah", Coordinates{Lat: 21.4858, Lon: 39.1925}},
+		{"Mecca", Coordinates{Lat: 21.3891, Lon: 39.8579}},
+		{"Dammam", Coordinates{Lat: 26.4207, Lon: 50.0888}},
+	},
+	"QA": {
+		{"Doha", Coordinates{Lat: 25.2854, Lon: 51.5310}},
+	},
+	"KW": {
+		{"Kuwait City", Coordinates{Lat: 29.3759, Lon: 47.9774}},
+	},
+	"BH": {
+		{"Manama", Coordinates{Lat: 26.2285, Lon: 50.5860}},
+	},
+	"OM": {
+		{"Muscat", Coordinates{Lat: 23.5880, Lon: 58.3829}},
+		{"Salalah", Coordinates{Lat: 17.0151, Lon: 54.0924}},
+	},
+	"JO": {
+		{"Amman", Coordinates{Lat: 31.9454, Lon: 35.9284}},
+		{"Aqaba", Coordinates{Lat: 29.5267, Lon: 35.0078}},
+	},
+	"LB": {
+		{"Beirut", Coordinates{Lat: 33.8938, Lon: 35.5018}},
+	},
+	"MO": {
+		{"Macau", Coordinates{Lat: 22.1987, Lon: 113.5439}},
+	},
+}
+
+// WithReferenceCity makes country lookups such as FetchWeather use city from
+// the gazetteer for country, e.g. ("JP", "Osaka"), in place of the city the
+// CityPreference picks. Unknown countries or cities fail NewClient with
+// ErrInvalidConfig wrapping ErrUnsupportedCountry or ErrUnknownCity.
+func WithReferenceCity(country, city string) Option {
+	return func(c *Client) {
+		if c.referenceCities == nil {
+			c.referenceCities = make(map[string]string)
+		}
+		c.referenceCities[normalizeCountry(country)] = city
+	}
+}
+
+// referenceCity returns the WithReferenceCity entry for country, if any
+func (c *Client) referenceCity(country string) (cityEntry, bool) {
+	city, ok := c.referenceCities[normalizeCountry(country)]
+	if !ok {
+		return cityEntry{}, false
+	}
+	entry, err := lookupCity(country, city)
+	return entry, err == nil
+}
+
+// referenceCitiesError checks every WithReferenceCity entry against the gazetteer
+func (c *Client) referenceCitiesError() error {
+	var errs []error
+	for country, city := range c.referenceCities {
+		if _, err := lookupCity(country, city); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cities returns the gazetteer's city names for a country, capital first, or
+// nil for unsupported countries
+func Cities(country string) []string {
+	entries := asiaCityGazetteer[normalizeCountry(country)]
+	if entries == nil {
+		return nil
+	}
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.name
+	}
+	return names
+}
+
+// lookupCity finds a city in a country's gazetteer, ignoring case and
+// surrounding space
+func lookupCity(country, city string) (cityEntry, error) {
+	code := normalizeCountry(country)
+	entries, ok := asiaCityGazetteer[code]
+	if !ok {
+		return cityEntry{}, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	for _, e := range entries {
+		if strings.EqualFold(e.name, strings.TrimSpace(city)) {
+			return e, nil
+		}
+	}
+	return cityEntry{}, fmt.Errorf("%w: %q in %s", ErrUnknownCity, city, code)
+}
+
+// FetchWeatherForCity calls FetchWeatherForCity on the default Client
+func FetchWeatherForCity(ctx context.Context, country, city string) (*WeatherData, error) {
+	return defaultClient.FetchWeatherForCity(ctx, country, city)
+}
+
+// FetchWeatherForCity fetches current conditions for a city in the bundled
+// gazetteer, e.g. ("JP", "Osaka"). Unknown countries return
+// ErrUnsupportedCountry regardless of the unknown country policy, and
+// unknown cities ErrUnknownCity.
+func (c *Client) FetchWeatherForCity(ctx context.Context, country, city string) (*WeatherData, error) {
+	entry, err := lookupCity(country, city)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.FetchWeatherAt(ctx, entry.coords)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, entry.name
+	return data, nil
+}
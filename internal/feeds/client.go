@@ -0,0 +1,298 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Client fetches weather data from Open-Meteo. The package-level functions
+// use a shared default Client.
+type Client struct {
+	unknownDescription string
+	hedgeDelay         time.Duration
+	cityPreference     CityPreference
+	referenceCities    map[string]string
+	transport          transportConfig
+	colorPalette       map[WeatherGroup]string
+	maxCurrentBytes    int64
+	maxForecastBytes   int64
+	model              string
+	nightDescriptions  map[int]string
+	locale             string
+	cache              Cache
+	cacheTTL           time.Duration
+	strictCache        bool
+	staleWindow        time.Duration
+	maxStaleness       time.Duration
+	lastGood           *lruCache
+	latency            *latencySampler
+	pastDays           int
+	narratives         bool
+	safeSunUV          float64
+	recordDir          string
+	replayDir          string
+	faults             *faultConfig
+	validators         *validatorStore
+	defaultCountry     string
+	windSpeedUnit      WindSpeedUnit
+	weatherFields      []WeatherField
+	forecastLimits     forecastLimits
+	redirectPolicy     RedirectPolicy
+	extraParams        map[string]string
+	plausible          PlausibleRanges
+	timeout            time.Duration
+	fallbacks          []WeatherProvider
+	tides              TideProvider
+	markets            MarketProvider
+	newsSources        map[string][]NewsSource
+	newsAPIKey         string
+	openMeteoAPIKey    string
+	advisorySource     AdvisorySource
+	aqiStandard        *AQIStandard
+	aqiStandards       map[string]AQIStandard
+	flights            *flightGroup[*WeatherData]
+	requests           *flightGroup[any]
+	fxCache            *fxCache
+	typhoonEvents      eventListCache
+	roundTripper       http.RoundTripper
+	userAgent          string
+	retry              RetryPolicy
+	retryBudget        *retryBudget
+	rand               *lockedRand
+	breakers           *breakerSet
+	limiters           *rateLimiters
+	metrics            *metrics
+	tracer             trace.Tracer
+
+	// unknownCountryPolicy is atomic so SetUnknownCountryPolicy is safe
+	// while the default Client is in use
+	unknownCountryPolicy atomic.Int32
+
+	// revalidations tracks background refreshes of stale cache entries, so
+	// Flush can wait for their cache writes
+	revalidations sync.WaitGroup
+
+	httpClient *http.Client
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithUnknownDescription sets the Summary used for weather codes that have
+// no description (default "Unknown")
+func WithUnknownDescription(s string) Option {
+	return func(c *Client) {
+		c.unknownDescription = s
+	}
+}
+
+// CityPreference selects which representative city is used for a country
+type CityPreference int
+
+const (
+	// Capital uses the capital (or main city) for each country (default)
+	Capital CityPreference = iota
+	// LargestCity uses the most populous city for countries where it differs
+	// from the capital, e.g. Shanghai for CN
+	LargestCity
+)
+
+// WithCityPreference selects the representative city used for country lookups
+func WithCityPreference(p CityPreference) Option {
+	return func(c *Client) {
+		c.cityPreference = p
+	}
+}
+
+// WithMaxResponseSize limits how many bytes are read from current-conditions
+// and forecast responses (defaults 256 KiB and 4 MiB); larger responses fail
+// with ErrResponseTooLarge
+func WithMaxResponseSize(current, forecast int64) Option {
+	return func(c *Client) {
+		c.maxCurrentBytes = current
+		c.maxForecastBytes = forecast
+	}
+}
+
+// defaultTimeout bounds each upstream request unless WithTimeout changes it
+const defaultTimeout = 10 * time.Second
+
+// WithTimeout sets the per-request timeout (default 10 seconds); zero leaves
+// requests bounded only by the caller's context
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// defaultUserAgent identifies requests unless WithUserAgent changes it
+const defaultUserAgent = "reef-asia-feeds/1.0"
+
+// WithUserAgent sets the User-Agent header sent upstream
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// ErrInvalidConfig is returned by NewClient when options are invalid
+var ErrInvalidConfig = errors.New("invalid feeds client configuration")
+
+// NewClient creates a Client with the given options applied. Obviously
+// invalid configuration, such as a nil cache, a negative TTL or an unknown
+// model, fails here with ErrInvalidConfig rather than on the first fetch.
+func NewClient(options ...Option) (*Client, error) {
+	c := &Client{
+		unknownDescription: "Unknown",
+		transport:          defaultTransportConfig,
+		maxCurrentBytes:    256 << 10,
+		maxForecastBytes:   4 << 20,
+		cacheTTL:           defaultCacheTTL,
+		safeSunUV:          defaultSafeSunUV,
+		latency:            newLatencySampler(defaultLatencyWindow),
+		validators:         newValidatorStore(),
+		windSpeedUnit:      KilometresPerHour,
+		redirectPolicy:     FollowRedirects,
+		plausible:          DefaultPlausibleRanges,
+		cache:              newLRUCache(defaultCacheSize),
+		timeout:            defaultTimeout,
+		flights:            newFlightGroup(copyWeather, nil),
+		fxCache:            newFXCache(),
+		userAgent:          defaultUserAgent,
+		retry:              DefaultRetryPolicy,
+		breakers:           newBreakerSet(),
+		limiters:           newRateLimiters(),
+		newsSources:        make(map[string][]NewsSource),
+		advisorySource:     defaultAdvisorySource,
+		metrics:            newMetrics(),
+	}
+	// Shared JSON values are copied by getJSON itself, and bodies are read-only
+	c.requests = newFlightGroup(func(v any) any { return v }, func() { c.metrics.observeCoalesced() })
+	for _, fn := range options {
+		fn(c)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	if c.httpClient != nil {
+		return c, nil
+	}
+	// Create HTTP client with timeout
+	transport := c.roundTripper
+	if transport == nil {
+		transport = newTransport(c.transport)
+	}
+	c.httpClient = &http.Client{
+		Timeout:       c.timeout,
+		Transport:     transport,
+		CheckRedirect: c.redirectPolicy,
+	}
+	return c, nil
+}
+
+// validate reports every invalid setting at once
+func (c *Client) validate() error {
+	var errs []error
+	if c.cache == nil {
+		errs = append(errs, errors.New("nil cache"))
+	}
+	if c.cacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("negative cache TTL %s", c.cacheTTL))
+	}
+	if c.retry.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("retry max attempts %d must be at least 1", c.retry.MaxAttempts))
+	}
+	if c.retry.BaseDelay < 0 || c.retry.MaxDelay < c.retry.BaseDelay {
+		errs = append(errs, fmt.Errorf("retry delays must satisfy 0 <= base (%s) <= max (%s)", c.retry.BaseDelay, c.retry.MaxDelay))
+	}
+	if c.rand != nil && c.rand.r == nil {
+		errs = append(errs, errors.New("nil rand source"))
+	}
+	if b := c.retryBudget; b != nil && (b.bucket.limit.RPS < 0 || b.bucket.limit.Burst < 0) {
+		errs = append(errs, fmt.Errorf("retry budget must not be negative, got %g per second and burst %d", b.bucket.limit.RPS, b.bucket.limit.Burst))
+	}
+	if c.breakers.settings.OpenDuration < 0 {
+		errs = append(errs, fmt.Errorf("negative circuit breaker open duration %s", c.breakers.settings.OpenDuration))
+	}
+	if err := c.limiters.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.timeout < 0 {
+		errs = append(errs, fmt.Errorf("negative timeout %s", c.timeout))
+	}
+	if c.staleWindow < 0 {
+		errs = append(errs, fmt.Errorf("negative stale-while-revalidate window %s", c.staleWindow))
+	}
+	if c.maxStaleness < 0 {
+		errs = append(errs, fmt.Errorf("negative max staleness %s", c.maxStaleness))
+	}
+	if !(c.safeSunUV > 0) {
+		errs = append(errs, fmt.Errorf("safe sun UV threshold %g must be positive", c.safeSunUV))
+	}
+	if c.hedgeDelay < 0 {
+		errs = append(errs, fmt.Errorf("negative hedge delay %s", c.hedgeDelay))
+	}
+	if c.maxCurrentBytes <= 0 || c.maxForecastBytes <= 0 {
+		errs = append(errs, fmt.Errorf("max response sizes must be positive, got %d and %d", c.maxCurrentBytes, c.maxForecastBytes))
+	}
+	if c.defaultCountry != "" && !IsSupportedCountry(c.defaultCountry) {
+		errs = append(errs, fmt.Errorf("%w: default country %q", ErrUnsupportedCountry, c.defaultCountry))
+	}
+	if c.faults != nil && (c.faults.probability < 0 || c.faults.probability > 1) {
+		errs = append(errs, fmt.Errorf("fault probability %g not within 0 to 1", c.faults.probability))
+	}
+	if slices.Contains(c.fallbacks, nil) {
+		errs = append(errs, errors.New("nil fallback provider"))
+	}
+	if err := c.checkAQIStandards(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.modelQuery(context.Background()); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.windSpeedQuery(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.pastDaysQuery(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.checkLocale(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.referenceCitiesError(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.newsSourcesError(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.advisorySourceError(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.weatherFieldsError(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+}
+
+// mustNewClient is NewClient for configurations known to be valid
+func mustNewClient(options ...Option) *Client {
+	c, err := NewClient(options...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+var defaultClient = mustNewClient()
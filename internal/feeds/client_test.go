@@ -0,0 +1,83 @@
+package feeds_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestMaxResponseSize(t *testing.T) {
+	fixture := feedstest.Fixture(feedstest.ForecastFixture)
+	const limit = 4 << 10
+	// Trailing whitespace keeps the padded fixture valid JSON
+	padded := func(n int) []byte {
+		return append(bytes.Clone(fixture), bytes.Repeat([]byte(" "), n-len(fixture))...)
+	}
+
+	tests := []struct {
+		name    string
+		body    []byte
+		wantErr error
+	}{
+		{name: "at the limit", body: padded(limit)},
+		{name: "one byte over", body: padded(limit + 1), wantErr: feeds.ErrResponseTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, tt.body)
+			c := srv.Client(t, feeds.WithMaxResponseSize(limit, 4<<20))
+
+			_, err := c.FetchWeather(context.Background(), "JP")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewClientInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []feeds.Option
+		want    string
+	}{
+		{name: "nil cache", options: []feeds.Option{feeds.WithCache(nil)}, want: "nil cache"},
+		{name: "negative cache TTL", options: []feeds.Option{feeds.WithCacheTTL(-time.Minute)}, want: "negative cache TTL"},
+		{name: "negative timeout", options: []feeds.Option{feeds.WithTimeout(-time.Second)}, want: "negative timeout"},
+		{name: "no attempts", options: []feeds.Option{feeds.WithRetryPolicy(feeds.RetryPolicy{})}, want: "retry max attempts"},
+		{name: "max response size zero", options: []feeds.Option{feeds.WithMaxResponseSize(0, 4<<20)}, want: "max response sizes"},
+		{name: "unsupported default country", options: []feeds.Option{feeds.WithDefaultCountry("XX")}, want: "default country"},
+		{name: "negative hedge delay", options: []feeds.Option{feeds.WithHedging(-time.Millisecond)}, want: "negative hedge delay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := feeds.NewClient(tt.options...)
+			if !errors.Is(err, feeds.ErrInvalidConfig) {
+				t.Fatalf("err = %v, want ErrInvalidConfig", err)
+			}
+			if c != nil {
+				t.Error("got a Client alongside the error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	// Every problem is reported at once
+	_, err := feeds.NewClient(feeds.WithCache(nil), feeds.WithTimeout(-time.Second))
+	if err == nil || !strings.Contains(err.Error(), "nil cache") || !strings.Contains(err.Error(), "negative timeout") {
+		t.Errorf("err = %v, want both nil cache and negative timeout", err)
+	}
+	if _, err := feeds.NewClient(); err != nil {
+		t.Errorf("defaults: err = %v, want none", err)
+	}
+}
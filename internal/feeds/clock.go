@@ -0,0 +1,124 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Business hours are 9:00 to 18:00 local time on working days
+const (
+	businessOpenHour  = 9
+	businessCloseHour = 18
+)
+
+// asiaWeekends are the weekend days of countries that don't rest on Saturday
+// and Sunday
+var asiaWeekends = map[string][]time.Weekday{
+	"AF": {time.Thursday, time.Friday},
+	"BD": {time.Friday, time.Saturday},
+	"BH": {time.Friday, time.Saturday},
+	"BN": {time.Friday, time.Sunday},
+	"IQ": {time.Friday, time.Saturday},
+	"IR": {time.Thursday, time.Friday},
+	"JO": {time.Friday, time.Saturday},
+	"KW": {time.Friday, time.Saturday},
+	"MV": {time.Friday, time.Saturday},
+	"NP": {time.Saturday},
+	"OM": {time.Friday, time.Saturday},
+	"QA": {time.Friday, time.Saturday},
+	"SA": {time.Friday, time.Saturday},
+}
+
+// ClockData is a country's current local time
+type ClockData struct {
+	// Timezone is the IANA name, e.g. "Asia/Tokyo", and Abbreviation its
+	// current abbreviation, e.g. "JST"
+	Timezone     string    `json:"timezone"`
+	Abbreviation string    `json:"abbreviation"`
+	LocalTime    time.Time `json:"localTime"`
+	// UTCOffset is formatted like "+05:30"
+	UTCOffset        string `json:"utcOffset"`
+	UTCOffsetSeconds int    `json:"utcOffsetSeconds"`
+	DST              bool   `json:"dst"`
+	// BusinessHours is true from 9:00 to 18:00 on local working days; public
+	// holidays aren't taken into account
+	BusinessHours bool `json:"businessHours"`
+}
+
+// LocalClock calls LocalClock on the default Client
+func LocalClock(ctx context.Context, country string) (*ClockData, error) {
+	return defaultClient.LocalClock(ctx, country)
+}
+
+// LocalClock returns the local time, UTC offset, DST status and business
+// hours of a supported country, failing like LocalTime for unknown ones
+func (c *Client) LocalClock(ctx context.Context, country string) (*ClockData, error) {
+	now, loc, err := c.LocalTime(ctx, country)
+	if err != nil {
+		return nil, err
+	}
+	abbreviation, offset := now.Zone()
+	return &ClockData{
+		Timezone:         loc.String(),
+		Abbreviation:     abbreviation,
+		LocalTime:        now,
+		UTCOffset:        now.Format("-07:00"),
+		UTCOffsetSeconds: offset,
+		DST:              now.IsDST(),
+		BusinessHours:    isBusinessHours(normalizeCountry(country), now),
+	}, nil
+}
+
+// LocalClocks calls LocalClocks on the default Client
+func LocalClocks(ctx context.Context) (map[string]*ClockData, error) {
+	return defaultClient.LocalClocks(ctx)
+}
+
+// LocalClocks returns LocalClock for every supported country, keyed by code
+func (c *Client) LocalClocks(ctx context.Context) (map[string]*ClockData, error) {
+	clocks := make(map[string]*ClockData)
+	for _, country := range SupportedCountries() {
+		clock, err := c.LocalClock(ctx, country)
+		if err != nil {
+			return nil, err
+		}
+		clocks[country] = clock
+	}
+	return clocks, nil
+}
+
+// IsBusinessHours reports whether it's currently business hours in a
+// supported country: 9:00 to 18:00 local time outside the local weekend
+func IsBusinessHours(country string) (bool, error) {
+	return IsBusinessHoursAt(country, time.Now())
+}
+
+// IsBusinessHoursAt reports whether t falls in a supported country's
+// business hours, as IsBusinessHours does for now
+func IsBusinessHoursAt(country string, t time.Time) (bool, error) {
+	code := normalizeCountry(country)
+	name, ok := asiaCountryTimezones[code]
+	if !ok {
+		return false, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return false, fmt.Errorf("load timezone %s: %w", name, err)
+	}
+	return isBusinessHours(code, t.In(loc)), nil
+}
+
+// isBusinessHours reports whether local, already in the country's
+// timezone, is within business hours
+func isBusinessHours(country string, local time.Time) bool {
+	weekend, ok := asiaWeekends[country]
+	if !ok {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	if slices.Contains(weekend, local.Weekday()) {
+		return false
+	}
+	return local.Hour() >= businessOpenHour && local.Hour() < businessCloseHour
+}
@@ -0,0 +1,97 @@
+package feeds_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestUnknownDescription(t *testing.T) {
+	// 42 isn't a WMO weather interpretation code
+	srv := feedstest.NewServer(t)
+	srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, []byte(`{"timezone":"Asia/Tokyo","utc_offset_seconds":32400,`+
+		`"current":{"time":"2025-01-15T12:00","temperature_2m":9.4,"apparent_temperature":7.1,"weather_code":42},`+
+		`"hourly":{"time":["2025-01-15T12:00"],"temperature_2m":[9.4],"apparent_temperature":[7.1],"weather_code":[42]}}`))
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name    string
+		options []feeds.Option
+		want    string
+	}{
+		{name: "default", want: "Unknown"},
+		{name: "custom", options: []feeds.Option{feeds.WithUnknownDescription("Conditions unavailable")}, want: "Conditions unavailable"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := srv.Client(t, append(tt.options, feeds.WithCacheTTL(0))...)
+			current, err := c.FetchWeather(ctx, "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if current.Summary != tt.want {
+				t.Errorf("current Summary = %q, want %q", current.Summary, tt.want)
+			}
+			forecast, err := c.ForecastAt(ctx, "JP", time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if forecast.Summary != tt.want {
+				t.Errorf("forecast Summary = %q, want %q", forecast.Summary, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeatherCodeDescriptions(t *testing.T) {
+	codes := feeds.WeatherCodeDescriptions()
+	for code, want := range map[int]string{0: "Clear sky", 3: "Overcast", 61: "Slight rain", 95: "Thunderstorm"} {
+		if got := codes[code]; got != want {
+			t.Errorf("code %d = %q, want %q", code, got, want)
+		}
+	}
+
+	codes[0] = "Changed"
+	delete(codes, 3)
+	again := feeds.WeatherCodeDescriptions()
+	if again[0] != "Clear sky" || again[3] != "Overcast" {
+		t.Errorf("mutating the returned map changed the package's: 0 = %q, 3 = %q", again[0], again[3])
+	}
+}
+
+func TestWeatherCodePreserved(t *testing.T) {
+	weather := map[string]countryWeather{"JP": {TempC: 8, Code: 63}, "TH": {TempC: 33, Code: 95}}
+	c := stubClient(t, countryTransport(t, weather))
+	for country, w := range weather {
+		data, err := c.FetchWeather(context.Background(), country)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.WeatherCode != w.Code {
+			t.Errorf("%s: WeatherCode = %d, want %d", country, data.WeatherCode, w.Code)
+		}
+	}
+}
+
+func TestFreezingCodes(t *testing.T) {
+	want := map[int]string{
+		56: "Light freezing drizzle",
+		57: "Dense freezing drizzle",
+		66: "Light freezing rain",
+		67: "Heavy freezing rain",
+	}
+	weather := map[string]countryWeather{"CN": {TempC: -1, Code: 56}, "KR": {TempC: -2, Code: 57}, "MN": {TempC: -3, Code: 66}, "JP": {TempC: 0, Code: 67}}
+	c := stubClient(t, countryTransport(t, weather))
+	for country, w := range weather {
+		data, err := c.FetchWeather(context.Background(), country)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.Summary != want[w.Code] {
+			t.Errorf("code %d: Summary = %q, want %q", w.Code, data.Summary, want[w.Code])
+		}
+	}
+}
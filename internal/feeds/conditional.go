@@ -0,0 +1,75 @@
+package feeds
+
+import (
+	"net/http"
+	"sync"
+)
+
+// maxValidators bounds how many URLs have stored validators
+const maxValidators = 256
+
+// validatedBody is a response body with the validators it was served with
+type validatedBody struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// validatorStore remembers ETag/Last-Modified per request URL so repeat
+// requests can be made conditional
+type validatorStore struct {
+	mu      sync.Mutex
+	entries map[string]validatedBody
+}
+
+func newValidatorStore() *validatorStore {
+	return &validatorStore{entries: make(map[string]validatedBody)}
+}
+
+// apply adds conditional headers to req from a previous response to the
+// same URL, returning that response for reuse on 304 Not Modified
+func (s *validatorStore) apply(req *http.Request) (validatedBody, bool) {
+	s.mu.Lock()
+	v, ok := s.entries[req.URL.String()]
+	s.mu.Unlock()
+	if !ok {
+		return validatedBody{}, false
+	}
+	if v.etag != "" {
+		req.Header.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		req.Header.Set("If-Modified-Since", v.lastModified)
+	}
+	return v, true
+}
+
+// store saves body with its validators; responses without any are skipped
+func (s *validatorStore) store(url string, header http.Header, body []byte) {
+	v := validatedBody{
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		body:         body,
+	}
+	if v.etag == "" && v.lastModified == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[url]; !ok && len(s.entries) >= maxValidators {
+		// Drop an arbitrary entry; it only costs one unconditional request
+		for k := range s.entries {
+			delete(s.entries, k)
+			break
+		}
+	}
+	s.entries[url] = v
+}
+
+// len counts the URLs with stored validators
+func (s *validatorStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
@@ -0,0 +1,71 @@
+package feeds_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	const lastModified = "Wed, 15 Jan 2025 03:00:00 GMT"
+	tests := []struct {
+		name       string
+		validators http.Header
+		want       http.Header
+	}{
+		{name: "etag", validators: http.Header{"Etag": {`"v1"`}}, want: http.Header{"If-None-Match": {`"v1"`}}},
+		{name: "last modified", validators: http.Header{"Last-Modified": {lastModified}}, want: http.Header{"If-Modified-Since": {lastModified}}},
+		{name: "no validators", want: http.Header{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var conditional []http.Header
+			rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				got := http.Header{}
+				for _, h := range []string{"If-None-Match", "If-Modified-Since"} {
+					if v := req.Header.Get(h); v != "" {
+						got.Set(h, v)
+					}
+				}
+				mu.Lock()
+				conditional = append(conditional, got)
+				mu.Unlock()
+				if len(got) > 0 {
+					return stubResponse(req, http.StatusNotModified, nil), nil
+				}
+				resp := stubResponse(req, http.StatusOK, feedstest.Fixture(feedstest.ForecastFixture))
+				for k, v := range tt.validators {
+					resp.Header[k] = v
+				}
+				return resp, nil
+			})
+			// The TTL cache is off so the second fetch goes upstream
+			c := stubClient(t, rt, feeds.WithCacheTTL(0))
+			ctx := context.Background()
+
+			first, err := c.FetchWeather(ctx, "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := c.FetchWeather(ctx, "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if second.TemperatureC != first.TemperatureC || second.Summary != first.Summary {
+				t.Errorf("second fetch = %v°C %q, want the stored %v°C %q", second.TemperatureC, second.Summary, first.TemperatureC, first.Summary)
+			}
+			if len(conditional) != 2 || len(conditional[0]) != 0 {
+				t.Fatalf("conditional headers = %v, want none on the first of two requests", conditional)
+			}
+			if got := conditional[1]; len(got) != len(tt.want) || got.Get("If-None-Match") != tt.want.Get("If-None-Match") ||
+				got.Get("If-Modified-Since") != tt.want.Get("If-Modified-Since") {
+				t.Errorf("second request sent %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCoordinates reports a latitude or longitude outside the valid range
+type ErrInvalidCoordinates struct {
+	Lat float64
+	Lon float64
+}
+
+func (e *ErrInvalidCoordinates) Error() string {
+	return fmt.Sprintf("invalid coordinates lat=%v lon=%v", e.Lat, e.Lon)
+}
+
+// ValidateCoordinates checks that lat is within [-90, 90] and lon within
+// [-180, 180], rejecting NaN and Inf values
+func ValidateCoordinates(lat, lon float64) error {
+	// NaN fails every comparison and Inf is out of range, so checking the
+	// ranges in the positive form rejects both
+	if !(lat >= -90 && lat <= 90) || !(lon >= -180 && lon <= 180) {
+		return &ErrInvalidCoordinates{Lat: lat, Lon: lon}
+	}
+	return nil
+}
+
+// NewCoordinates returns validated coordinates, failing with
+// *ErrInvalidCoordinates for out-of-range, NaN or Inf values
+func NewCoordinates(lat, lon float64) (Coordinates, error) {
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return Coordinates{}, err
+	}
+	return Coordinates{Lat: lat, Lon: lon}, nil
+}
+
+// Valid reports whether the coordinates are within range and finite
+func (c Coordinates) Valid() bool {
+	return ValidateCoordinates(c.Lat, c.Lon) == nil
+}
+
+// ValidateBuiltinCoordinates checks that every built-in country entry has a
+// two-letter uppercase country code, every region entry an ISO 3166-2 code,
+// that all coordinates, including the city gazetteer's, are valid and that
+// every coastal city is in the gazetteer
+func ValidateBuiltinCoordinates() error {
+	var errs []error
+	for _, table := range []map[string]Coordinates{asiaCountryCoordinates, asiaLargestCityCoordinates} {
+		for code, coords := range table {
+			if !isAlpha2(code) {
+				errs = append(errs, fmt.Errorf("country code %q is not ISO 3166-1 alpha-2", code))
+			}
+			if !coords.Valid() {
+				errs = append(errs, fmt.Errorf("%s: %w", code, &ErrInvalidCoordinates{Lat: coords.Lat, Lon: coords.Lon}))
+			}
+		}
+	}
+	for code, cities := range asiaCityGazetteer {
+		for _, e := range cities {
+			if !e.coords.Valid() {
+				errs = append(errs, fmt.Errorf("%s/%s: %w", code, e.name, &ErrInvalidCoordinates{Lat: e.coords.Lat, Lon: e.coords.Lon}))
+			}
+		}
+	}
+	for code, city := range asiaCoastalCities {
+		if _, err := lookupCity(code, city); err != nil {
+			errs = append(errs, fmt.Errorf("coastal city: %w", err))
+		}
+	}
+	for code, e := range asiaRegions {
+		if len(code) < 4 || !isAlpha2(code[:2]) || code[2] != '-' {
+			errs = append(errs, fmt.Errorf("region code %q is not ISO 3166-2", code))
+		}
+		if !e.coords.Valid() {
+			errs = append(errs, fmt.Errorf("%s: %w", code, &ErrInvalidCoordinates{Lat: e.coords.Lat, Lon: e.coords.Lon}))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isAlpha2 reports whether code is exactly two uppercase ASCII letters
+func isAlpha2(code string) bool {
+	return len(code) == 2 &&
+		code[0] >= 'A' && code[0] <= 'Z' &&
+		code[1] >= 'A' && code[1] <= 'Z'
+}
@@ -0,0 +1,76 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestValidateCoordinates(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		wantErr  bool
+	}{
+		{name: "Tokyo", lat: 35.6762, lon: 139.6503},
+		{name: "bounds", lat: -90, lon: 180},
+		{name: "latitude 95", lat: 95, lon: 100, wantErr: true},
+		{name: "longitude 200", lat: 10, lon: 200, wantErr: true},
+		{name: "NaN latitude", lat: math.NaN(), lon: 100, wantErr: true},
+		{name: "NaN longitude", lat: 10, lon: math.NaN(), wantErr: true},
+		{name: "infinite longitude", lat: 10, lon: math.Inf(-1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := feeds.ValidateCoordinates(tt.lat, tt.lon)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+			var invalid *feeds.ErrInvalidCoordinates
+			if !errors.As(err, &invalid) {
+				t.Fatalf("err = %v, want *ErrInvalidCoordinates", err)
+			}
+			if !sameFloat(invalid.Lat, tt.lat) || !sameFloat(invalid.Lon, tt.lon) {
+				t.Errorf("error carries %v, %v, want %v, %v", invalid.Lat, invalid.Lon, tt.lat, tt.lon)
+			}
+		})
+	}
+}
+
+func TestNewCoordinates(t *testing.T) {
+	if c, err := feeds.NewCoordinates(1.3521, 103.8198); err != nil || c != (feeds.Coordinates{Lat: 1.3521, Lon: 103.8198}) || !c.Valid() {
+		t.Errorf("NewCoordinates(Singapore) = %v, %v, want valid coordinates", c, err)
+	}
+	for _, c := range []feeds.Coordinates{{Lat: 200}, {Lon: -181}, {Lat: math.Inf(1)}, {Lon: math.NaN()}} {
+		if got, err := feeds.NewCoordinates(c.Lat, c.Lon); err == nil || got != (feeds.Coordinates{}) {
+			t.Errorf("NewCoordinates(%v, %v) = %v, %v, want an error", c.Lat, c.Lon, got, err)
+		}
+		if c.Valid() {
+			t.Errorf("%v is Valid", c)
+		}
+	}
+}
+
+func TestFetchWeatherAtInvalidCoordinates(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	_, err := srv.Client(t).FetchWeatherAt(context.Background(), feeds.Coordinates{Lat: 95, Lon: 100})
+	var invalid *feeds.ErrInvalidCoordinates
+	if !errors.As(err, &invalid) {
+		t.Fatalf("err = %v, want *ErrInvalidCoordinates", err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("%d upstream requests, want none", n)
+	}
+}
+
+// sameFloat reports whether a and b are equal, or both NaN
+func sameFloat(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
@@ -0,0 +1,114 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrUnsupportedCountry is returned for countries outside the known set;
+// check input with IsSupportedCountry or list coverage with SupportedCountries
+var ErrUnsupportedCountry = errors.New("unsupported country")
+
+// UnknownCountryPolicy controls what happens when a country isn't in the known set
+type UnknownCountryPolicy int32
+
+const (
+	// RejectUnknown returns ErrUnsupportedCountry for unknown countries (default)
+	RejectUnknown UnknownCountryPolicy = iota
+	// FallbackToTokyo serves Tokyo's weather for unknown countries. It hides
+	// bad input behind plausible but wrong data, so only opt in for legacy
+	// callers that depend on it.
+	FallbackToTokyo
+)
+
+// WithUnknownCountryPolicy sets how the Client handles unknown countries
+func WithUnknownCountryPolicy(p UnknownCountryPolicy) Option {
+	return func(c *Client) {
+		c.unknownCountryPolicy.Store(int32(p))
+	}
+}
+
+// SetUnknownCountryPolicy changes how the shared default Client, and so the
+// package-level functions such as FetchWeather, handle unknown countries.
+// Clients created with NewClient are unaffected.
+func SetUnknownCountryPolicy(p UnknownCountryPolicy) {
+	defaultClient.unknownCountryPolicy.Store(int32(p))
+}
+
+// normalizeCountry canonicalizes user input such as " jp" to "JP"
+func normalizeCountry(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsSupportedCountry reports whether code, after normalization, is a known country
+func IsSupportedCountry(code string) bool {
+	_, ok := asiaCountryCoordinates[normalizeCountry(code)]
+	return ok
+}
+
+// SupportedCountries returns the supported country codes in sorted order
+func SupportedCountries() []string {
+	return slices.Sorted(maps.Keys(asiaCountryCoordinates))
+}
+
+// WithDefaultCountry makes batch fetches such as FetchWeatherMulti fetch
+// country in place of empty or unknown entries, reporting it on
+// WeatherResult.Substitute; results stay keyed by the code as given. This
+// applies before, and independently of, the unknown country policy, which
+// still governs single fetches and batches without a default country.
+func WithDefaultCountry(country string) Option {
+	return func(c *Client) {
+		c.defaultCountry = normalizeCountry(country)
+	}
+}
+
+// batchSubstitute returns the default country to fetch in place of country
+// when country is empty or unknown
+func (c *Client) batchSubstitute(country string) (string, bool) {
+	if c.defaultCountry == "" || IsSupportedCountry(country) {
+		return "", false
+	}
+	return c.defaultCountry, true
+}
+
+// coordinatesFor returns the coordinates for a country, honoring the
+// reference city, the city preference and the unknown country policy
+func (c *Client) coordinatesFor(country string) (Coordinates, error) {
+	country = normalizeCountry(country)
+	if entry, ok := c.referenceCity(country); ok {
+		return entry.coords, nil
+	}
+	if c.cityPreference == LargestCity {
+		if coords, ok := asiaLargestCityCoordinates[country]; ok {
+			return coords, nil
+		}
+	}
+	coords, ok := asiaCountryCoordinates[country]
+	if !ok {
+		if UnknownCountryPolicy(c.unknownCountryPolicy.Load()) != FallbackToTokyo {
+			return Coordinates{}, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+		}
+		coords = asiaCountryCoordinates["JP"]
+	}
+	return coords, nil
+}
+
+// cityFor returns the name of the city coordinatesFor uses for a country
+func (c *Client) cityFor(country string) string {
+	country = normalizeCountry(country)
+	if entry, ok := c.referenceCity(country); ok {
+		return entry.name
+	}
+	if c.cityPreference == LargestCity {
+		if city, ok := asiaLargestCities[country]; ok {
+			return city
+		}
+	}
+	if city, ok := asiaCountryCities[country]; ok {
+		return city
+	}
+	return asiaCountryCities["JP"]
+}
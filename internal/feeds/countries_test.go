@@ -0,0 +1,102 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestCityPreference(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []feeds.Option
+		wantCity string
+		wantLat  string
+		wantLon  string
+	}{
+		{name: "default", wantCity: "Beijing", wantLat: "39.9042", wantLon: "116.4074"},
+		{name: "largest city", options: []feeds.Option{feeds.WithCityPreference(feeds.LargestCity)}, wantCity: "Shanghai", wantLat: "31.2304", wantLon: "121.4737"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			data, err := srv.Client(t, tt.options...).FetchWeather(context.Background(), "CN")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.City != tt.wantCity {
+				t.Errorf("City = %q, want %q", data.City, tt.wantCity)
+			}
+			q := srv.Requests()[0].Query()
+			if lat, lon := q.Get("latitude"), q.Get("longitude"); lat != tt.wantLat || lon != tt.wantLon {
+				t.Errorf("requested %s, %s, want %s, %s", lat, lon, tt.wantLat, tt.wantLon)
+			}
+		})
+	}
+}
+
+func TestSetUnknownCountryPolicy(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	defer feeds.SetDefaultTransport(srv.Transport())()
+	defer feeds.SetUnknownCountryPolicy(feeds.RejectUnknown)
+	strict := srv.Client(t)
+
+	if _, err := feeds.FetchWeather("XX"); !errors.Is(err, feeds.ErrUnsupportedCountry) {
+		t.Fatalf("default policy: err = %v, want ErrUnsupportedCountry", err)
+	}
+
+	feeds.SetUnknownCountryPolicy(feeds.FallbackToTokyo)
+	data, err := feeds.FetchWeather("XX")
+	if err != nil {
+		t.Fatalf("FallbackToTokyo: %v", err)
+	}
+	if data.City != "Tokyo" {
+		t.Errorf("FallbackToTokyo: City = %q, want Tokyo", data.City)
+	}
+	if _, err := strict.FetchWeather(context.Background(), "XX"); !errors.Is(err, feeds.ErrUnsupportedCountry) {
+		t.Errorf("NewClient Client after SetUnknownCountryPolicy: err = %v, want ErrUnsupportedCountry", err)
+	}
+
+	feeds.SetUnknownCountryPolicy(feeds.RejectUnknown)
+	if _, err := feeds.FetchWeather("XX"); !errors.Is(err, feeds.ErrUnsupportedCountry) {
+		t.Errorf("policy reset: err = %v, want ErrUnsupportedCountry", err)
+	}
+}
+
+func TestIsSupportedCountry(t *testing.T) {
+	for code, want := range map[string]bool{"JP": true, "jp": true, " sg ": true, "XX": false, "": false, "JPN": false} {
+		if got := feeds.IsSupportedCountry(code); got != want {
+			t.Errorf("IsSupportedCountry(%q) = %v, want %v", code, got, want)
+		}
+	}
+
+	// The fetch path normalizes the same way
+	srv := feedstest.NewServer(t)
+	if _, err := srv.Client(t).FetchWeather(context.Background(), "jp"); err != nil {
+		t.Errorf("FetchWeather(jp): %v", err)
+	}
+}
+
+func TestApproximateResults(t *testing.T) {
+	c := feedstest.NewServer(t).Client(t)
+	ctx := context.Background()
+
+	byCountry, err := c.FetchWeather(ctx, "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !byCountry.Approximate || byCountry.City != "Tokyo" {
+		t.Errorf("country fetch: Approximate = %v, City = %q, want true, Tokyo", byCountry.Approximate, byCountry.City)
+	}
+
+	precise, err := c.FetchWeatherAt(ctx, feeds.Coordinates{Lat: 35.7100, Lon: 139.8107})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if precise.Approximate || precise.City != "" {
+		t.Errorf("coordinates fetch: Approximate = %v, City = %q, want false, none", precise.Approximate, precise.City)
+	}
+}
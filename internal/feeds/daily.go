@@ -0,0 +1,118 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxForecastDays is the longest daily forecast Open-Meteo provides
+const maxForecastDays = 16
+
+// ErrInvalidForecastDays is returned when a forecast is requested for outside 1 to 16 days
+var ErrInvalidForecastDays = errors.New("forecast days must be between 1 and 16")
+
+// openMeteoDateLayout is the local date format of Open-Meteo daily values
+const openMeteoDateLayout = "2006-01-02"
+
+// DailyForecast is one day of a multi-day forecast
+type DailyForecast struct {
+	// Date is local midnight of the day in the country's timezone
+	Date            time.Time `json:"date"`
+	Summary         string    `json:"summary"`
+	WeatherCode     int       `json:"weatherCode"`
+	MinTemperatureC float64   `json:"minTemperatureC"`
+	MaxTemperatureC float64   `json:"maxTemperatureC"`
+	// PrecipitationProbability is the day's highest hourly chance of
+	// precipitation in percent, or zero when the model doesn't provide one
+	PrecipitationProbability float64 `json:"precipitationProbability"`
+
+	Provenance
+}
+
+// dailyForecastResponse represents the daily block of an Open-Meteo forecast
+type dailyForecastResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Daily                struct {
+		Time                     []string   `json:"time"`
+		WeatherCode              []*int     `json:"weather_code"`
+		MaxTemperature           []*float64 `json:"temperature_2m_max"`
+		MinTemperature           []*float64 `json:"temperature_2m_min"`
+		PrecipitationProbability []*float64 `json:"precipitation_probability_max"`
+	} `json:"daily"`
+}
+
+// FetchForecast calls FetchForecast on the default Client
+func FetchForecast(ctx context.Context, country string, days int) ([]DailyForecast, error) {
+	return defaultClient.FetchForecast(ctx, country, days)
+}
+
+// FetchForecast returns a daily forecast for the next days days (1 to 16),
+// starting today, e.g. for a 7-day outlook
+func (c *Client) FetchForecast(ctx context.Context, country string, days int) ([]DailyForecast, error) {
+	if days < 1 || days > maxForecastDays {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidForecastDays, days)
+	}
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("daily=weather_code,temperature_2m_max,temperature_2m_min,precipitation_probability_max&timezone=auto&forecast_days=%d", days)
+	url, err := c.forecastURL(ctx, coords, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp dailyForecastResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	return c.dailyForecasts(ctx, &apiResp)
+}
+
+// dailyForecasts parses the daily arrays in the response timezone, reading up
+// to their shortest common length and skipping days without temperatures or
+// a weather code
+func (c *Client) dailyForecasts(ctx context.Context, apiResp *dailyForecastResponse) ([]DailyForecast, error) {
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+
+	d := apiResp.Daily
+	n := min(len(d.Time), len(d.WeatherCode), len(d.MaxTemperature), len(d.MinTemperature))
+	forecasts := make([]DailyForecast, 0, n)
+	for i := 0; i < n; i++ {
+		if d.WeatherCode[i] == nil || d.MaxTemperature[i] == nil || d.MinTemperature[i] == nil {
+			continue
+		}
+		date, err := time.ParseInLocation(openMeteoDateLayout, d.Time[i], loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: daily time: %w", ErrDecode, err)
+		}
+		if *d.MinTemperature[i] > *d.MaxTemperature[i] {
+			return nil, fmt.Errorf("%w: %s minimum %g above maximum %g", ErrInvalidUpstreamData, d.Time[i], *d.MinTemperature[i], *d.MaxTemperature[i])
+		}
+		temps := map[string]float64{"temperature_2m_min": *d.MinTemperature[i], "temperature_2m_max": *d.MaxTemperature[i]}
+		if err := c.plausible.check(temps, nil); err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Time[i], err)
+		}
+		day := DailyForecast{
+			Date:            date,
+			Summary:         c.describeWeatherCode(*d.WeatherCode[i]),
+			WeatherCode:     *d.WeatherCode[i],
+			MinTemperatureC: *d.MinTemperature[i],
+			MaxTemperatureC: *d.MaxTemperature[i],
+			Provenance:      c.provenance(c.openMeteoSource(ctx), time.Time{}),
+		}
+		if i < len(d.PrecipitationProbability) && d.PrecipitationProbability[i] != nil {
+			day.PrecipitationProbability = *d.PrecipitationProbability[i]
+		}
+		forecasts = append(forecasts, day)
+	}
+	if len(forecasts) == 0 {
+		return nil, fmt.Errorf("%w: no daily data", ErrDecode)
+	}
+	return forecasts, nil
+}
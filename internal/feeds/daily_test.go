@@ -0,0 +1,44 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestFetchForecastDays(t *testing.T) {
+	tests := []struct {
+		days    int
+		wantErr bool
+	}{
+		{days: 0, wantErr: true},
+		{days: 1},
+		{days: 16},
+		{days: 17, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.days), func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			_, err := srv.Client(t).FetchForecast(context.Background(), "JP", tt.days)
+			if tt.wantErr {
+				if !errors.Is(err, feeds.ErrInvalidForecastDays) {
+					t.Errorf("err = %v, want ErrInvalidForecastDays", err)
+				}
+				if n := len(srv.Requests()); n != 0 {
+					t.Errorf("%d upstream requests, want none", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := srv.Requests()[0].Query().Get("forecast_days"); got != strconv.Itoa(tt.days) {
+				t.Errorf("forecast_days = %q, want %d", got, tt.days)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// DaylightPhase is the part of the solar day a moment falls in
+type DaylightPhase string
+
+const (
+	PhaseDay      DaylightPhase = "day"
+	PhaseTwilight DaylightPhase = "twilight"
+	PhaseNight    DaylightPhase = "night"
+)
+
+// DaylightData is today's sun times for a place, in its local timezone
+type DaylightData struct {
+	// Date is local midnight of the day
+	Date    time.Time `json:"date"`
+	Sunrise time.Time `json:"sunrise"`
+	Sunset  time.Time `json:"sunset"`
+	// CivilDawn and CivilDusk bound civil twilight, when the sun is less than
+	// 6° below the horizon; they are zero where the sun doesn't get that low
+	CivilDawn        time.Time `json:"civilDawn,omitzero"`
+	CivilDusk        time.Time `json:"civilDusk,omitzero"`
+	DayLengthSeconds float64   `json:"dayLengthSeconds"`
+	// Phase is the phase at fetch time, for switching day and night themes
+	Phase DaylightPhase `json:"phase"`
+
+	Approximate bool   `json:"approximate,omitempty"`
+	City        string `json:"city,omitempty"`
+
+	Provenance
+}
+
+// PhaseAt returns the daylight phase at t, which should fall on d's Date
+func (d *DaylightData) PhaseAt(t time.Time) DaylightPhase {
+	switch {
+	case !t.Before(d.Sunrise) && t.Before(d.Sunset):
+		return PhaseDay
+	case !d.CivilDawn.IsZero() && !t.Before(d.CivilDawn) && t.Before(d.Sunrise),
+		!d.CivilDusk.IsZero() && !t.Before(d.Sunset) && t.Before(d.CivilDusk):
+		return PhaseTwilight
+	default:
+		return PhaseNight
+	}
+}
+
+// daylightResponse represents the daily sun times from Open-Meteo
+type daylightResponse struct {
+	Latitude             float64 `json:"latitude"`
+	Timezone             string  `json:"timezone"`
+	TimezoneAbbreviation string  `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int     `json:"utc_offset_seconds"`
+	Daily                struct {
+		Time             []string   `json:"time"`
+		Sunrise          []string   `json:"sunrise"`
+		Sunset           []string   `json:"sunset"`
+		DaylightDuration []*float64 `json:"daylight_duration"`
+	} `json:"daily"`
+}
+
+// FetchDaylight calls FetchDaylight on the default Client
+func FetchDaylight(ctx context.Context, country string) (*DaylightData, error) {
+	return defaultClient.FetchDaylight(ctx, country)
+}
+
+// FetchDaylight returns today's sunrise, sunset, day length and civil
+// twilight for a country's representative city
+func (c *Client) FetchDaylight(ctx context.Context, country string) (*DaylightData, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchDaylight(ctx, coords)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, c.cityFor(country)
+	return data, nil
+}
+
+// FetchDaylightForCity calls FetchDaylightForCity on the default Client
+func FetchDaylightForCity(ctx context.Context, country, city string) (*DaylightData, error) {
+	return defaultClient.FetchDaylightForCity(ctx, country, city)
+}
+
+// FetchDaylightForCity returns today's sun times for a city in the bundled
+// gazetteer, as FetchWeatherForCity does for weather
+func (c *Client) FetchDaylightForCity(ctx context.Context, country, city string) (*DaylightData, error) {
+	entry, err := lookupCity(country, city)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchDaylight(ctx, entry.coords)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, entry.name
+	return data, nil
+}
+
+// fetchDaylight fetches today's sun times at the given coordinates
+func (c *Client) fetchDaylight(ctx context.Context, coords Coordinates) (*DaylightData, error) {
+	url, err := c.forecastURL(ctx, coords, "daily=sunrise,sunset,daylight_duration&timezone=auto&forecast_days=1")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp daylightResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	data, err := decodeDaylight(&apiResp, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	data.Provenance = c.provenance(c.openMeteoSource(ctx), time.Time{})
+	return data, nil
+}
+
+// decodeDaylight builds DaylightData from the first day of the response,
+// with the phase at now
+func decodeDaylight(apiResp *daylightResponse, now time.Time) (*DaylightData, error) {
+	d := apiResp.Daily
+	if len(d.Time) == 0 || len(d.Sunrise) == 0 || len(d.Sunset) == 0 {
+		return nil, fmt.Errorf("%w: missing sunrise/sunset", ErrDecode)
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+
+	date, err := time.ParseInLocation(openMeteoDateLayout, d.Time[0], loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date: %w", ErrDecode, err)
+	}
+	sunrise, err := time.ParseInLocation(openMeteoTimeLayout, d.Sunrise[0], loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sunrise: %w", ErrDecode, err)
+	}
+	sunset, err := time.ParseInLocation(openMeteoTimeLayout, d.Sunset[0], loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sunset: %w", ErrDecode, err)
+	}
+
+	data := &DaylightData{
+		Date:             date,
+		Sunrise:          sunrise,
+		Sunset:           sunset,
+		DayLengthSeconds: sunset.Sub(sunrise).Seconds(),
+	}
+	// Prefer the API's day length, which isn't rounded to the minute
+	if len(d.DaylightDuration) > 0 && d.DaylightDuration[0] != nil {
+		data.DayLengthSeconds = *d.DaylightDuration[0]
+	}
+	if offset, ok := civilTwilightOffset(apiResp.Latitude, date); ok {
+		data.CivilDawn, data.CivilDusk = sunrise.Add(-offset), sunset.Add(offset)
+	}
+	data.Phase = data.PhaseAt(now.In(loc))
+	return data, nil
+}
+
+// civilTwilightOffset returns how long civil twilight lasts before sunrise
+// and after sunset at lat on date, from the solar hour angles at sunrise
+// (-0.833° for refraction and the sun's radius) and at -6°. It reports false
+// where the sun doesn't reach either altitude that day.
+func civilTwilightOffset(lat float64, date time.Time) (time.Duration, bool) {
+	const rad = math.Pi / 180
+	declination := -23.44 * math.Cos(2*math.Pi/365*float64(date.YearDay()+10))
+
+	hourAngle := func(altitude float64) (float64, bool) {
+		cos := (math.Sin(altitude*rad) - math.Sin(lat*rad)*math.Sin(declination*rad)) /
+			(math.Cos(lat*rad) * math.Cos(declination*rad))
+		if cos < -1 || cos > 1 {
+			return 0, false
+		}
+		return math.Acos(cos) / rad, true
+	}
+	atSunrise, ok := hourAngle(-0.833)
+	if !ok {
+		return 0, false
+	}
+	atDawn, ok := hourAngle(-6)
+	if !ok {
+		return 0, false
+	}
+	// The sun moves 15° of hour angle per hour, so 4 minutes per degree
+	return time.Duration((atDawn - atSunrise) * 4 * float64(time.Minute)).Round(time.Second), true
+}
@@ -0,0 +1,59 @@
+package feeds
+
+import "math"
+
+// DiffThresholds sets how large a change must be to count as meaningful
+type DiffThresholds struct {
+	// TemperatureC is the minimum absolute temperature change in °C
+	TemperatureC float64
+	// FeelsLikeC is the minimum absolute feels-like change in °C
+	FeelsLikeC float64
+}
+
+// DefaultDiffThresholds treats a 2°C swing in either temperature as meaningful
+var DefaultDiffThresholds = DiffThresholds{TemperatureC: 2, FeelsLikeC: 2}
+
+// WeatherDiff describes how conditions changed between two results. The
+// changes are judged from weather codes, not Summary, whose wording depends
+// on the locale and the time of day.
+type WeatherDiff struct {
+	TemperatureDeltaC float64 `json:"temperatureDeltaC"`
+	FeelsLikeDeltaC   float64 `json:"feelsLikeDeltaC"`
+	// GroupChanged is true when the codes fall in different WeatherGroups,
+	// such as clear to rain
+	GroupChanged bool `json:"groupChanged"`
+	// SeverityChanged is true when the codes have different severities,
+	// such as light to heavy rain
+	SeverityChanged bool `json:"severityChanged"`
+
+	// Meaningful is true when the group or severity changed or a delta met
+	// its threshold
+	Meaningful bool `json:"meaningful"`
+}
+
+// Diff compares two results using DefaultDiffThresholds
+func Diff(old, new *WeatherData) WeatherDiff {
+	return DiffWithThresholds(old, new, DefaultDiffThresholds)
+}
+
+// DiffWithThresholds compares two results; a nil old result always counts as
+// a meaningful group change
+func DiffWithThresholds(old, new *WeatherData, t DiffThresholds) WeatherDiff {
+	if new == nil {
+		return WeatherDiff{}
+	}
+	if old == nil {
+		return WeatherDiff{GroupChanged: true, Meaningful: true}
+	}
+
+	d := WeatherDiff{
+		TemperatureDeltaC: new.TemperatureC - old.TemperatureC,
+		FeelsLikeDeltaC:   new.FeelsLikeC - old.FeelsLikeC,
+		GroupChanged:      WeatherGroupFor(new.WeatherCode) != WeatherGroupFor(old.WeatherCode),
+		SeverityChanged:   SeverityFor(new.WeatherCode) != SeverityFor(old.WeatherCode),
+	}
+	d.Meaningful = d.GroupChanged || d.SeverityChanged ||
+		math.Abs(d.TemperatureDeltaC) >= t.TemperatureC ||
+		math.Abs(d.FeelsLikeDeltaC) >= t.FeelsLikeC
+	return d
+}
@@ -0,0 +1,76 @@
+package feeds_test
+
+import (
+	"math"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestDiff(t *testing.T) {
+	sunny := &feeds.WeatherData{Summary: "Clear sky", WeatherCode: 0, TemperatureC: 30, FeelsLikeC: 33}
+	tests := []struct {
+		name string
+		old  *feeds.WeatherData
+		new  *feeds.WeatherData
+		want feeds.WeatherDiff
+	}{
+		{
+			name: "group change with a small temperature change",
+			old:  sunny,
+			new:  &feeds.WeatherData{Summary: "Slight rain", WeatherCode: 61, TemperatureC: 29.5, FeelsLikeC: 32.5},
+			want: feeds.WeatherDiff{TemperatureDeltaC: -0.5, FeelsLikeDeltaC: -0.5, GroupChanged: true, Meaningful: true},
+		},
+		{
+			name: "small temperature change only",
+			old:  sunny,
+			new:  &feeds.WeatherData{Summary: "Clear sky", WeatherCode: 0, TemperatureC: 31, FeelsLikeC: 34},
+			want: feeds.WeatherDiff{TemperatureDeltaC: 1, FeelsLikeDeltaC: 1},
+		},
+		{
+			name: "summary reworded after sunset",
+			old:  sunny,
+			new:  &feeds.WeatherData{Summary: "Clear night", WeatherCode: 0, TemperatureC: 30, FeelsLikeC: 33},
+			want: feeds.WeatherDiff{},
+		},
+		{
+			name: "severity change within a group",
+			old:  &feeds.WeatherData{WeatherCode: 61, TemperatureC: 25, FeelsLikeC: 27},
+			new:  &feeds.WeatherData{WeatherCode: 65, TemperatureC: 25, FeelsLikeC: 27},
+			want: feeds.WeatherDiff{SeverityChanged: true, Meaningful: true},
+		},
+		{
+			name: "temperature change at the threshold",
+			old:  sunny,
+			new:  &feeds.WeatherData{WeatherCode: 1, TemperatureC: 32, FeelsLikeC: 33},
+			want: feeds.WeatherDiff{TemperatureDeltaC: 2, Meaningful: true},
+		},
+		{
+			name: "no previous result",
+			new:  sunny,
+			want: feeds.WeatherDiff{GroupChanged: true, Meaningful: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := feeds.Diff(tt.old, tt.new)
+			if math.Abs(got.TemperatureDeltaC-tt.want.TemperatureDeltaC) < 1e-9 {
+				got.TemperatureDeltaC = tt.want.TemperatureDeltaC
+			}
+			if math.Abs(got.FeelsLikeDeltaC-tt.want.FeelsLikeDeltaC) < 1e-9 {
+				got.FeelsLikeDeltaC = tt.want.FeelsLikeDeltaC
+			}
+			if got != tt.want {
+				t.Errorf("Diff = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffWithThresholds(t *testing.T) {
+	old := &feeds.WeatherData{WeatherCode: 3, TemperatureC: 20, FeelsLikeC: 20}
+	new := &feeds.WeatherData{WeatherCode: 3, TemperatureC: 21, FeelsLikeC: 20}
+	if d := feeds.DiffWithThresholds(old, new, feeds.DiffThresholds{TemperatureC: 0.5, FeelsLikeC: 2}); !d.Meaningful {
+		t.Errorf("1°C change with a 0.5°C threshold: %+v, want meaningful", d)
+	}
+}
@@ -0,0 +1,47 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+)
+
+// groupSeverity ranks weather groups from least to most severe, for breaking ties
+var groupSeverity = map[WeatherGroup]int{
+	GroupUnknown:      0,
+	GroupClear:        1,
+	GroupCloudy:       2,
+	GroupFog:          3,
+	GroupRain:         4,
+	GroupSnow:         5,
+	GroupThunderstorm: 6,
+}
+
+// RegionDominantCondition calls RegionDominantCondition on the default Client
+func RegionDominantCondition(ctx context.Context, countries []string) (WeatherGroup, int, int, error) {
+	return defaultClient.RegionDominantCondition(ctx, countries)
+}
+
+// RegionDominantCondition fetches countries and returns the most common
+// weather group among those that reported, how many share it and how many
+// reported, e.g. for "Mostly rainy across Southeast Asia". Ties go to the more
+// severe group. Failed countries reduce the total and are reported through an
+// error wrapping ErrPartialFailure; if every fetch fails the error wraps
+// ErrAllFailed.
+func (c *Client) RegionDominantCondition(ctx context.Context, countries []string) (WeatherGroup, int, int, error) {
+	results, err := c.FetchWeatherMulti(ctx, countries)
+	if errors.Is(err, ErrAllFailed) {
+		return "", 0, 0, err
+	}
+
+	counts := make(map[WeatherGroup]int)
+	for _, data := range results {
+		counts[data.Group()]++
+	}
+	best, bestCount := GroupUnknown, 0
+	for group, n := range counts {
+		if n > bestCount || (n == bestCount && groupSeverity[group] > groupSeverity[best]) {
+			best, bestCount = group, n
+		}
+	}
+	return best, bestCount, len(results), err
+}
@@ -0,0 +1,57 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestRegionDominantCondition(t *testing.T) {
+	rain := countryWeather{TempC: 27, Code: 61}
+	showers := countryWeather{TempC: 28, Code: 80}
+	sunny := countryWeather{TempC: 32, Code: 0}
+	storm := countryWeather{TempC: 29, Code: 95}
+	failed := countryWeather{Status: http.StatusInternalServerError}
+
+	tests := []struct {
+		name      string
+		weather   map[string]countryWeather
+		wantGroup feeds.WeatherGroup
+		wantCount int
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name:      "two of three share a group",
+			weather:   map[string]countryWeather{"SG": rain, "MY": showers, "TH": sunny},
+			wantGroup: feeds.GroupRain, wantCount: 2, wantTotal: 3,
+		},
+		{
+			name:      "tie goes to the more severe group",
+			weather:   map[string]countryWeather{"SG": sunny, "MY": storm, "TH": failed},
+			wantGroup: feeds.GroupThunderstorm, wantCount: 1, wantTotal: 2,
+			wantErr: feeds.ErrPartialFailure,
+		},
+		{
+			name:    "all fail",
+			weather: map[string]countryWeather{"SG": failed, "MY": failed, "TH": failed},
+			wantErr: feeds.ErrAllFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := stubClient(t, countryTransport(t, tt.weather))
+
+			group, count, total, err := c.RegionDominantCondition(context.Background(), []string{"SG", "MY", "TH"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if group != tt.wantGroup || count != tt.wantCount || total != tt.wantTotal {
+				t.Errorf("got %q %d of %d, want %q %d of %d", group, count, total, tt.wantGroup, tt.wantCount, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// usgsEventEndpoint is the USGS FDSN event query API
+const usgsEventEndpoint = "https://earthquake.usgs.gov/fdsnws/event/1/query"
+
+// asiaBounds is the bounding box earthquake searches cover, spanning the
+// supported countries from India to Japan and Indonesia
+var asiaBounds = struct {
+	minLat, maxLat, minLon, maxLon float64
+}{minLat: -11, maxLat: 55, minLon: 60, maxLon: 150}
+
+// Earthquake is a recorded earthquake
+type Earthquake struct {
+	ID          string      `json:"id"`
+	Magnitude   float64     `json:"magnitude"`
+	Place       string      `json:"place"`
+	Coordinates Coordinates `json:"coordinates"`
+	DepthKm     float64     `json:"depthKm"`
+	Time        time.Time   `json:"time"`
+	// Tsunami is true when USGS flagged the event for possible tsunami
+	// impact; it is not itself a tsunami warning
+	Tsunami bool   `json:"tsunami"`
+	URL     string `json:"url,omitempty"`
+
+	Provenance
+}
+
+// usgsResponse represents the GeoJSON returned by the USGS event API
+type usgsResponse struct {
+	Features []struct {
+		ID         string `json:"id"`
+		Properties struct {
+			Mag     *float64 `json:"mag"`
+			Place   string   `json:"place"`
+			Time    int64    `json:"time"`
+			Tsunami int      `json:"tsunami"`
+			URL     string   `json:"url"`
+		} `json:"properties"`
+		Geometry struct {
+			// Coordinates are longitude, latitude and depth in km
+			Coordinates []float64 `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"features"`
+}
+
+// FetchEarthquakes calls FetchEarthquakes on the default Client
+func FetchEarthquakes(ctx context.Context, minMagnitude float64, since time.Time) ([]Earthquake, error) {
+	return defaultClient.FetchEarthquakes(ctx, minMagnitude, since)
+}
+
+// FetchEarthquakes returns earthquakes across Asia since a given time with at
+// least minMagnitude, newest first, from the USGS catalog
+func (c *Client) FetchEarthquakes(ctx context.Context, minMagnitude float64, since time.Time) ([]Earthquake, error) {
+	q := url.Values{}
+	q.Set("format", "geojson")
+	q.Set("orderby", "time")
+	q.Set("starttime", since.UTC().Format(time.RFC3339))
+	q.Set("minmagnitude", strconv.FormatFloat(minMagnitude, 'f', -1, 64))
+	q.Set("minlatitude", strconv.FormatFloat(asiaBounds.minLat, 'f', -1, 64))
+	q.Set("maxlatitude", strconv.FormatFloat(asiaBounds.maxLat, 'f', -1, 64))
+	q.Set("minlongitude", strconv.FormatFloat(asiaBounds.minLon, 'f', -1, 64))
+	q.Set("maxlongitude", strconv.FormatFloat(asiaBounds.maxLon, 'f', -1, 64))
+
+	var apiResp usgsResponse
+	if err := c.getJSON(ctx, usgsEventEndpoint+"?"+q.Encode(), c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	return c.earthquakes(&apiResp)
+}
+
+// earthquakes converts USGS features, skipping any without a magnitude
+func (c *Client) earthquakes(apiResp *usgsResponse) ([]Earthquake, error) {
+	quakes := make([]Earthquake, 0, len(apiResp.Features))
+	for _, f := range apiResp.Features {
+		if f.Properties.Mag == nil {
+			continue
+		}
+		if len(f.Geometry.Coordinates) < 3 {
+			return nil, fmt.Errorf("%w: earthquake %s has no location", ErrDecode, f.ID)
+		}
+		quakes = append(quakes, Earthquake{
+			ID:          f.ID,
+			Magnitude:   *f.Properties.Mag,
+			Place:       f.Properties.Place,
+			Coordinates: Coordinates{Lat: f.Geometry.Coordinates[1], Lon: f.Geometry.Coordinates[0]},
+			DepthKm:     f.Geometry.Coordinates[2],
+			Time:        time.UnixMilli(f.Properties.Time).UTC(),
+			Tsunami:     f.Properties.Tsunami == 1,
+			URL:         f.Properties.URL,
+			Provenance:  c.provenance(sourceUSGS, time.Time{}),
+		})
+	}
+	return quakes, nil
+}
@@ -0,0 +1,115 @@
+package feeds
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// DefaultEnsembleModels are the models FetchEnsemble compares when none are given
+var DefaultEnsembleModels = []string{"jma_seamless", "gfs_seamless", "ecmwf_ifs025", "icon_seamless"}
+
+// ConditionCandidate is a weather code predicted by one or more models
+type ConditionCandidate struct {
+	WeatherCode int          `json:"weatherCode"`
+	Summary     string       `json:"summary"`
+	Group       WeatherGroup `json:"group"`
+	// Models lists the models that predicted this code
+	Models []string `json:"models"`
+	// Confidence is the fraction of reporting models that agree, 0 to 1
+	Confidence float64 `json:"confidence"`
+}
+
+// FetchEnsemble calls FetchEnsemble on the default Client
+func FetchEnsemble(ctx context.Context, country string, models ...string) ([]ConditionCandidate, error) {
+	return defaultClient.FetchEnsemble(ctx, country, models...)
+}
+
+// FetchEnsemble asks several weather models for the current condition and
+// returns each distinct weather code with the share of models predicting it,
+// most likely first. Models that return no value for the location are left
+// out of the confidence calculation. It uses DefaultEnsembleModels when no
+// models are given and ignores WithModel.
+func (c *Client) FetchEnsemble(ctx context.Context, country string, models ...string) ([]ConditionCandidate, error) {
+	if len(models) == 0 {
+		models = DefaultEnsembleModels
+	}
+	for _, m := range models {
+		if !slices.Contains(KnownModels, m) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownModel, m)
+		}
+	}
+
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	reqURL := c.baseForecastURL(coords, "current=weather_code&models="+url.QueryEscape(strings.Join(models, ",")))
+
+	var raw json.RawMessage
+	if err := c.getJSON(ctx, reqURL, c.maxCurrentBytes, &raw); err != nil {
+		return nil, err
+	}
+	return c.ensembleCandidates(raw, models)
+}
+
+// ensembleCandidates tallies per-model weather codes. With several models
+// Open-Meteo suffixes each field with the model name, e.g.
+// "weather_code_jma_seamless"; with one it uses the plain field name.
+func (c *Client) ensembleCandidates(raw []byte, models []string) ([]ConditionCandidate, error) {
+	var resp struct {
+		Current map[string]json.RawMessage `json:"current"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+
+	byCode := make(map[int]*ConditionCandidate)
+	reporting := 0
+	for _, m := range models {
+		field := "weather_code_" + m
+		if len(models) == 1 {
+			field = "weather_code"
+		}
+		v, ok := resp.Current[field]
+		if !ok || string(v) == "null" {
+			continue
+		}
+		var code int
+		if err := json.Unmarshal(v, &code); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrDecode, field, err)
+		}
+		reporting++
+
+		cand, ok := byCode[code]
+		if !ok {
+			cand = &ConditionCandidate{
+				WeatherCode: code,
+				Summary:     c.describeWeatherCode(code),
+				Group:       WeatherGroupFor(code),
+			}
+			byCode[code] = cand
+		}
+		cand.Models = append(cand.Models, m)
+	}
+	if reporting == 0 {
+		return nil, fmt.Errorf("%w: no model reported a weather code", ErrDecode)
+	}
+
+	candidates := make([]ConditionCandidate, 0, len(byCode))
+	for _, cand := range byCode {
+		cand.Confidence = float64(len(cand.Models)) / float64(reporting)
+		candidates = append(candidates, *cand)
+	}
+	slices.SortFunc(candidates, func(a, b ConditionCandidate) int {
+		if n := cmp.Compare(len(b.Models), len(a.Models)); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.WeatherCode, b.WeatherCode)
+	})
+	return candidates, nil
+}
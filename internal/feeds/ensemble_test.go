@@ -0,0 +1,53 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestFetchEnsemble(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, []byte(`{"current":{"time":"2025-01-15T12:00",`+
+		`"weather_code_jma_seamless":61,"weather_code_gfs_seamless":3,"weather_code_ecmwf_ifs025":61,"weather_code_icon_seamless":null}}`))
+
+	got, err := srv.Client(t).FetchEnsemble(context.Background(), "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ICON has no value, so three models report
+	want := []feeds.ConditionCandidate{
+		{WeatherCode: 61, Summary: "Slight rain", Group: feeds.GroupRain, Models: []string{"jma_seamless", "ecmwf_ifs025"}, Confidence: 2.0 / 3},
+		{WeatherCode: 3, Summary: "Overcast", Group: feeds.GroupCloudy, Models: []string{"gfs_seamless"}, Confidence: 1.0 / 3},
+	}
+	if !slices.EqualFunc(got, want, func(a, b feeds.ConditionCandidate) bool {
+		return a.WeatherCode == b.WeatherCode && a.Summary == b.Summary && a.Group == b.Group &&
+			slices.Equal(a.Models, b.Models) && a.Confidence == b.Confidence
+	}) {
+		t.Errorf("candidates = %+v, want %+v", got, want)
+	}
+	if models := srv.Requests()[0].Query().Get("models"); models != "jma_seamless,gfs_seamless,ecmwf_ifs025,icon_seamless" {
+		t.Errorf("models = %q, want the default ensemble", models)
+	}
+}
+
+func TestFetchEnsembleSingleModel(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	got, err := srv.Client(t).FetchEnsemble(context.Background(), "JP", "jma_seamless")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One model uses the fixture's plain weather_code
+	if len(got) != 1 || got[0].WeatherCode != 1 || got[0].Confidence != 1 {
+		t.Errorf("candidates = %+v, want code 1 with confidence 1", got)
+	}
+
+	if _, err := srv.Client(t).FetchEnsemble(context.Background(), "JP", "bogus"); !errors.Is(err, feeds.ErrUnknownModel) {
+		t.Errorf("unknown model: err = %v, want ErrUnknownModel", err)
+	}
+}
@@ -0,0 +1,71 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Failures fall into a few classes callers can test with errors.Is:
+// ErrProviderUnavailable and ErrRateLimited are temporary and worth retrying
+// later; ErrDecode means upstream sent something unusable; input errors such
+// as ErrUnsupportedCountry, ErrUnknownCity and *ErrInvalidCoordinates are
+// permanent. IsTemporary tells the first group from the rest.
+
+// ErrProviderUnavailable is matched by failures to get an answer from
+// upstream: network errors, timeouts, 5xx responses and open circuit breakers
+var ErrProviderUnavailable = errors.New("weather provider unavailable")
+
+// ErrDecode is returned when an upstream response can't be decoded
+var ErrDecode = errors.New("failed to parse weather response")
+
+// ErrWeatherDecode is the former name of ErrDecode.
+//
+// Deprecated: use ErrDecode.
+var ErrWeatherDecode = ErrDecode
+
+// ProviderError is a failed upstream request: a network error when
+// StatusCode is zero, otherwise a non-200 response. It matches
+// ErrProviderUnavailable for network errors and 5xx statuses, and
+// ErrRateLimited for 429 Too Many Requests.
+type ProviderError struct {
+	Host       string
+	StatusCode int
+	// RetryAfter is the response's Retry-After, zero when absent
+	RetryAfter time.Duration
+	// Err is the network error when StatusCode is zero
+	Err error
+}
+
+func (e *ProviderError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s API call failed: %v", e.Host, e.Err)
+	}
+	return fmt.Sprintf("%s API returned status %d", e.Host, e.StatusCode)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ProviderError) Is(target error) bool {
+	switch target {
+	case ErrProviderUnavailable:
+		if e.StatusCode == 0 {
+			// The caller giving up says nothing about the provider
+			return !errors.Is(e.Err, context.Canceled)
+		}
+		return e.StatusCode >= http.StatusInternalServerError
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// IsTemporary reports whether err is a failure that may clear up by itself,
+// so the same request is worth making again later
+func IsTemporary(err error) bool {
+	return errors.Is(err, ErrProviderUnavailable) || errors.Is(err, ErrRateLimited)
+}
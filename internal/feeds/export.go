@@ -0,0 +1,241 @@
+package feeds
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// ExportFormat is a file format of snapshot exports
+type ExportFormat string
+
+// Export formats
+const (
+	// ExportJSON is the StoreSnapshot as JSON, as GET /v1/snapshot serves it
+	ExportJSON ExportFormat = "json"
+	// ExportCSV has one row per field of every feed value; see WriteCSV
+	ExportCSV ExportFormat = "csv"
+)
+
+// ExportFormats lists the supported export formats
+var ExportFormats = []ExportFormat{ExportJSON, ExportCSV}
+
+// ErrUnknownExportFormat is returned for export formats not in ExportFormats
+var ErrUnknownExportFormat = errors.New("unknown export format")
+
+// exportTimeLayout names export files by snapshot time, sorting in time order
+const exportTimeLayout = "20060102T150405Z"
+
+// csvHeader is the header row of CSV exports
+var csvHeader = []string{"country", "feed", "fetched_at", "field", "value"}
+
+// Write writes the snapshot to w in format
+func (s StoreSnapshot) Write(w io.Writer, format ExportFormat) error {
+	switch format {
+	case ExportJSON:
+		return s.WriteJSON(w)
+	case ExportCSV:
+		return s.WriteCSV(w)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownExportFormat, format)
+	}
+}
+
+// WriteJSON writes the snapshot to w as indented JSON
+func (s StoreSnapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// WriteCSV writes the snapshot to w in long form, one row per field of each
+// feed value, so feeds of any shape share the columns country, feed,
+// fetched_at, field and value. Fields are JSON paths joined with dots, such
+// as "days.0.grass.band", and global feeds have an empty country. Rows are
+// sorted by country, feed and field.
+func (s StoreSnapshot) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	write := func(country string, feeds map[string]Snapshot) error {
+		for _, feed := range slices.Sorted(maps.Keys(feeds)) {
+			snap := feeds[feed]
+			fields, err := flattenValue(snap.Value)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", country, feed, err)
+			}
+			fetchedAt := snap.FetchedAt.UTC().Format(time.RFC3339)
+			for _, field := range slices.Sorted(maps.Keys(fields)) {
+				if err := cw.Write([]string{country, feed, fetchedAt, field, fields[field]}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := write("", s.Global); err != nil {
+		return err
+	}
+	for _, country := range slices.Sorted(maps.Keys(s.Countries)) {
+		if err := write(country, s.Countries[country]); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// flattenValue maps the JSON paths of a feed value's leaves to their values.
+// Going through JSON keeps field names and omissions as the API has them.
+func flattenValue(v any) (map[string]string, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string)
+	flatten(fields, "", tree)
+	return fields, nil
+}
+
+// flatten adds the leaves under node to fields, their paths prefixed by path
+func flatten(fields map[string]string, path string, node any) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	switch n := node.(type) {
+	case map[string]any:
+		for key, child := range n {
+			flatten(fields, join(key), child)
+		}
+	case []any:
+		for i, child := range n {
+			flatten(fields, join(strconv.Itoa(i)), child)
+		}
+	case nil:
+		fields[path] = ""
+	default:
+		fields[path] = fmt.Sprint(n)
+	}
+}
+
+// Exporter writes Store snapshots to files in a directory, for archiving
+// regional conditions
+type Exporter struct {
+	store   *Store
+	dir     string
+	formats []ExportFormat
+}
+
+// NewExporter creates an Exporter of store into dir, writing each snapshot
+// in every format given, or JSON and CSV when none are. Unknown formats
+// fail with ErrUnknownExportFormat.
+func NewExporter(store *Store, dir string, formats ...ExportFormat) (*Exporter, error) {
+	if len(formats) == 0 {
+		formats = ExportFormats
+	}
+	for _, f := range formats {
+		if !slices.Contains(ExportFormats, f) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownExportFormat, f)
+		}
+	}
+	return &Exporter{store: store, dir: dir, formats: slices.Clone(formats)}, nil
+}
+
+// Export writes one snapshot of the store in every format, to files named
+// like "reef-20250115T030000Z.json" for the snapshot time, and returns their
+// paths. Files are written whole, so readers never see partial exports.
+func (e *Exporter) Export() ([]string, error) {
+	if err := os.MkdirAll(e.dir, 0o755); err != nil {
+		return nil, err
+	}
+	snap := e.store.Snapshot()
+	name := "reef-" + snap.TakenAt.UTC().Format(exportTimeLayout)
+
+	paths := make([]string, 0, len(e.formats))
+	for _, format := range e.formats {
+		path := filepath.Join(e.dir, name+"."+string(format))
+		if err := writeFileAtomic(path, func(w io.Writer) error { return snap.Write(w, format) }); err != nil {
+			return paths, fmt.Errorf("export %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Run exports every interval until ctx is done, starting one interval in so
+// a Refresher started alongside has values to export, and once more as it
+// stops so values refreshed since the last export aren't lost on shutdown.
+// Failed exports are logged and retried at the next interval.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			e.exportOnce(ctx)
+			return
+		}
+		e.exportOnce(ctx)
+	}
+}
+
+// exportOnce exports, logging the outcome
+func (e *Exporter) exportOnce(ctx context.Context) {
+	if paths, err := e.Export(); err != nil {
+		logger.Warnf("%sexport failed: %v", logPrefix(ctx), err)
+	} else {
+		logger.Debugf("%sexported %v", logPrefix(ctx), paths)
+	}
+}
+
+// writeFileAtomic writes path through a temporary file in the same
+// directory, renamed into place once complete
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	// Exports are for other users to read, unlike CreateTemp's default
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package feeds
+
+import (
+	"net/http"
+	"time"
+)
+
+// CountryCoordinates are the built-in representative coordinates, for
+// stubbing upstream responses per country
+var CountryCoordinates = asiaCountryCoordinates
+
+// SetDefaultTransport sends the default Client's requests through rt, with
+// its cache off, until the returned function restores it
+func SetDefaultTransport(rt http.RoundTripper) (restore func()) {
+	hc, ttl := defaultClient.httpClient, defaultClient.cacheTTL
+	defaultClient.httpClient = &http.Client{Transport: rt}
+	defaultClient.cacheTTL = 0
+	return func() {
+		defaultClient.httpClient, defaultClient.cacheTTL = hc, ttl
+	}
+}
+
+// Jitter returns the retry wait drawn for backoff
+func (c *Client) Jitter(backoff time.Duration) time.Duration {
+	return c.jitter(backoff)
+}
@@ -0,0 +1,36 @@
+package feeds
+
+import (
+	"errors"
+	"math/rand/v2"
+)
+
+// ErrInjectedFault is the failure used by WithFaultInjection when no errors are given
+var ErrInjectedFault = errors.New("injected fault")
+
+// faultConfig describes the failures a Client injects for chaos testing
+type faultConfig struct {
+	probability float64
+	errs        []error
+}
+
+// WithFaultInjection makes roughly probability (0 to 1) of calls fail before
+// reaching the network, with an error picked at random from errs, or
+// ErrInjectedFault when none are given. Intended for resilience testing
+// only; fault injection is off unless this option is used.
+func WithFaultInjection(probability float64, errs ...error) Option {
+	return func(c *Client) {
+		if len(errs) == 0 {
+			errs = []error{ErrInjectedFault}
+		}
+		c.faults = &faultConfig{probability: probability, errs: errs}
+	}
+}
+
+// injectFault returns the injected error for this call, or nil
+func (c *Client) injectFault() error {
+	if c.faults == nil || rand.Float64() >= c.faults.probability {
+		return nil
+	}
+	return c.faults.errs[rand.IntN(len(c.faults.errs))]
+}
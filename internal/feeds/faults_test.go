@@ -0,0 +1,51 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestFaultInjection(t *testing.T) {
+	errTimeout := errors.New("injected timeout")
+	tests := []struct {
+		name    string
+		option  feeds.Option
+		allowed []error
+	}{
+		{name: "default error", option: feeds.WithFaultInjection(1), allowed: []error{feeds.ErrInjectedFault}},
+		{name: "given errors", option: feeds.WithFaultInjection(1, errTimeout, feeds.ErrProviderUnavailable), allowed: []error{errTimeout, feeds.ErrProviderUnavailable}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			c := srv.Client(t, tt.option)
+
+			for range 20 {
+				_, err := c.FetchWeather(context.Background(), "JP")
+				matched := false
+				for _, want := range tt.allowed {
+					matched = matched || errors.Is(err, want)
+				}
+				if !matched {
+					t.Fatalf("err = %v, want one of %v", err, tt.allowed)
+				}
+			}
+			if n := len(srv.Requests()); n != 0 {
+				t.Errorf("%d upstream requests with every call failing, want 0", n)
+			}
+		})
+	}
+}
+
+func TestFaultInjectionOff(t *testing.T) {
+	c := feedstest.NewServer(t).Client(t, feeds.WithFaultInjection(0), feeds.WithCacheTTL(0))
+	for range 20 {
+		if _, err := c.FetchWeather(context.Background(), "JP"); err != nil {
+			t.Fatalf("err = %v with probability 0", err)
+		}
+	}
+}
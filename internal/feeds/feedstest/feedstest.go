@@ -0,0 +1,54 @@
+// Package feedstest provides fakes and canned upstream responses for testing
+// code built on the feeds package without network access
+package feedstest
+
+import (
+	"context"
+	"sync"
+
+	"reef-asia/internal/feeds"
+)
+
+// Provider is a fake feeds.WeatherProvider that returns Data, or Err when it
+// is set, and records the coordinates it was asked for. The zero Provider
+// returns empty WeatherData.
+type Provider struct {
+	Data *feeds.WeatherData
+	Err  error
+
+	mu    sync.Mutex
+	calls []feeds.Coordinates
+}
+
+// Fetch returns a copy of Data, so callers can't change later results
+func (p *Provider) Fetch(ctx context.Context, coords feeds.Coordinates) (*feeds.WeatherData, error) {
+	p.mu.Lock()
+	p.calls = append(p.calls, coords)
+	p.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	data := feeds.WeatherData{}
+	if p.Data != nil {
+		data = *p.Data
+	}
+	return &data, nil
+}
+
+// Calls returns the coordinates of every Fetch so far, in call order
+func (p *Provider) Calls() []feeds.Coordinates {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]feeds.Coordinates(nil), p.calls...)
+}
+
+// ProviderFunc adapts a function to feeds.WeatherProvider
+type ProviderFunc func(ctx context.Context, coords feeds.Coordinates) (*feeds.WeatherData, error)
+
+func (f ProviderFunc) Fetch(ctx context.Context, coords feeds.Coordinates) (*feeds.WeatherData, error) {
+	return f(ctx, coords)
+}
@@ -0,0 +1,114 @@
+package feedstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+// Server is a stub of the upstream APIs. Clients from Client send every
+// request to it, whatever the upstream host, and it answers with the
+// response registered for that host and path.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string]response
+	requests  []*url.URL
+}
+
+// response is a canned reply to one upstream host and path
+type response struct {
+	status int
+	body   []byte
+}
+
+// NewServer starts a Server answering the Open-Meteo forecast, air quality,
+// marine and archive APIs with the bundled fixtures. It is closed when the
+// test ends. Unregistered requests get 404 Not Found.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{responses: make(map[string]response)}
+	s.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, Fixture(ForecastFixture))
+	s.Handle("https://air-quality-api.open-meteo.com/v1/air-quality", http.StatusOK, Fixture(AirQualityFixture))
+	s.Handle("https://marine-api.open-meteo.com/v1/marine", http.StatusOK, Fixture(MarineFixture))
+	s.Handle("https://archive-api.open-meteo.com/v1/archive", http.StatusOK, Fixture(ArchiveFixture))
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Handle answers requests to endpoint, an upstream URL whose query is
+// ignored, with status and body, replacing any earlier response
+func (s *Server) Handle(endpoint string, status int, body []byte) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		panic("feedstest: bad endpoint " + endpoint)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[u.Host+u.Path] = response{status: status, body: body}
+}
+
+// Requests returns the upstream URLs requested so far, in request order
+func (s *Server) Requests() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*url.URL(nil), s.requests...)
+}
+
+// Transport returns a RoundTripper sending every request to the Server,
+// carrying the upstream host as the first path segment
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Path = "/" + req.URL.Host + req.URL.Path
+		req.URL.RawPath = ""
+		req.URL.Scheme, req.URL.Host, req.Host = target.Scheme, target.Host, ""
+		return http.DefaultTransport.RoundTrip(req)
+	})
+}
+
+// Client returns a feeds.Client using the Server, with options applied
+// after the transport. Retries are off, so error responses fail at once.
+func (s *Server) Client(t testing.TB, options ...feeds.Option) *feeds.Client {
+	t.Helper()
+	options = append([]feeds.Option{
+		feeds.WithTransport(s.Transport()),
+		feeds.WithRetryPolicy(feeds.RetryPolicy{MaxAttempts: 1, RetryableStatus: feeds.DefaultRetryPolicy.RetryableStatus}),
+	}, options...)
+	c, err := feeds.NewClient(options...)
+	if err != nil {
+		t.Fatalf("feedstest: %v", err)
+	}
+	return c
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	host, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	upstream := &url.URL{Scheme: "https", Host: host, Path: "/" + path, RawQuery: r.URL.RawQuery}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, upstream)
+	resp, ok := s.responses[host+"/"+path]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "feedstest: no response for "+upstream.String(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.status)
+	w.Write(resp.body)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,95 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WeatherField is an optional Open-Meteo current variable that
+// WithWeatherFields adds to WeatherData
+type WeatherField string
+
+const (
+	// WindDirectionField fills WeatherData.WindDirection
+	WindDirectionField WeatherField = "wind_direction_10m"
+	// PressureField fills WeatherData.SurfacePressureHPa
+	PressureField WeatherField = "surface_pressure"
+	// CloudCoverField fills WeatherData.CloudCover
+	CloudCoverField WeatherField = "cloud_cover"
+	// PrecipitationField fills WeatherData.PrecipitationMm
+	PrecipitationField WeatherField = "precipitation"
+)
+
+// AllWeatherFields are every optional field, for WithWeatherFields(AllWeatherFields...)
+var AllWeatherFields = []WeatherField{WindDirectionField, PressureField, CloudCoverField, PrecipitationField}
+
+// ErrUnknownWeatherField reports a WithWeatherFields field that isn't one of AllWeatherFields
+var ErrUnknownWeatherField = errors.New("unknown weather field")
+
+// WithWeatherFields fetches optional fields on top of the default current
+// conditions, which stay the lightweight summary, temperature, wind speed,
+// humidity and UV index. Only Open-Meteo fills them; fallback providers leave
+// them nil. Unknown fields fail NewClient with ErrInvalidConfig wrapping
+// ErrUnknownWeatherField.
+func WithWeatherFields(fields ...WeatherField) Option {
+	return func(c *Client) {
+		c.weatherFields = slices.Clone(fields)
+	}
+}
+
+// weatherFieldsError reports configured fields that aren't known
+func (c *Client) weatherFieldsError() error {
+	var errs []error
+	for _, f := range c.weatherFields {
+		if !slices.Contains(AllWeatherFields, f) {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownWeatherField, f))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// weatherFieldsQuery returns the configured fields to append to the current
+// variables of a forecast request
+func (c *Client) weatherFieldsQuery() string {
+	var b strings.Builder
+	for _, f := range c.weatherFields {
+		b.WriteString("," + string(f))
+	}
+	return b.String()
+}
+
+// checkOptionalFields rejects optional field values no instrument reports
+func checkOptionalFields(resp *OpenMeteoResponse) error {
+	cur := resp.Current
+	outside := func(v *float64, lo, hi float64) bool { return v != nil && (*v < lo || *v > hi) }
+	switch {
+	case outside(cur.WindDirection, 0, 360):
+		return fmt.Errorf("%w: wind direction %g outside 0 to 360", ErrInvalidUpstreamData, *cur.WindDirection)
+	case cur.SurfacePressure != nil && *cur.SurfacePressure <= 0:
+		return fmt.Errorf("%w: surface pressure %g", ErrInvalidUpstreamData, *cur.SurfacePressure)
+	case outside(cur.CloudCover, 0, 100):
+		return fmt.Errorf("%w: cloud cover %g outside 0 to 100", ErrInvalidUpstreamData, *cur.CloudCover)
+	case cur.Precipitation != nil && *cur.Precipitation < 0:
+		return fmt.Errorf("%w: negative precipitation %g", ErrInvalidUpstreamData, *cur.Precipitation)
+	}
+	return nil
+}
+
+// setWeatherFields copies the requested optional fields from resp, leaving
+// the others nil even if the response carries them
+func (c *Client) setWeatherFields(data *WeatherData, resp *OpenMeteoResponse) {
+	for _, f := range c.weatherFields {
+		switch f {
+		case WindDirectionField:
+			data.WindDirection = resp.Current.WindDirection
+		case PressureField:
+			data.SurfacePressureHPa = resp.Current.SurfacePressure
+		case CloudCoverField:
+			data.CloudCover = resp.Current.CloudCover
+		case PrecipitationField:
+			data.PrecipitationMm = resp.Current.Precipitation
+		}
+	}
+}
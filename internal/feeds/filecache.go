@@ -0,0 +1,94 @@
+package feeds
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileCache is a Cache keeping one JSON file per entry in a directory, so
+// results survive restarts. Expired entries are removed when next read.
+type FileCache struct {
+	dir string
+}
+
+// fileCacheEntry is the on-disk form of a cached result
+type fileCacheEntry struct {
+	Key     string       `json:"key"`
+	Expires time.Time    `json:"expires"`
+	Data    *WeatherData `json:"data"`
+}
+
+// NewFileCache returns a FileCache in dir, creating it if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// path returns the file for key; keys are URLs, so they're hashed into names
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (f *FileCache) Get(key string) (*WeatherData, bool, error) {
+	path := f.path(key)
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(body, &entry); err != nil {
+		// A torn or foreign file is a miss, and is replaced by the next Set
+		return nil, false, nil
+	}
+	if entry.Key != key || entry.Data == nil {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.Expires) {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+	return entry.Data, true, nil
+}
+
+// Set writes the entry to a temporary file and renames it into place, so
+// readers never see a partial entry
+func (f *FileCache) Set(key string, data *WeatherData, ttl time.Duration) error {
+	body, err := json.Marshal(fileCacheEntry{Key: key, Expires: time.Now().Add(ttl), Data: data})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrOutsideForecastRange is returned when a requested time isn't covered by the forecast
+var ErrOutsideForecastRange = errors.New("requested time outside forecast range")
+
+// hourlyForecastResponse represents the hourly block of an Open-Meteo forecast.
+// Values are pointers so nulls for missing hours can be told apart from zero.
+type hourlyForecastResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Hourly               struct {
+		Time                []string   `json:"time"`
+		Temperature         []*float64 `json:"temperature_2m"`
+		ApparentTemperature []*float64 `json:"apparent_temperature"`
+		WeatherCode         []*int     `json:"weather_code"`
+	} `json:"hourly"`
+}
+
+// hourlyEntry is one complete hour of a parsed forecast
+type hourlyEntry struct {
+	time         time.Time
+	temperatureC float64
+	feelsLikeC   float64
+	weatherCode  int
+}
+
+// ForecastAt calls ForecastAt on the default Client
+func ForecastAt(ctx context.Context, country string, at time.Time) (*WeatherData, error) {
+	return defaultClient.ForecastAt(ctx, country, at)
+}
+
+// ForecastAt returns the hourly forecast entry nearest to the requested time
+// for a given country
+func (c *Client) ForecastAt(ctx context.Context, country string, at time.Time) (*WeatherData, error) {
+	apiResp, err := c.fetchHourly(ctx, country)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.forecastAt(ctx, apiResp, at)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, c.cityFor(country)
+	return data, nil
+}
+
+// fetchHourly fetches the hourly forecast for a given country
+func (c *Client) fetchHourly(ctx context.Context, country string) (*hourlyForecastResponse, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+
+	pastDays, err := c.pastDaysQuery()
+	if err != nil {
+		return nil, err
+	}
+	url, err := c.forecastURL(ctx, coords, "hourly=temperature_2m,apparent_temperature,weather_code&timezone=auto"+pastDays)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp hourlyForecastResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	return &apiResp, nil
+}
+
+// entries parses the hourly arrays in the response timezone. The parallel
+// arrays are read up to their shortest common length and hours with any
+// missing value are skipped, so mismatched arrays never misalign or panic.
+// Limits from WithForecastLimits are applied here, while parsing.
+func (r *hourlyForecastResponse) entries(limits forecastLimits) ([]hourlyEntry, error) {
+	loc := responseLocation(r.Timezone, r.TimezoneAbbreviation, r.UTCOffsetSeconds)
+
+	h := r.Hourly
+	n := min(len(h.Time), len(h.Temperature), len(h.ApparentTemperature), len(h.WeatherCode))
+	entries := make([]hourlyEntry, 0, limits.capacity(n))
+	for i := 0; i < n && !limits.full(len(entries)); i += limits.stride() {
+		if h.Temperature[i] == nil || h.ApparentTemperature[i] == nil || h.WeatherCode[i] == nil {
+			continue
+		}
+		t, err := time.ParseInLocation(openMeteoTimeLayout, h.Time[i], loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: hourly time: %w", ErrDecode, err)
+		}
+		entries = append(entries, hourlyEntry{
+			time:         t,
+			temperatureC: *h.Temperature[i],
+			feelsLikeC:   *h.ApparentTemperature[i],
+			weatherCode:  *h.WeatherCode[i],
+		})
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no hourly data", ErrDecode)
+	}
+	return entries, nil
+}
+
+// nearestHour returns the index of the hourly entry nearest to at. Each entry
+// covers span from its start (an hour, or more when downsampled), so times from
+// the first entry up to span after the last one are in range.
+func nearestHour(entries []hourlyEntry, at time.Time, span time.Duration) (int, error) {
+	first, end := entries[0].time, entries[len(entries)-1].time.Add(span)
+	if at.Before(first) || !at.Before(end) {
+		return 0, fmt.Errorf("%w: %s not within %s to %s", ErrOutsideForecastRange,
+			at.In(first.Location()).Format(time.RFC3339), first.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	best, bestDiff := 0, time.Duration(-1)
+	for i, e := range entries {
+		diff := at.Sub(e.time)
+		if diff < 0 {
+			diff = -diff
+		}
+		if bestDiff < 0 || diff < bestDiff {
+			best, bestDiff = i, diff
+		}
+	}
+	return best, nil
+}
+
+// forecastAt picks the hourly entry nearest to at. Hourly times are local to
+// the response timezone, so they're parsed there and compared as instants.
+func (c *Client) forecastAt(ctx context.Context, apiResp *hourlyForecastResponse, at time.Time) (*WeatherData, error) {
+	entries, err := apiResp.entries(c.forecastLimits)
+	if err != nil {
+		return nil, err
+	}
+	best, err := nearestHour(entries, at, c.forecastLimits.span())
+	if err != nil {
+		return nil, err
+	}
+
+	e := entries[best]
+	data := &WeatherData{
+		Summary:      c.describeWeatherCode(e.weatherCode),
+		WeatherCode:  e.weatherCode,
+		TemperatureC: e.temperatureC,
+		FeelsLikeC:   e.feelsLikeC,
+		Provenance:   c.provenance(c.openMeteoSource(ctx), time.Time{}),
+		palette:      c.colorPalette,
+	}
+	data.setCondition(true, true)
+	return data, nil
+}
+
+// TempChangeRate calls TempChangeRate on the default Client
+func TempChangeRate(ctx context.Context, country string) (float64, error) {
+	return defaultClient.TempChangeRate(ctx, country)
+}
+
+// TempChangeRate returns how fast the temperature is changing right now in
+// °C per hour, derived from the hourly forecast around the current time
+func (c *Client) TempChangeRate(ctx context.Context, country string) (float64, error) {
+	apiResp, err := c.fetchHourly(ctx, country)
+	if err != nil {
+		return 0, err
+	}
+	return tempChangeRate(apiResp, c.forecastLimits, time.Now())
+}
+
+// tempChangeRate uses a central difference around the hour nearest to at, or
+// a one-sided difference at the start and end of the forecast window
+func tempChangeRate(apiResp *hourlyForecastResponse, limits forecastLimits, at time.Time) (float64, error) {
+	entries, err := apiResp.entries(limits)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) < 2 {
+		return 0, fmt.Errorf("%w: need at least two hourly entries", ErrDecode)
+	}
+	i, err := nearestHour(entries, at, limits.span())
+	if err != nil {
+		return 0, err
+	}
+
+	lo, hi := entries[max(i-1, 0)], entries[min(i+1, len(entries)-1)]
+	hours := hi.time.Sub(lo.time).Hours()
+	if hours <= 0 {
+		return 0, fmt.Errorf("%w: hourly times not increasing", ErrDecode)
+	}
+	return (hi.temperatureC - lo.temperatureC) / hours, nil
+}
@@ -0,0 +1,169 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+// bangkokEvening is an hourly forecast from 17:00 to 21:00 Bangkok time
+const bangkokEvening = `{"timezone":"Asia/Bangkok","timezone_abbreviation":"+07","utc_offset_seconds":25200,
+"hourly":{"time":["2025-01-15T17:00","2025-01-15T18:00","2025-01-15T19:00","2025-01-15T20:00","2025-01-15T21:00"],
+"temperature_2m":[31,30,28.5,27,26],"apparent_temperature":[35,33,31,29.5,28],"weather_code":[1,2,80,61,3]}}`
+
+// forecastServer answers forecast requests with body
+func forecastServer(t *testing.T, body string) *feedstest.Server {
+	t.Helper()
+	srv := feedstest.NewServer(t)
+	srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, []byte(body))
+	return srv
+}
+
+func TestForecastAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       time.Time
+		wantTemp float64
+		wantCode int
+		wantErr  error
+	}{
+		// 12:10 UTC is 19:10 in Bangkok
+		{name: "nearest hour in UTC", at: time.Date(2025, 1, 15, 12, 10, 0, 0, time.UTC), wantTemp: 28.5, wantCode: 80},
+		{name: "nearest hour in Tokyo time", at: time.Date(2025, 1, 15, 22, 20, 0, 0, time.FixedZone("JST", 9*60*60)), wantTemp: 27, wantCode: 61},
+		{name: "first hour", at: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), wantTemp: 31, wantCode: 1},
+		{name: "before the forecast", at: time.Date(2025, 1, 15, 9, 59, 0, 0, time.UTC), wantErr: feeds.ErrOutsideForecastRange},
+		{name: "after the forecast", at: time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC), wantErr: feeds.ErrOutsideForecastRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := forecastServer(t, bangkokEvening).Client(t)
+			data, err := c.ForecastAt(context.Background(), "TH", tt.at)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.TemperatureC != tt.wantTemp || data.WeatherCode != tt.wantCode {
+				t.Errorf("got %v°C, code %d, want %v°C, code %d", data.TemperatureC, data.WeatherCode, tt.wantTemp, tt.wantCode)
+			}
+			if !data.Approximate || data.City != "Bangkok" {
+				t.Errorf("Approximate = %v, City = %q, want true, Bangkok", data.Approximate, data.City)
+			}
+		})
+	}
+}
+
+func TestForecastAtMismatchedArrays(t *testing.T) {
+	// Five times but only three weather codes, and no temperature at 18:00
+	ragged := `{"timezone":"Asia/Bangkok","timezone_abbreviation":"+07","utc_offset_seconds":25200,
+"hourly":{"time":["2025-01-15T17:00","2025-01-15T18:00","2025-01-15T19:00","2025-01-15T20:00","2025-01-15T21:00"],
+"temperature_2m":[31,null,28.5,27],"apparent_temperature":[35,33,31,29.5,28],"weather_code":[1,2,80]}}`
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		wantTemp float64
+		wantCode int
+		wantErr  error
+	}{
+		// 11:40 UTC is 18:40 in Bangkok, nearest the skipped 18:00 hour
+		{name: "hour with a missing value skipped", at: time.Date(2025, 1, 15, 11, 40, 0, 0, time.UTC), wantTemp: 28.5, wantCode: 80},
+		{name: "first hour", at: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), wantTemp: 31, wantCode: 1},
+		{name: "past the shortest array", at: time.Date(2025, 1, 15, 13, 30, 0, 0, time.UTC), wantErr: feeds.ErrOutsideForecastRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := forecastServer(t, ragged).Client(t)
+			data, err := c.ForecastAt(context.Background(), "TH", tt.at)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.TemperatureC != tt.wantTemp || data.WeatherCode != tt.wantCode {
+				t.Errorf("got %v°C, code %d, want %v°C, code %d", data.TemperatureC, data.WeatherCode, tt.wantTemp, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWithPastDays(t *testing.T) {
+	// Two days of history before the Bangkok evening
+	withHistory := `{"timezone":"Asia/Bangkok","timezone_abbreviation":"+07","utc_offset_seconds":25200,
+"hourly":{"time":["2025-01-13T19:00","2025-01-14T19:00","2025-01-15T19:00"],
+"temperature_2m":[24,26,28.5],"apparent_temperature":[26,28,31],"weather_code":[3,61,80]}}`
+
+	srv := forecastServer(t, withHistory)
+	c := srv.Client(t, feeds.WithPastDays(2))
+	data, err := c.ForecastAt(context.Background(), "TH", time.Date(2025, 1, 13, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.TemperatureC != 24 || data.WeatherCode != 3 {
+		t.Errorf("two days ago: %v°C, code %d, want 24°C, code 3", data.TemperatureC, data.WeatherCode)
+	}
+	if got := srv.Requests()[0].Query().Get("past_days"); got != "2" {
+		t.Errorf("past_days = %q, want 2", got)
+	}
+
+	for _, n := range []int{-1, 93} {
+		if _, err := feeds.NewClient(feeds.WithPastDays(n)); !errors.Is(err, feeds.ErrInvalidPastDays) {
+			t.Errorf("WithPastDays(%d): err = %v, want ErrInvalidPastDays", n, err)
+		}
+	}
+}
+
+func TestTempChangeRate(t *testing.T) {
+	// hours returns a UTC hourly series of the given temperatures starting
+	// offset hours from the current hour
+	hours := func(offset int, temps ...float64) string {
+		start := time.Now().UTC().Truncate(time.Hour).Add(time.Duration(offset) * time.Hour)
+		var times, values, codes []string
+		for i, temp := range temps {
+			times = append(times, `"`+start.Add(time.Duration(i)*time.Hour).Format("2006-01-02T15:04")+`"`)
+			values = append(values, strconv.FormatFloat(temp, 'f', -1, 64))
+			codes = append(codes, "1")
+		}
+		return `{"timezone":"GMT","utc_offset_seconds":0,"hourly":{"time":[` + strings.Join(times, ",") +
+			`],"temperature_2m":[` + strings.Join(values, ",") + `],"apparent_temperature":[` + strings.Join(values, ",") +
+			`],"weather_code":[` + strings.Join(codes, ",") + `]}}`
+	}
+	tests := []struct {
+		name string
+		body string
+		want float64
+	}{
+		// The nearest hour is the current one, or the next after half past;
+		// either way the neighbours differ by 6°C over two hours, while a
+		// one-sided difference would see 0 or 6
+		{name: "central difference", body: hours(-2, 20, 20, 20, 26, 26, 26), want: 3},
+		{name: "start of the window", body: hours(0, 20, 22), want: 2},
+		{name: "end of the window", body: hours(-1, 25, 24), want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := forecastServer(t, tt.body).Client(t).TempChangeRate(context.Background(), "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rate != tt.want {
+				t.Errorf("rate = %v°C/h, want %v", rate, tt.want)
+			}
+		})
+	}
+}
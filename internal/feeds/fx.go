@@ -0,0 +1,167 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fxEndpoint serves daily exchange rates for a base currency by date or
+// "latest", covering every Asian currency including VND and TWD
+const fxEndpoint = "https://cdn.jsdelivr.net/npm/@fawazahmed0/currency-api@%s/v1/currencies/%s.json"
+
+// ErrUnknownCurrency is returned for currency codes the rates source doesn't list
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// AsiaCurrencies are the currencies of the supported countries
+var AsiaCurrencies = []string{
+	"JPY", "CNY", "INR", "SGD", "HKD", "KRW", "THB", "IDR", "MYR", "PHP", "VND", "TWD",
+	"BDT", "PKR", "LKR", "NPR", "BTN", "MVR", "MMK", "KHR", "LAK", "BND", "MNT", "KZT",
+	"UZS", "KGS", "TJS", "TMT", "AFN", "IRR", "IQD", "AED", "SAR", "QAR", "KWD", "BHD",
+	"OMR", "JOD", "LBP", "MOP",
+}
+
+// countryCurrencies are the currencies of the supported countries; Timor-Leste uses USD
+var countryCurrencies = map[string]string{
+	"JP": "JPY", "CN": "CNY", "IN": "INR", "SG": "SGD", "HK": "HKD", "KR": "KRW",
+	"TH": "THB", "ID": "IDR", "MY": "MYR", "PH": "PHP", "VN": "VND", "TW": "TWD",
+	"BD": "BDT", "PK": "PKR", "LK": "LKR", "NP": "NPR", "BT": "BTN", "MV": "MVR",
+	"MM": "MMK", "KH": "KHR", "LA": "LAK", "BN": "BND", "TL": "USD", "MN": "MNT",
+	"KZ": "KZT", "UZ": "UZS", "KG": "KGS", "TJ": "TJS", "TM": "TMT", "AF": "AFN",
+	"IR": "IRR", "IQ": "IQD", "AE": "AED", "SA": "SAR", "QA": "QAR", "KW": "KWD",
+	"BH": "BHD", "OM": "OMR", "JO": "JOD", "LB": "LBP", "MO": "MOP",
+}
+
+// ExchangeRates are units of each currency per one unit of Base
+type ExchangeRates struct {
+	Base  string             `json:"base"`
+	Date  time.Time          `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+
+	// Provenance.ObservedAt is Date
+	Provenance
+}
+
+// FetchExchangeRates calls FetchExchangeRates on the default Client
+func FetchExchangeRates(ctx context.Context, base string) (*ExchangeRates, error) {
+	return defaultClient.FetchExchangeRates(ctx, base)
+}
+
+// FetchExchangeRates returns the latest rates of AsiaCurrencies and USD
+// against base, e.g. "USD" or "SGD". Rates are cached for the Client's cache TTL.
+func (c *Client) FetchExchangeRates(ctx context.Context, base string) (*ExchangeRates, error) {
+	return c.fetchExchangeRates(ctx, base, "latest")
+}
+
+// FetchExchangeRatesOn calls FetchExchangeRatesOn on the default Client
+func FetchExchangeRatesOn(ctx context.Context, base string, date time.Time) (*ExchangeRates, error) {
+	return defaultClient.FetchExchangeRatesOn(ctx, base, date)
+}
+
+// FetchExchangeRatesOn returns the rates published for a past date, as
+// FetchExchangeRates does for the latest ones
+func (c *Client) FetchExchangeRatesOn(ctx context.Context, base string, date time.Time) (*ExchangeRates, error) {
+	return c.fetchExchangeRates(ctx, base, date.Format(openMeteoDateLayout))
+}
+
+func (c *Client) fetchExchangeRates(ctx context.Context, base, version string) (*ExchangeRates, error) {
+	code := strings.ToLower(strings.TrimSpace(base))
+	if len(code) != 3 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCurrency, base)
+	}
+	url := fmt.Sprintf(fxEndpoint, version, code)
+	if rates, ok := c.fxCache.get(url); ok {
+		return rates, nil
+	}
+
+	// The body is {"date": "...", "<base>": {"<code>": rate, ...}}
+	var apiResp map[string]any
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	rates, err := c.decodeExchangeRates(apiResp, code)
+	if err != nil {
+		return nil, err
+	}
+	if c.cacheTTL > 0 {
+		c.fxCache.set(url, rates, c.cacheTTL)
+	}
+	return rates, nil
+}
+
+// decodeExchangeRates keeps the Asian currencies and USD from a full rate table
+func (c *Client) decodeExchangeRates(apiResp map[string]any, code string) (*ExchangeRates, error) {
+	dateStr, _ := apiResp["date"].(string)
+	date, err := time.Parse(openMeteoDateLayout, dateStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rates date: %w", ErrDecode, err)
+	}
+	table, ok := apiResp[code].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCurrency, strings.ToUpper(code))
+	}
+
+	rates := &ExchangeRates{
+		Base:       strings.ToUpper(code),
+		Date:       date,
+		Rates:      make(map[string]float64),
+		Provenance: c.provenance(sourceCurrencyAPI, date),
+	}
+	for _, cur := range append([]string{"USD"}, AsiaCurrencies...) {
+		if rate, ok := table[strings.ToLower(cur)].(float64); ok && cur != rates.Base {
+			rates.Rates[cur] = rate
+		}
+	}
+	return rates, nil
+}
+
+// fxEntry is cached rates with their expiry
+type fxEntry struct {
+	rates   *ExchangeRates
+	expires time.Time
+}
+
+// fxCache keeps exchange rates in memory; there are only a few bases and dates
+// in use, so expired entries are simply replaced
+type fxCache struct {
+	mu      sync.Mutex
+	entries map[string]fxEntry
+}
+
+func newFXCache() *fxCache {
+	return &fxCache{entries: make(map[string]fxEntry)}
+}
+
+// get returns a copy of unexpired rates
+func (f *fxCache) get(key string) (*ExchangeRates, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e, ok := f.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	rates := *e.rates
+	rates.Rates = maps.Clone(e.rates.Rates)
+	return &rates, true
+}
+
+func (f *fxCache) set(key string, rates *ExchangeRates, ttl time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *rates
+	stored.Rates = maps.Clone(rates.Rates)
+	f.entries[key] = fxEntry{rates: &stored, expires: time.Now().Add(ttl)}
+}
+
+// len counts the cached rates, expired ones included until replaced
+func (f *fxCache) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.entries)
+}
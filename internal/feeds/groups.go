@@ -0,0 +1,76 @@
+package feeds
+
+import "maps"
+
+// WeatherGroup is a coarse classification of WMO weather codes
+type WeatherGroup string
+
+const (
+	GroupClear        WeatherGroup = "clear"
+	GroupCloudy       WeatherGroup = "cloudy"
+	GroupFog          WeatherGroup = "fog"
+	GroupRain         WeatherGroup = "rain"
+	GroupSnow         WeatherGroup = "snow"
+	GroupThunderstorm WeatherGroup = "thunderstorm"
+	GroupUnknown      WeatherGroup = "unknown"
+)
+
+// WeatherGroupFor classifies a WMO weather code; drizzle and showers count as rain
+func WeatherGroupFor(code int) WeatherGroup {
+	switch {
+	case code == 0 || code == 1:
+		return GroupClear
+	case code == 2 || code == 3:
+		return GroupCloudy
+	case code == 45 || code == 48:
+		return GroupFog
+	case code >= 51 && code <= 67, code >= 80 && code <= 82:
+		return GroupRain
+	case code >= 71 && code <= 77, code == 85 || code == 86:
+		return GroupSnow
+	case code >= 95 && code <= 99:
+		return GroupThunderstorm
+	default:
+		return GroupUnknown
+	}
+}
+
+// Group returns the weather group for the result's WeatherCode
+func (w *WeatherData) Group() WeatherGroup {
+	return WeatherGroupFor(w.WeatherCode)
+}
+
+// DefaultColorPalette maps weather groups to suggested background colors:
+// warm yellow for clear, grays for cloudy and fog, blue for rain, white for
+// snow and dark purple for thunderstorms
+var DefaultColorPalette = map[WeatherGroup]string{
+	GroupClear:        "#FFD966",
+	GroupCloudy:       "#A9AFB7",
+	GroupFog:          "#D3D6DA",
+	GroupRain:         "#4A90D9",
+	GroupSnow:         "#FFFFFF",
+	GroupThunderstorm: "#3B1F4F",
+	GroupUnknown:      "#C0C0C0",
+}
+
+// WithColorPalette overrides suggested colors for some or all weather groups;
+// groups not in the map keep their DefaultColorPalette color
+func WithColorPalette(palette map[WeatherGroup]string) Option {
+	return func(c *Client) {
+		merged := maps.Clone(DefaultColorPalette)
+		maps.Copy(merged, palette)
+		c.colorPalette = merged
+	}
+}
+
+// SuggestedColor returns a hex background color for the result's weather group
+func (w *WeatherData) SuggestedColor() string {
+	palette := w.palette
+	if palette == nil {
+		palette = DefaultColorPalette
+	}
+	if color, ok := palette[w.Group()]; ok {
+		return color
+	}
+	return DefaultColorPalette[GroupUnknown]
+}
@@ -0,0 +1,44 @@
+package feeds_test
+
+import (
+	"context"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestSuggestedColor(t *testing.T) {
+	tests := []struct {
+		code int
+		want string
+	}{
+		{code: 0, want: "#FFD966"},
+		{code: 3, want: "#A9AFB7"},
+		{code: 45, want: "#D3D6DA"},
+		{code: 53, want: "#4A90D9"},
+		{code: 81, want: "#4A90D9"},
+		{code: 75, want: "#FFFFFF"},
+		{code: 99, want: "#3B1F4F"},
+		{code: 42, want: "#C0C0C0"},
+	}
+	for _, tt := range tests {
+		w := &feeds.WeatherData{WeatherCode: tt.code}
+		if got := w.SuggestedColor(); got != tt.want {
+			t.Errorf("code %d: SuggestedColor = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestColorPaletteOverride(t *testing.T) {
+	weather := map[string]countryWeather{"JP": {Code: 61}, "TH": {Code: 0}}
+	c := stubClient(t, countryTransport(t, weather), feeds.WithColorPalette(map[feeds.WeatherGroup]string{feeds.GroupRain: "#000080"}))
+	for country, want := range map[string]string{"JP": "#000080", "TH": "#FFD966"} {
+		data, err := c.FetchWeather(context.Background(), country)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := data.SuggestedColor(); got != want {
+			t.Errorf("%s: SuggestedColor = %s, want %s", country, got, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Handler serves GET /weather?country=XX as WeatherData JSON using client, or
+// the default Client when nil. It responds 400 when country is missing, and
+// otherwise with handlerStatus of the fetch's error, or 200. A country the
+// client resolves, such as through WithUnknownCountryPolicy, is served.
+func Handler(client *Client) http.Handler {
+	if client == nil {
+		client = defaultClient
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /weather", func(w http.ResponseWriter, r *http.Request) {
+		country := r.URL.Query().Get("country")
+		if country == "" {
+			http.Error(w, "country is required", http.StatusBadRequest)
+			return
+		}
+
+		data, err := client.FetchWeather(r.Context(), country)
+		if errors.Is(err, context.Canceled) && r.Context().Err() != nil {
+			// The caller hung up, so there's no one to answer
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), handlerStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(data)
+	})
+	return mux
+}
+
+// handlerStatus maps a fetch error to an HTTP status: 4xx for bad input, 503
+// while the client holds requests back, 504 for timeouts, 502 for failed
+// upstream requests and 500 for anything else
+func handlerStatus(err error) int {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrUnsupportedCountry),
+		errors.Is(err, ErrUnknownCity):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidParams),
+		errors.Is(err, ErrUnknownModel),
+		errors.As(err, new(*ErrInvalidCoordinates)):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrCircuitOpen),
+		errors.Is(err, ErrRateLimited):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return http.StatusGatewayTimeout
+	case errors.As(err, new(*ProviderError)):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
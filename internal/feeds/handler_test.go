@@ -0,0 +1,73 @@
+package feeds_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		options  []feeds.Option
+		upstream int
+		want     int
+	}{
+		{name: "success", query: "?country=JP", upstream: http.StatusOK, want: http.StatusOK},
+		{name: "missing country", query: "", upstream: http.StatusOK, want: http.StatusBadRequest},
+		{name: "unknown country", query: "?country=XX", upstream: http.StatusOK, want: http.StatusNotFound},
+		{
+			name:     "unknown country with Tokyo fallback",
+			query:    "?country=XX",
+			options:  []feeds.Option{feeds.WithUnknownCountryPolicy(feeds.FallbackToTokyo)},
+			upstream: http.StatusOK,
+			want:     http.StatusOK,
+		},
+		{name: "upstream error", query: "?country=JP", upstream: http.StatusInternalServerError, want: http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			if tt.upstream != http.StatusOK {
+				srv.Handle("https://api.open-meteo.com/v1/forecast", tt.upstream, []byte(`{"error":true}`))
+			}
+			h := feeds.Handler(srv.Client(t, tt.options...))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather"+tt.query, nil))
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			var data feeds.WeatherData
+			if err := json.Unmarshal(rec.Body.Bytes(), &data); err != nil {
+				t.Fatal(err)
+			}
+			if data.TemperatureC != 9.4 || data.WeatherCode != 1 {
+				t.Errorf("got %.1f°C code %d, want the fixture's 9.4°C code 1", data.TemperatureC, data.WeatherCode)
+			}
+		})
+	}
+}
+
+func TestHandlerCanceled(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	h := feeds.Handler(srv.Client(t))
+
+	req := httptest.NewRequest(http.MethodGet, "/weather?country=JP", nil)
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req.WithContext(ctx))
+	if rec.Code == http.StatusBadGateway || rec.Body.Len() != 0 {
+		t.Errorf("got %d %q for a caller that hung up, want no response", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,162 @@
+package feeds
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// HealthStatus summarizes a HealthReport
+type HealthStatus string
+
+const (
+	// HealthOK means every provider answered its last request and every
+	// stored feed refreshed successfully
+	HealthOK HealthStatus = "ok"
+	// HealthDegraded means some provider or feed is failing; the others
+	// still serve
+	HealthDegraded HealthStatus = "degraded"
+)
+
+// HealthReport is an operator's view of a Client and the Store it refreshes into,
+// for telling at a glance which upstream is broken
+type HealthReport struct {
+	Status    HealthStatus     `json:"status"`
+	CheckedAt time.Time        `json:"checkedAt"`
+	Providers []ProviderHealth `json:"providers"`
+	Feeds     []FeedHealth     `json:"feeds,omitempty"`
+	// Caches maps cache names to their entry counts. A Cache given to
+	// WithCache is counted as "weather" only if it has a Len() int method.
+	Caches  map[string]int `json:"caches"`
+	Latency LatencySummary `json:"latency"`
+}
+
+// ProviderHealth is the state of one upstream host
+type ProviderHealth struct {
+	Host string `json:"host"`
+	// Healthy is false while the breaker isn't closed or the last request
+	// failed
+	Healthy bool `json:"healthy"`
+	// Breaker is "closed", "open" or "half-open"
+	Breaker             string `json:"breaker"`
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+	// LastStatus is the HTTP status of the last request, or "error" when it
+	// got no response
+	LastStatus    string    `json:"lastStatus,omitempty"`
+	LastRequestAt time.Time `json:"lastRequestAt,omitzero"`
+	LastSuccessAt time.Time `json:"lastSuccessAt,omitzero"`
+	Requests      uint64    `json:"requests"`
+	Failures      uint64    `json:"failures"`
+}
+
+// FeedHealth is the refresh state of one stored feed
+type FeedHealth struct {
+	Feed string `json:"feed"`
+	// Country is empty for feeds that aren't per country
+	Country       string    `json:"country,omitempty"`
+	Healthy       bool      `json:"healthy"`
+	LastSuccessAt time.Time `json:"lastSuccessAt,omitzero"`
+	// Error is the error of the last refresh, when it failed
+	Error string `json:"error,omitempty"`
+}
+
+// Health calls Health on the default Client
+func Health(store *Store) HealthReport {
+	return defaultClient.Health(store)
+}
+
+// Health reports the state of every provider host the Client has contacted
+// and, with a non-nil store, the last refresh of every feed in it
+func (c *Client) Health(store *Store) HealthReport {
+	h := HealthReport{
+		Status:    HealthOK,
+		CheckedAt: time.Now(),
+		Providers: c.providerHealth(),
+		Caches:    c.cacheSizes(),
+		Latency:   c.LatencyStats(),
+	}
+	if store != nil {
+		store.each(func(key snapshotKey, snap Snapshot) {
+			f := FeedHealth{Feed: key.feed, Country: key.country, Healthy: snap.Err == nil, LastSuccessAt: snap.FetchedAt}
+			if snap.Err != nil {
+				f.Error = snap.Err.Error()
+			}
+			h.Feeds = append(h.Feeds, f)
+		})
+		slices.SortFunc(h.Feeds, func(a, b FeedHealth) int {
+			return cmp.Or(cmp.Compare(a.Feed, b.Feed), cmp.Compare(a.Country, b.Country))
+		})
+	}
+
+	for _, p := range h.Providers {
+		if !p.Healthy {
+			h.Status = HealthDegraded
+		}
+	}
+	for _, f := range h.Feeds {
+		if !f.Healthy {
+			h.Status = HealthDegraded
+		}
+	}
+	return h
+}
+
+// providerHealth joins request metrics and breaker states by host
+func (c *Client) providerHealth() []ProviderHealth {
+	activity, counts := c.metrics.hostActivity()
+	breakers := c.breakers.states()
+
+	hosts := make(map[string]struct{})
+	for host := range activity {
+		hosts[host] = struct{}{}
+	}
+	for host := range breakers {
+		hosts[host] = struct{}{}
+	}
+
+	providers := make([]ProviderHealth, 0, len(hosts))
+	for _, host := range slices.Sorted(maps.Keys(hosts)) {
+		a, b := activity[host], breakers[host]
+		p := ProviderHealth{
+			Host:                host,
+			Breaker:             b.state.String(),
+			ConsecutiveFailures: b.failures,
+			LastStatus:          a.lastStatus,
+			LastRequestAt:       a.lastAt,
+			LastSuccessAt:       a.lastSuccessAt,
+		}
+		for status, n := range counts[host] {
+			p.Requests += n
+			if requestFailed(status) {
+				p.Failures += n
+			}
+		}
+		p.Healthy = b.state == breakerClosed && (a.lastStatus == "" || !requestFailed(a.lastStatus))
+		providers = append(providers, p)
+	}
+	return providers
+}
+
+// requestFailed reports whether a request status of metrics means the
+// provider is unwell: no response, too many requests or a server error
+func requestFailed(status string) bool {
+	code, err := strconv.Atoi(status)
+	return err != nil || code == 429 || code >= 500
+}
+
+// cacheSizes counts the entries of the Client's caches
+func (c *Client) cacheSizes() map[string]int {
+	sizes := map[string]int{
+		"validators": c.validators.len(),
+		"fx":         c.fxCache.len(),
+	}
+	if l, ok := c.cache.(interface{ Len() int }); ok {
+		sizes["weather"] = l.Len()
+	}
+	if c.lastGood != nil {
+		sizes["lastGood"] = c.lastGood.Len()
+	}
+	return sizes
+}
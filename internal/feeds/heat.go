@@ -0,0 +1,61 @@
+package feeds
+
+import "math"
+
+// HeatAdvisory is the NWS heat index risk category
+type HeatAdvisory string
+
+const (
+	HeatCaution        HeatAdvisory = "Caution"
+	HeatExtremeCaution HeatAdvisory = "Extreme caution"
+	HeatDanger         HeatAdvisory = "Danger"
+	HeatExtremeDanger  HeatAdvisory = "Extreme danger"
+)
+
+// heatAdvisoryFor returns the NWS category for a heat index in °C, or "" below
+// 80°F where no advisory applies
+func heatAdvisoryFor(heatIndexC float64) HeatAdvisory {
+	switch f := heatIndexC*9/5 + 32; {
+	case f >= 125:
+		return HeatExtremeDanger
+	case f >= 103:
+		return HeatDanger
+	case f >= 90:
+		return HeatExtremeCaution
+	case f >= 80:
+		return HeatCaution
+	default:
+		return ""
+	}
+}
+
+// setHeat fills RelativeHumidity, HeatIndexC and HeatAdvisory from the
+// temperature and humidity (%); a NaN humidity means it's unknown, in which
+// case the heat index is the air temperature
+func (w *WeatherData) setHeat(humidity float64) {
+	w.HeatIndexC = w.TemperatureC
+	if !math.IsNaN(humidity) {
+		w.RelativeHumidity = humidity
+		if w.TemperatureC >= heatIndexMinC {
+			w.HeatIndexC = heatIndexC(w.TemperatureC, humidity)
+		}
+	}
+	w.HeatAdvisory = heatAdvisoryFor(w.HeatIndexC)
+}
+
+// UVRisk returns the WHO exposure category of UVIndex: "Low", "Moderate",
+// "High", "Very high" or "Extreme"
+func (w *WeatherData) UVRisk() string {
+	switch uv := math.Round(w.UVIndex); {
+	case uv >= 11:
+		return "Extreme"
+	case uv >= 8:
+		return "Very high"
+	case uv >= 6:
+		return "High"
+	case uv >= 3:
+		return "Moderate"
+	default:
+		return "Low"
+	}
+}
@@ -0,0 +1,68 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WithHedging sends a duplicate request when the first hasn't responded
+// within delay, using whichever succeeds first and cancelling the other.
+// A delay of zero disables hedging (default).
+func WithHedging(delay time.Duration) Option {
+	return func(c *Client) {
+		c.hedgeDelay = delay
+	}
+}
+
+// hedgeResult is the outcome of one hedged attempt
+type hedgeResult struct {
+	raw json.RawMessage
+	err error
+}
+
+// getJSONHedged races up to two requests for url. Each attempt decodes into
+// its own buffer and only the winner is decoded into out.
+func (c *Client) getJSONHedged(ctx context.Context, url string, maxBytes int64, out any) error {
+	ctx, cancel := context.WithCancel(ctx)
+	// Cancelling on return stops the losing request
+	defer cancel()
+
+	// Buffered for both attempts so neither goroutine blocks after we return
+	results := make(chan hedgeResult, 2)
+	attempt := func() {
+		var raw json.RawMessage
+		err := c.getJSONOnce(ctx, url, maxBytes, &raw)
+		results <- hedgeResult{raw: raw, err: err}
+	}
+
+	go attempt()
+	inFlight := 1
+
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			go attempt()
+			inFlight++
+		case res := <-results:
+			inFlight--
+			if res.err == nil {
+				if err := json.Unmarshal(res.raw, out); err != nil {
+					return fmt.Errorf("%w: %w", ErrDecode, err)
+				}
+				return nil
+			}
+			// A failure before the hedge fires isn't retried; hedging
+			// is for latency, not errors
+			if inFlight == 0 {
+				return res.err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
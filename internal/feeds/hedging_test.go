@@ -0,0 +1,71 @@
+package feeds_test
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestHedgingFastResponseWins(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	upstream := srv.Transport()
+
+	// The first attempt stalls until it is canceled; the hedge is answered
+	// at once
+	var attempts atomic.Int32
+	loserDone := make(chan error, 1)
+	stall := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if attempts.Add(1) == 1 {
+			<-req.Context().Done()
+			loserDone <- req.Context().Err()
+			return nil, req.Context().Err()
+		}
+		return upstream.RoundTrip(req)
+	})
+	cache := &fakeCache{}
+	c := srv.Client(t, feeds.WithTransport(stall), feeds.WithHedging(20*time.Millisecond), feeds.WithCache(cache))
+
+	before := runtime.NumGoroutine()
+	start := time.Now()
+	data, err := c.FetchWeather(context.Background(), "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("hedged fetch took %v, want about the 20ms hedge delay", elapsed)
+	}
+	if data.TemperatureC != 9.4 {
+		t.Errorf("TemperatureC = %v, want the fixture's 9.4", data.TemperatureC)
+	}
+	if n := attempts.Load(); n != 2 {
+		t.Errorf("%d attempts, want 2", n)
+	}
+
+	select {
+	case err := <-loserDone:
+		if err != context.Canceled {
+			t.Errorf("losing attempt ended with %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("losing attempt was not canceled")
+	}
+	if _, _, sets := cache.counts(); sets != 1 {
+		t.Errorf("%d cache writes, want 1", sets)
+	}
+
+	// Idle keep-alive connections have goroutines of their own
+	http.DefaultTransport.(*http.Transport).CloseIdleConnections()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("%d goroutines after the fetch, %d before", after, before)
+	}
+}
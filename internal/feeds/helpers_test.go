@@ -0,0 +1,138 @@
+package feeds_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper for stub transports
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// errBackend is the failure of a fakeCache with failing set
+var errBackend = errors.New("cache backend down")
+
+// fakeCache is an in-memory feeds.Cache counting its calls. With failing
+// set, every call fails with errBackend, as an unreachable Redis would.
+type fakeCache struct {
+	failing bool
+
+	mu      sync.Mutex
+	entries map[string]feeds.WeatherData
+	gets    int
+	hits    int
+	sets    int
+}
+
+func (f *fakeCache) Get(key string) (*feeds.WeatherData, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	if f.failing {
+		return nil, false, errBackend
+	}
+	data, ok := f.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	f.hits++
+	return &data, true, nil
+}
+
+func (f *fakeCache) Set(key string, data *feeds.WeatherData, _ time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sets++
+	if f.failing {
+		return errBackend
+	}
+	if f.entries == nil {
+		f.entries = make(map[string]feeds.WeatherData)
+	}
+	f.entries[key] = *data
+	return nil
+}
+
+// counts returns the Get calls, the Gets that hit and the Set calls so far
+func (f *fakeCache) counts() (gets, hits, sets int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.gets, f.hits, f.sets
+}
+
+// countryWeather is a stubbed current-conditions response for one country:
+// Status 200 with TempC and Code, or a failure with any other Status
+type countryWeather struct {
+	TempC  float64
+	Code   int
+	Status int
+}
+
+// countryTransport answers forecast requests for the built-in coordinates of
+// each country in weather; requests for any other coordinates fail with 404
+func countryTransport(t testing.TB, weather map[string]countryWeather) http.RoundTripper {
+	t.Helper()
+	byCoords := make(map[feeds.Coordinates]countryWeather, len(weather))
+	for country, w := range weather {
+		coords, ok := feeds.CountryCoordinates[country]
+		if !ok {
+			t.Fatalf("countryTransport: unsupported country %q", country)
+		}
+		byCoords[coords] = w
+	}
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		lat, _ := strconv.ParseFloat(q.Get("latitude"), 64)
+		lon, _ := strconv.ParseFloat(q.Get("longitude"), 64)
+		w, ok := byCoords[feeds.Coordinates{Lat: lat, Lon: lon}]
+		switch {
+		case !ok:
+			return stubResponse(req, http.StatusNotFound, nil), nil
+		case w.Status != 0 && w.Status != http.StatusOK:
+			return stubResponse(req, w.Status, nil), nil
+		}
+		body := fmt.Sprintf(`{"utc_offset_seconds":32400,"timezone":"Asia/Tokyo","timezone_abbreviation":"JST",`+
+			`"current":{"time":"2025-01-15T12:00","temperature_2m":%g,"apparent_temperature":%g,"weather_code":%d,`+
+			`"relative_humidity_2m":50,"wind_speed_10m":5,"uv_index":3,"is_day":1}}`, w.TempC, w.TempC, w.Code)
+		return stubResponse(req, http.StatusOK, []byte(body)), nil
+	})
+}
+
+// stubResponse is an upstream response to req without a network round trip
+func stubResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode:    status,
+		Status:        http.StatusText(status),
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// stubClient returns a Client sending every request through rt, without
+// retries, with options applied after
+func stubClient(t testing.TB, rt http.RoundTripper, options ...feeds.Option) *feeds.Client {
+	t.Helper()
+	options = append([]feeds.Option{
+		feeds.WithTransport(rt),
+		feeds.WithRetryPolicy(feeds.RetryPolicy{MaxAttempts: 1}),
+	}, options...)
+	c, err := feeds.NewClient(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
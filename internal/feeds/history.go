@@ -0,0 +1,119 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// archiveEndpoint is the Open-Meteo historical weather API
+const archiveEndpoint = "https://archive-api.open-meteo.com/v1/archive"
+
+// archiveStart is the first date the archive covers
+var archiveStart = time.Date(1940, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrInvalidHistoricalDate is returned for dates before 1940 or not yet in the past
+var ErrInvalidHistoricalDate = errors.New("historical date must be between 1940-01-01 and yesterday")
+
+// HistoricalWeather is the observed weather of one past day
+type HistoricalWeather struct {
+	// Date is local midnight of the day in the country's timezone
+	Date             time.Time `json:"date"`
+	Summary          string    `json:"summary"`
+	WeatherCode      int       `json:"weatherCode"`
+	MinTemperatureC  float64   `json:"minTemperatureC"`
+	MaxTemperatureC  float64   `json:"maxTemperatureC"`
+	MeanTemperatureC float64   `json:"meanTemperatureC"`
+	PrecipitationMm  float64   `json:"precipitationMm"`
+
+	// Approximate and City mean the same as on WeatherData
+	Approximate bool   `json:"approximate,omitempty"`
+	City        string `json:"city,omitempty"`
+
+	// Provenance.ObservedAt is Date
+	Provenance
+}
+
+// archiveResponse represents the daily block of an Open-Meteo archive response
+type archiveResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Daily                struct {
+		Time            []string   `json:"time"`
+		WeatherCode     []*int     `json:"weather_code"`
+		MaxTemperature  []*float64 `json:"temperature_2m_max"`
+		MinTemperature  []*float64 `json:"temperature_2m_min"`
+		MeanTemperature []*float64 `json:"temperature_2m_mean"`
+		Precipitation   []*float64 `json:"precipitation_sum"`
+	} `json:"daily"`
+}
+
+// FetchHistoricalWeather calls FetchHistoricalWeather on the default Client
+func FetchHistoricalWeather(ctx context.Context, country string, date time.Time) (*HistoricalWeather, error) {
+	return defaultClient.FetchHistoricalWeather(ctx, country, date)
+}
+
+// FetchHistoricalWeather returns the observed weather for a country's
+// representative city on date's calendar day, e.g. for "this day last year"
+// with time.Now().AddDate(-1, 0, 0). The archive lags a few days behind, so
+// the most recent days can fail with ErrDecode until they're filled in.
+func (c *Client) FetchHistoricalWeather(ctx context.Context, country string, date time.Time) (*HistoricalWeather, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if day.Before(archiveStart) || !day.Before(today) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHistoricalDate, day.Format(openMeteoDateLayout))
+	}
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+
+	ymd := day.Format(openMeteoDateLayout)
+	url := fmt.Sprintf("%s?latitude=%.4f&longitude=%.4f&start_date=%s&end_date=%s&daily=weather_code,temperature_2m_max,temperature_2m_min,temperature_2m_mean,precipitation_sum&timezone=auto",
+		archiveEndpoint, coords.Lat, coords.Lon, ymd, ymd)
+
+	var apiResp archiveResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	data, err := c.decodeHistorical(&apiResp)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, c.cityFor(country)
+	return data, nil
+}
+
+// decodeHistorical builds HistoricalWeather from the first archive day
+func (c *Client) decodeHistorical(apiResp *archiveResponse) (*HistoricalWeather, error) {
+	d := apiResp.Daily
+	if len(d.Time) == 0 || len(d.WeatherCode) == 0 || len(d.MaxTemperature) == 0 ||
+		len(d.MinTemperature) == 0 || len(d.MeanTemperature) == 0 || len(d.Precipitation) == 0 {
+		return nil, fmt.Errorf("%w: no archive day", ErrDecode)
+	}
+	if d.WeatherCode[0] == nil || d.MaxTemperature[0] == nil || d.MinTemperature[0] == nil || d.MeanTemperature[0] == nil {
+		return nil, fmt.Errorf("%w: archive day %s not yet available", ErrDecode, d.Time[0])
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+	date, err := time.ParseInLocation(openMeteoDateLayout, d.Time[0], loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: archive date: %w", ErrDecode, err)
+	}
+
+	data := &HistoricalWeather{
+		Date:             date,
+		Summary:          c.describeWeatherCode(*d.WeatherCode[0]),
+		WeatherCode:      *d.WeatherCode[0],
+		MinTemperatureC:  *d.MinTemperature[0],
+		MaxTemperatureC:  *d.MaxTemperature[0],
+		MeanTemperatureC: *d.MeanTemperature[0],
+		Provenance:       c.provenance(sourceOpenMeteo, date),
+	}
+	// A null precipitation sum means none was recorded
+	if d.Precipitation[0] != nil {
+		data.PrecipitationMm = *d.Precipitation[0]
+	}
+	return data, nil
+}
@@ -0,0 +1,89 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// nagerHolidaysURL lists a year's public holidays for a country from Nager.Date
+const nagerHolidaysURL = "https://date.nager.at/api/v3/PublicHolidays/%d/%s"
+
+// Holiday is a national public holiday
+type Holiday struct {
+	// Date is the local calendar date, at midnight UTC
+	Date      time.Time `json:"date"`
+	Name      string    `json:"name"`
+	LocalName string    `json:"localName"`
+
+	Provenance
+}
+
+// nagerHoliday is one entry of a Nager.Date response
+type nagerHoliday struct {
+	Date      string `json:"date"`
+	LocalName string `json:"localName"`
+	Name      string `json:"name"`
+	Global    bool   `json:"global"`
+}
+
+// FetchHolidays calls FetchHolidays on the default Client
+func FetchHolidays(ctx context.Context, country string, year int) ([]Holiday, error) {
+	return defaultClient.FetchHolidays(ctx, country, year)
+}
+
+// FetchHolidays returns a supported country's national public holidays for
+// year in date order, with English and local names. Regional holidays are
+// left out. Unknown countries return ErrUnsupportedCountry regardless of the
+// unknown country policy; Nager.Date doesn't cover every supported country,
+// and those fail with the upstream status.
+func (c *Client) FetchHolidays(ctx context.Context, country string, year int) ([]Holiday, error) {
+	code := normalizeCountry(country)
+	if !IsSupportedCountry(code) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+
+	var apiResp []nagerHoliday
+	if err := c.getJSON(ctx, fmt.Sprintf(nagerHolidaysURL, year, code), c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+
+	holidays := make([]Holiday, 0, len(apiResp))
+	for _, h := range apiResp {
+		if !h.Global {
+			continue
+		}
+		date, err := time.Parse(openMeteoDateLayout, h.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: holiday date: %w", ErrDecode, err)
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: h.Name, LocalName: h.LocalName, Provenance: c.provenance(sourceNagerDate, time.Time{})})
+	}
+	return holidays, nil
+}
+
+// UpcomingHolidays calls UpcomingHolidays on the default Client
+func UpcomingHolidays(ctx context.Context, country string) ([]Holiday, error) {
+	return defaultClient.UpcomingHolidays(ctx, country)
+}
+
+// UpcomingHolidays returns the national holidays from today through the end
+// of next year, so the list doesn't run dry in December
+func (c *Client) UpcomingHolidays(ctx context.Context, country string) ([]Holiday, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var upcoming []Holiday
+	for _, year := range []int{now.Year(), now.Year() + 1} {
+		holidays, err := c.FetchHolidays(ctx, country, year)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range holidays {
+			if !h.Date.Before(today) {
+				upcoming = append(upcoming, h)
+			}
+		}
+	}
+	return upcoming, nil
+}
@@ -0,0 +1,142 @@
+package feeds
+
+import "fmt"
+
+// Icon is a stable weather icon identifier, the same for every frontend.
+// New identifiers may be added, but existing ones are never renamed.
+type Icon string
+
+const (
+	IconClearDay          Icon = "clear-day"
+	IconClearNight        Icon = "clear-night"
+	IconPartlyCloudyDay   Icon = "partly-cloudy-day"
+	IconPartlyCloudyNight Icon = "partly-cloudy-night"
+	IconCloudy            Icon = "cloudy"
+	IconFog               Icon = "fog"
+	IconDrizzle           Icon = "drizzle"
+	IconRain              Icon = "rain"
+	IconFreezingRain      Icon = "freezing-rain"
+	IconShowers           Icon = "showers"
+	IconSnow              Icon = "snow"
+	IconSnowShowers       Icon = "snow-showers"
+	IconThunderstorm      Icon = "thunderstorm"
+	IconThunderstormHail  Icon = "thunderstorm-hail"
+	IconUnknown           Icon = "unknown"
+)
+
+// IconFor returns the icon of a WMO weather code, with day or night
+// variants for clear and partly cloudy skies. Mainly clear counts as clear,
+// as in WeatherGroupFor.
+func IconFor(code int, day bool) Icon {
+	switch code {
+	case 0, 1:
+		if day {
+			return IconClearDay
+		}
+		return IconClearNight
+	case 2:
+		if day {
+			return IconPartlyCloudyDay
+		}
+		return IconPartlyCloudyNight
+	case 3:
+		return IconCloudy
+	case 45, 48:
+		return IconFog
+	case 51, 53, 55:
+		return IconDrizzle
+	case 56, 57, 66, 67:
+		return IconFreezingRain
+	case 61, 63, 65:
+		return IconRain
+	case 80, 81, 82:
+		return IconShowers
+	case 71, 73, 75, 77:
+		return IconSnow
+	case 85, 86:
+		return IconSnowShowers
+	case 95:
+		return IconThunderstorm
+	case 96, 99:
+		return IconThunderstormHail
+	default:
+		return IconUnknown
+	}
+}
+
+// Severity grades how disruptive a weather condition is. Levels are
+// ordered, so they can be compared, and marshal as their lowercase names.
+type Severity int
+
+const (
+	// SeverityNone is ordinary weather
+	SeverityNone Severity = iota
+	// SeverityAdvisory is a nuisance worth mentioning, such as rime fog or
+	// moderate rain
+	SeverityAdvisory
+	// SeverityWarning disrupts travel or outdoor plans, such as heavy rain
+	// or snow, freezing rain or thunderstorms
+	SeverityWarning
+	// SeveritySevere is dangerous, currently thunderstorms with hail
+	SeveritySevere
+)
+
+// severityNames are the String and JSON forms of each Severity
+var severityNames = map[Severity]string{
+	SeverityNone:     "none",
+	SeverityAdvisory: "advisory",
+	SeverityWarning:  "warning",
+	SeveritySevere:   "severe",
+}
+
+// SeverityFor grades a WMO weather code; unknown codes are SeverityNone
+func SeverityFor(code int) Severity {
+	switch code {
+	case 48, 55, 56, 63, 73, 77, 81, 85:
+		return SeverityAdvisory
+	case 57, 65, 66, 67, 75, 82, 86, 95:
+		return SeverityWarning
+	case 96, 99:
+		return SeveritySevere
+	default:
+		return SeverityNone
+	}
+}
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText encodes the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	name, ok := severityNames[s]
+	if !ok {
+		return nil, fmt.Errorf("unknown severity %d", int(s))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a severity name
+func (s *Severity) UnmarshalText(text []byte) error {
+	for level, name := range severityNames {
+		if name == string(text) {
+			*s = level
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q", text)
+}
+
+// setCondition fills Icon and Severity from WeatherCode; known is false when
+// the source reported no weather code
+func (w *WeatherData) setCondition(known, day bool) {
+	if !known {
+		w.Icon, w.Severity = IconUnknown, SeverityNone
+		return
+	}
+	w.Icon, w.Severity = IconFor(w.WeatherCode, day), SeverityFor(w.WeatherCode)
+}
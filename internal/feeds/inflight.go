@@ -0,0 +1,96 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// errFlightPanicked is returned to callers waiting on a fetch that panicked
+var errFlightPanicked = errors.New("shared fetch panicked")
+
+// flightCall is a fetch in progress that later callers for the same key wait on
+type flightCall[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// flightGroup de-duplicates concurrent fetches of the same request, so a
+// burst of identical requests reaches upstream once
+type flightGroup[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+	// copy gives each caller its own copy of a shared result
+	copy func(T) T
+	// onShared is called for every caller served by another's fetch
+	onShared func()
+}
+
+func newFlightGroup[T any](copy func(T) T, onShared func()) *flightGroup[T] {
+	return &flightGroup[T]{calls: make(map[string]*flightCall[T]), copy: copy, onShared: onShared}
+}
+
+// do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call's result instead. The first caller's context
+// governs the shared fetch; waiting callers can still give up on their own
+// context, and fetch again themselves when only the first caller gave up.
+func (g *flightGroup[T]) do(ctx context.Context, key string, fn func() (T, error)) (T, error) {
+	for {
+		g.mu.Lock()
+		call, ok := g.calls[key]
+		if !ok {
+			break
+		}
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		if isContextError(call.err) && ctx.Err() == nil {
+			continue
+		}
+		if g.onShared != nil {
+			g.onShared()
+		}
+		return g.copy(call.val), call.err
+	}
+	call := &flightCall[T]{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		r := recover()
+		if r != nil {
+			call.err = fmt.Errorf("%w: %v", errFlightPanicked, r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+		// Waiters get the error, while the caller whose fn panicked still
+		// panics
+		if r != nil {
+			panic(r)
+		}
+	}()
+	call.val, call.err = fn()
+	return g.copy(call.val), call.err
+}
+
+// isContextError reports whether err is a cancellation or deadline
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// copyWeather returns a shallow copy of data, or nil
+func copyWeather(data *WeatherData) *WeatherData {
+	if data == nil {
+		return nil
+	}
+	cp := *data
+	return &cp
+}
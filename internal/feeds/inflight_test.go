@@ -0,0 +1,51 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupPanic(t *testing.T) {
+	g := newFlightGroup(func(n int) int { return n }, nil)
+	ctx := context.Background()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	panicked := make(chan any, 1)
+	go func() {
+		defer func() { panicked <- recover() }()
+		g.do(ctx, "key", func() (int, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+
+	waiter := make(chan error, 1)
+	go func() {
+		_, err := g.do(ctx, "key", func() (int, error) { return 0, errors.New("waiter ran its own fetch") })
+		waiter <- err
+	}()
+	// Let the waiter find the call in flight before it panics
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if r := <-panicked; r != "boom" {
+		t.Errorf("caller recovered %v, want the panic", r)
+	}
+	select {
+	case err := <-waiter:
+		if !errors.Is(err, errFlightPanicked) {
+			t.Errorf("waiter err = %v, want errFlightPanicked", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter still blocked after the fetch panicked")
+	}
+
+	n, err := g.do(ctx, "key", func() (int, error) { return 42, nil })
+	if n != 42 || err != nil {
+		t.Errorf("next call = %d, %v, want a fresh fetch", n, err)
+	}
+}
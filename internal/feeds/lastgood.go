@@ -0,0 +1,60 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// WithMaxStaleness serves the last successful current conditions for a
+// location, marked Stale with its AgeSeconds, when fetching them fails and
+// that result is at most d old, so dashboards show slightly old data rather
+// than an error. Results are kept in memory apart from the cache, so this
+// works with caching disabled. Zero, the default, returns the error.
+func WithMaxStaleness(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxStaleness = d
+		if d > 0 && c.lastGood == nil {
+			c.lastGood = newLRUCache(defaultCacheSize)
+		}
+	}
+}
+
+// lastGoodKey keys the last-known-good result of a location
+func lastGoodKey(coords Coordinates) string {
+	return fmt.Sprintf("%g,%g", coords.Lat, coords.Lon)
+}
+
+// keepLastGood records a successful result for lastKnownGood
+func (c *Client) keepLastGood(coords Coordinates, data *WeatherData) {
+	if c.maxStaleness <= 0 {
+		return
+	}
+	// The in-memory store never fails
+	_ = c.lastGood.Set(lastGoodKey(coords), data, c.maxStaleness)
+}
+
+// lastKnownGood returns the last successful result at coords in place of
+// err, unless it's too old or the caller has given up
+func (c *Client) lastKnownGood(ctx context.Context, coords Coordinates, err error) (*WeatherData, bool) {
+	if c.maxStaleness <= 0 || ctx.Err() != nil {
+		return nil, false
+	}
+	data, ok, _ := c.lastGood.Get(lastGoodKey(coords))
+	if !ok || data.FetchedAt.IsZero() {
+		return nil, false
+	}
+	if time.Since(data.FetchedAt) > c.maxStaleness {
+		return nil, false
+	}
+	data.Stale, data.AgeSeconds = true, staleAge(data)
+	logger.Warnf("%sserving last-known-good weather at %s, %gs old: %v", logPrefix(ctx), lastGoodKey(coords), data.AgeSeconds, err)
+	return data, true
+}
+
+// staleAge is the AgeSeconds of a stale result, to the second
+func staleAge(data *WeatherData) float64 {
+	return time.Since(data.FetchedAt).Round(time.Second).Seconds()
+}
@@ -0,0 +1,95 @@
+package feeds
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultLatencyWindow is how many recent request durations are kept by default
+const defaultLatencyWindow = 100
+
+// LatencySummary summarizes recent upstream request durations
+type LatencySummary struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Avg   time.Duration `json:"avg"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	Max   time.Duration `json:"max"`
+}
+
+// WithLatencyWindow sets how many recent request durations LatencyStats covers (default 100)
+func WithLatencyWindow(n int) Option {
+	return func(c *Client) {
+		c.latency = newLatencySampler(n)
+	}
+}
+
+// latencySampler keeps the most recent durations in a ring buffer
+type latencySampler struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+func newLatencySampler(window int) *latencySampler {
+	return &latencySampler{samples: make([]time.Duration, max(window, 1))}
+}
+
+func (s *latencySampler) record(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.samples[s.next] = d
+	s.next = (s.next + 1) % len(s.samples)
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+func (s *latencySampler) stats() LatencySummary {
+	s.mu.Lock()
+	n := s.next
+	if s.full {
+		n = len(s.samples)
+	}
+	sorted := slices.Clone(s.samples[:n])
+	s.mu.Unlock()
+
+	if len(sorted) == 0 {
+		return LatencySummary{}
+	}
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return LatencySummary{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Avg:   total / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 0.50),
+		P95:   percentile(sorted, 0.95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// LatencyStats returns request latency statistics for the default Client
+func LatencyStats() LatencySummary {
+	return defaultClient.LatencyStats()
+}
+
+// LatencyStats returns statistics over the Client's most recent upstream requests
+func (c *Client) LatencyStats() LatencySummary {
+	return c.latency.stats()
+}
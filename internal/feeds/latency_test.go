@@ -0,0 +1,50 @@
+package feeds
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLatencySampler(t *testing.T) {
+	ms := time.Millisecond
+	// durations returns from to to milliseconds in that order
+	durations := func(from, to int) []time.Duration {
+		var d []time.Duration
+		for i := from; i <= to; i++ {
+			d = append(d, time.Duration(i)*ms)
+		}
+		return d
+	}
+	descending := durations(1, 100)
+	slices.Reverse(descending)
+
+	tests := []struct {
+		name   string
+		window int
+		record []time.Duration
+		want   LatencySummary
+	}{
+		{name: "empty", window: 10},
+		{
+			name: "100ms down to 1ms", window: 100, record: descending,
+			want: LatencySummary{Count: 100, Min: ms, Avg: 50*ms + 500*time.Microsecond, P50: 50 * ms, P95: 95 * ms, Max: 100 * ms},
+		},
+		{
+			// Only 11ms to 20ms are still in the window
+			name: "rolling window", window: 10, record: durations(1, 20),
+			want: LatencySummary{Count: 10, Min: 11 * ms, Avg: 15*ms + 500*time.Microsecond, P50: 15 * ms, P95: 20 * ms, Max: 20 * ms},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newLatencySampler(tt.window)
+			for _, d := range tt.record {
+				s.record(d)
+			}
+			if got := s.stats(); got != tt.want {
+				t.Errorf("stats = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
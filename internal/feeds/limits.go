@@ -0,0 +1,46 @@
+package feeds
+
+import "time"
+
+// forecastLimits bounds how much of an hourly forecast is kept after parsing
+type forecastLimits struct {
+	maxEntries int
+	step       int
+}
+
+// WithForecastLimits caps parsed hourly forecasts at maxEntries entries and
+// keeps only every step-th hour; zero or negative values mean no cap and
+// every hour. Limits are applied while the response is parsed, so the full
+// arrays are dropped as soon as they're read. The trade-off is resolution and
+// reach: a downsampled forecast answers ForecastAt with the nearest kept hour,
+// TempChangeRate averages over the wider gap, and times past the capped end
+// return ErrOutsideForecastRange.
+func WithForecastLimits(maxEntries, step int) Option {
+	return func(c *Client) {
+		c.forecastLimits = forecastLimits{maxEntries: maxEntries, step: step}
+	}
+}
+
+// stride is the index increment between kept hours
+func (l forecastLimits) stride() int {
+	return max(l.step, 1)
+}
+
+// span is how long each kept entry covers
+func (l forecastLimits) span() time.Duration {
+	return time.Duration(l.stride()) * time.Hour
+}
+
+// full reports whether n entries reach the cap
+func (l forecastLimits) full(n int) bool {
+	return l.maxEntries > 0 && n >= l.maxEntries
+}
+
+// capacity is how many entries parsing n hours can keep at most
+func (l forecastLimits) capacity(n int) int {
+	kept := (n + l.stride() - 1) / l.stride()
+	if l.maxEntries > 0 {
+		kept = min(kept, l.maxEntries)
+	}
+	return kept
+}
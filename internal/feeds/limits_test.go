@@ -0,0 +1,51 @@
+package feeds
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForecastLimits(t *testing.T) {
+	// Two days of hourly UTC data, each hour's temperature its index
+	var r hourlyForecastResponse
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	for i := range 48 {
+		temp, code := float64(i), 1
+		r.Hourly.Time = append(r.Hourly.Time, start.Add(time.Duration(i)*time.Hour).Format(openMeteoTimeLayout))
+		r.Hourly.Temperature = append(r.Hourly.Temperature, &temp)
+		r.Hourly.ApparentTemperature = append(r.Hourly.ApparentTemperature, &temp)
+		r.Hourly.WeatherCode = append(r.Hourly.WeatherCode, &code)
+	}
+	r.Timezone = "GMT"
+
+	tests := []struct {
+		name      string
+		limits    forecastLimits
+		wantLen   int
+		wantTemps []float64 // the first few kept hours
+	}{
+		{name: "no limits", wantLen: 48, wantTemps: []float64{0, 1, 2}},
+		{name: "every 3 hours", limits: forecastLimits{step: 3}, wantLen: 16, wantTemps: []float64{0, 3, 6}},
+		{name: "capped", limits: forecastLimits{maxEntries: 24}, wantLen: 24, wantTemps: []float64{0, 1, 2}},
+		{name: "every 3 hours, capped", limits: forecastLimits{maxEntries: 4, step: 3}, wantLen: 4, wantTemps: []float64{0, 3, 6, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := r.entries(tt.limits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != tt.wantLen {
+				t.Fatalf("%d entries, want %d", len(entries), tt.wantLen)
+			}
+			if cap(entries) != tt.wantLen {
+				t.Errorf("capacity %d, want %d with nothing over-allocated", cap(entries), tt.wantLen)
+			}
+			for i, want := range tt.wantTemps {
+				if got := entries[i].temperatureC; got != want {
+					t.Errorf("entry %d = %v°C, want %v°C", i, got, want)
+				}
+			}
+		})
+	}
+}
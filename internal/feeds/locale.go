@@ -0,0 +1,428 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrUnsupportedLocale reports a WithLocale tag with no bundled translations
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// localeKey is the context key for the caller's preferred locale
+type localeKey struct{}
+
+// WithLocale sets the BCP-47 locale of current-conditions summaries, e.g.
+// "ja" or "zh-TW" (default English). Tags are matched by language, and for
+// Chinese by script or region, so "th-TH" uses Thai and "zh-HK" Traditional
+// Chinese. A tag with no translations fails NewClient with ErrInvalidConfig
+// wrapping ErrUnsupportedLocale.
+func WithLocale(tag string) Option {
+	return func(c *Client) {
+		c.locale = tag
+	}
+}
+
+// ContextWithLocale returns a context whose fetches use tag in place of the
+// Client's locale. Unlike WithLocale an unsupported tag isn't an error: the
+// Client's locale is used instead, so Accept-Language values can be passed
+// straight through.
+func ContextWithLocale(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, localeKey{}, tag)
+}
+
+// SupportedLocales returns the locales with bundled summary translations,
+// including "en", in sorted order
+func SupportedLocales() []string {
+	return slices.Sorted(maps.Keys(summaryTranslations))
+}
+
+// DescribeWeatherCode returns the summary of a WMO weather code in locale,
+// reporting false when the code or locale is unknown
+func DescribeWeatherCode(code int, locale string) (string, bool) {
+	tag, ok := matchLocale(locale)
+	if !ok {
+		return "", false
+	}
+	description, ok := summaryTranslations[tag][code]
+	return description, ok
+}
+
+// matchLocale maps a BCP-47 tag to a summaryTranslations key
+func matchLocale(tag string) (string, bool) {
+	subtags := strings.Split(strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")), "-")
+	lang := subtags[0]
+	switch lang {
+	case "tl":
+		lang = "fil"
+	case "zh":
+		for _, sub := range subtags[1:] {
+			switch sub {
+			case "hans":
+				return "zh-Hans", true
+			case "hant", "tw", "hk", "mo":
+				return "zh-Hant", true
+			}
+		}
+		return "zh-Hans", true
+	}
+	_, ok := summaryTranslations[lang]
+	return lang, ok
+}
+
+// localeFor returns the matched locale for a fetch: the context's when it is
+// supported, else the Client's
+func (c *Client) localeFor(ctx context.Context) string {
+	if tag, ok := ctx.Value(localeKey{}).(string); ok {
+		if matched, ok := matchLocale(tag); ok {
+			return matched
+		}
+	}
+	matched, _ := matchLocale(c.locale)
+	return matched
+}
+
+// checkLocale validates the configured locale
+func (c *Client) checkLocale() error {
+	if c.locale == "" {
+		return nil
+	}
+	if _, ok := matchLocale(c.locale); !ok {
+		return fmt.Errorf("%w: %q", ErrUnsupportedLocale, c.locale)
+	}
+	return nil
+}
+
+// localize returns data with its Summary and Narrative in the fetch's
+// locale. Only the standard English descriptions are translated; custom
+// wording such as WithNightDescriptions or WithUnknownDescription is kept.
+// data may be shared through the cache, so a localized copy is returned
+// rather than changing it.
+func (c *Client) localize(ctx context.Context, data *WeatherData) *WeatherData {
+	locale := c.localeFor(ctx)
+	if locale == "" || locale == "en" {
+		return data
+	}
+	localized := *data
+	if data.Summary == weatherCodeDescriptions[data.WeatherCode] {
+		if description, ok := summaryTranslations[locale][data.WeatherCode]; ok {
+			localized.Summary, localized.Locale = description, locale
+		}
+	}
+	if data.Outlook != nil {
+		localized.Narrative = data.Outlook.Narrative(locale)
+	}
+	if localized.Summary == data.Summary && localized.Narrative == data.Narrative {
+		return data
+	}
+	return &localized
+}
+
+// summaryTranslations holds the WMO code descriptions for the languages of
+// the supported countries
+var summaryTranslations = map[string]map[int]string{
+	"en": weatherCodeDescriptions,
+	"ja": {
+		0:  "快晴",
+		1:  "晴れ",
+		2:  "一部曇り",
+		3:  "曇り",
+		45: "霧",
+		48: "着氷性の霧",
+		51: "弱い霧雨",
+		53: "霧雨",
+		55: "強い霧雨",
+		56: "弱い着氷性の霧雨",
+		57: "強い着氷性の霧雨",
+		61: "小雨",
+		63: "雨",
+		65: "大雨",
+		66: "弱い着氷性の雨",
+		67: "強い着氷性の雨",
+		71: "小雪",
+		73: "雪",
+		75: "大雪",
+		77: "霧雪",
+		80: "弱いにわか雨",
+		81: "にわか雨",
+		82: "激しいにわか雨",
+		85: "弱いにわか雪",
+		86: "強いにわか雪",
+		95: "雷雨",
+		96: "雷雨（弱いひょうを伴う）",
+		99: "雷雨（強いひょうを伴う）",
+	},
+	"zh-Hans": {
+		0:  "晴",
+		1:  "大部晴朗",
+		2:  "局部多云",
+		3:  "阴",
+		45: "雾",
+		48: "冻雾",
+		51: "小毛毛雨",
+		53: "中毛毛雨",
+		55: "大毛毛雨",
+		56: "小冻毛毛雨",
+		57: "大冻毛毛雨",
+		61: "小雨",
+		63: "中雨",
+		65: "大雨",
+		66: "小冻雨",
+		67: "大冻雨",
+		71: "小雪",
+		73: "中雪",
+		75: "大雪",
+		77: "米雪",
+		80: "小阵雨",
+		81: "中阵雨",
+		82: "强阵雨",
+		85: "小阵雪",
+		86: "大阵雪",
+		95: "雷暴",
+		96: "雷暴伴有小冰雹",
+		99: "雷暴伴有大冰雹",
+	},
+	"zh-Hant": {
+		0:  "晴",
+		1:  "大致晴朗",
+		2:  "局部多雲",
+		3:  "陰",
+		45: "霧",
+		48: "凍霧",
+		51: "小毛毛雨",
+		53: "中毛毛雨",
+		55: "大毛毛雨",
+		56: "小凍毛毛雨",
+		57: "大凍毛毛雨",
+		61: "小雨",
+		63: "中雨",
+		65: "大雨",
+		66: "小凍雨",
+		67: "大凍雨",
+		71: "小雪",
+		73: "中雪",
+		75: "大雪",
+		77: "米雪",
+		80: "小陣雨",
+		81: "中陣雨",
+		82: "強陣雨",
+		85: "小陣雪",
+		86: "大陣雪",
+		95: "雷暴",
+		96: "雷暴伴有小冰雹",
+		99: "雷暴伴有大冰雹",
+	},
+	"ko": {
+		0:  "맑음",
+		1:  "대체로 맑음",
+		2:  "구름 조금",
+		3:  "흐림",
+		45: "안개",
+		48: "서리 안개",
+		51: "약한 이슬비",
+		53: "보통 이슬비",
+		55: "강한 이슬비",
+		56: "약한 어는 이슬비",
+		57: "강한 어는 이슬비",
+		61: "약한 비",
+		63: "보통 비",
+		65: "강한 비",
+		66: "약한 어는 비",
+		67: "강한 어는 비",
+		71: "약한 눈",
+		73: "보통 눈",
+		75: "강한 눈",
+		77: "싸락눈",
+		80: "약한 소나기",
+		81: "보통 소나기",
+		82: "강한 소나기",
+		85: "약한 소낙눈",
+		86: "강한 소낙눈",
+		95: "뇌우",
+		96: "약한 우박을 동반한 뇌우",
+		99: "강한 우박을 동반한 뇌우",
+	},
+	"th": {
+		0:  "ท้องฟ้าแจ่มใส",
+		1:  "ส่วนใหญ่แจ่มใส",
+		2:  "มีเมฆบางส่วน",
+		3:  "เมฆครึ้ม",
+		45: "หมอก",
+		48: "หมอกน้ำค้างแข็ง",
+		51: "ฝนละอองเบา",
+		53: "ฝนละอองปานกลาง",
+		55: "ฝนละอองหนาแน่น",
+		56: "ฝนละอองเยือกแข็งเบา",
+		57: "ฝนละอองเยือกแข็งหนาแน่น",
+		61: "ฝนตกเล็กน้อย",
+		63: "ฝนตกปานกลาง",
+		65: "ฝนตกหนัก",
+		66: "ฝนเยือกแข็งเล็กน้อย",
+		67: "ฝนเยือกแข็งหนัก",
+		71: "หิมะตกเล็กน้อย",
+		73: "หิมะตกปานกลาง",
+		75: "หิมะตกหนัก",
+		77: "เม็ดหิมะ",
+		80: "ฝนซู่เล็กน้อย",
+		81: "ฝนซู่ปานกลาง",
+		82: "ฝนซู่รุนแรง",
+		85: "หิมะซู่เล็กน้อย",
+		86: "หิมะซู่หนัก",
+		95: "พายุฝนฟ้าคะนอง",
+		96: "พายุฝนฟ้าคะนองพร้อมลูกเห็บเล็กน้อย",
+		99: "พายุฝนฟ้าคะนองพร้อมลูกเห็บหนัก",
+	},
+	"vi": {
+		0:  "Trời quang",
+		1:  "Chủ yếu quang đãng",
+		2:  "Có mây rải rác",
+		3:  "U ám",
+		45: "Sương mù",
+		48: "Sương mù băng giá",
+		51: "Mưa phùn nhẹ",
+		53: "Mưa phùn vừa",
+		55: "Mưa phùn dày",
+		56: "Mưa phùn băng giá nhẹ",
+		57: "Mưa phùn băng giá dày",
+		61: "Mưa nhẹ",
+		63: "Mưa vừa",
+		65: "Mưa to",
+		66: "Mưa băng nhẹ",
+		67: "Mưa băng to",
+		71: "Tuyết nhẹ",
+		73: "Tuyết vừa",
+		75: "Tuyết dày",
+		77: "Hạt tuyết",
+		80: "Mưa rào nhẹ",
+		81: "Mưa rào vừa",
+		82: "Mưa rào rất to",
+		85: "Tuyết rơi rào nhẹ",
+		86: "Tuyết rơi rào dày",
+		95: "Dông",
+		96: "Dông kèm mưa đá nhẹ",
+		99: "Dông kèm mưa đá lớn",
+	},
+	"id": {
+		0:  "Cerah",
+		1:  "Sebagian besar cerah",
+		2:  "Berawan sebagian",
+		3:  "Mendung",
+		45: "Berkabut",
+		48: "Kabut beku",
+		51: "Gerimis ringan",
+		53: "Gerimis sedang",
+		55: "Gerimis lebat",
+		56: "Gerimis beku ringan",
+		57: "Gerimis beku lebat",
+		61: "Hujan ringan",
+		63: "Hujan sedang",
+		65: "Hujan lebat",
+		66: "Hujan beku ringan",
+		67: "Hujan beku lebat",
+		71: "Salju ringan",
+		73: "Salju sedang",
+		75: "Salju lebat",
+		77: "Butiran salju",
+		80: "Hujan sesaat ringan",
+		81: "Hujan sesaat sedang",
+		82: "Hujan sesaat sangat lebat",
+		85: "Salju sesaat ringan",
+		86: "Salju sesaat lebat",
+		95: "Badai petir",
+		96: "Badai petir dengan hujan es ringan",
+		99: "Badai petir dengan hujan es lebat",
+	},
+	"ms": {
+		0:  "Langit cerah",
+		1:  "Kebanyakannya cerah",
+		2:  "Berawan sebahagian",
+		3:  "Mendung",
+		45: "Berkabus",
+		48: "Kabus beku",
+		51: "Gerimis ringan",
+		53: "Gerimis sederhana",
+		55: "Gerimis lebat",
+		56: "Gerimis beku ringan",
+		57: "Gerimis beku lebat",
+		61: "Hujan ringan",
+		63: "Hujan sederhana",
+		65: "Hujan lebat",
+		66: "Hujan beku ringan",
+		67: "Hujan beku lebat",
+		71: "Salji ringan",
+		73: "Salji sederhana",
+		75: "Salji lebat",
+		77: "Butiran salji",
+		80: "Hujan sekejap ringan",
+		81: "Hujan sekejap sederhana",
+		82: "Hujan sekejap sangat lebat",
+		85: "Salji sekejap ringan",
+		86: "Salji sekejap lebat",
+		95: "Ribut petir",
+		96: "Ribut petir dengan hujan batu ringan",
+		99: "Ribut petir dengan hujan batu lebat",
+	},
+	"hi": {
+		0:  "साफ़ आसमान",
+		1:  "अधिकतर साफ़",
+		2:  "आंशिक रूप से बादल",
+		3:  "घने बादल",
+		45: "कोहरा",
+		48: "जमने वाला कोहरा",
+		51: "हल्की फुहार",
+		53: "मध्यम फुहार",
+		55: "घनी फुहार",
+		56: "हल्की जमने वाली फुहार",
+		57: "घनी जमने वाली फुहार",
+		61: "हल्की बारिश",
+		63: "मध्यम बारिश",
+		65: "भारी बारिश",
+		66: "हल्की जमने वाली बारिश",
+		67: "भारी जमने वाली बारिश",
+		71: "हल्की बर्फबारी",
+		73: "मध्यम बर्फबारी",
+		75: "भारी बर्फबारी",
+		77: "बर्फ के कण",
+		80: "हल्की बौछारें",
+		81: "मध्यम बौछारें",
+		82: "तेज़ बौछारें",
+		85: "हल्की बर्फ़ की बौछारें",
+		86: "भारी बर्फ़ की बौछारें",
+		95: "आंधी-तूफ़ान",
+		96: "हल्के ओलों के साथ आंधी-तूफ़ान",
+		99: "भारी ओलों के साथ आंधी-तूफ़ान",
+	},
+	"fil": {
+		0:  "Maaliwalas na kalangitan",
+		1:  "Halos maaliwalas",
+		2:  "Bahagyang maulap",
+		3:  "Makulimlim",
+		45: "Mahamog",
+		48: "Nagyeyelong hamog",
+		51: "Mahinang ambon",
+		53: "Katamtamang ambon",
+		55: "Makapal na ambon",
+		56: "Mahinang nagyeyelong ambon",
+		57: "Makapal na nagyeyelong ambon",
+		61: "Mahinang ulan",
+		63: "Katamtamang ulan",
+		65: "Malakas na ulan",
+		66: "Mahinang nagyeyelong ulan",
+		67: "Malakas na nagyeyelong ulan",
+		71: "Mahinang niyebe",
+		73: "Katamtamang niyebe",
+		75: "Malakas na niyebe",
+		77: "Butil ng niyebe",
+		80: "Mahinang pabugso-bugsong ulan",
+		81: "Katamtamang pabugso-bugsong ulan",
+		82: "Napakalakas na pabugso-bugsong ulan",
+		85: "Mahinang pabugso-bugsong niyebe",
+		86: "Malakas na pabugso-bugsong niyebe",
+		95: "Kulog at kidlat",
+		96: "Kulog at kidlat na may mahinang graniso",
+		99: "Kulog at kidlat na may malakas na graniso",
+	},
+}
@@ -0,0 +1,84 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestContextWithLocale(t *testing.T) {
+	// The forecast fixture reports weather code 1
+	describe := func(locale string) string {
+		t.Helper()
+		s, ok := feeds.DescribeWeatherCode(1, locale)
+		if !ok {
+			t.Fatalf("no %s summary for code 1", locale)
+		}
+		return s
+	}
+	tests := []struct {
+		name    string
+		options []feeds.Option
+		ctx     context.Context
+		want    string
+	}{
+		{name: "client default", ctx: context.Background(), want: describe("en")},
+		{name: "context language", ctx: feeds.ContextWithLocale(context.Background(), "ja"), want: describe("ja")},
+		{
+			name:    "context overrides the client locale",
+			options: []feeds.Option{feeds.WithLocale("th")},
+			ctx:     feeds.ContextWithLocale(context.Background(), "ja-JP"),
+			want:    describe("ja"),
+		},
+		{
+			name:    "unsupported context language",
+			options: []feeds.Option{feeds.WithLocale("th")},
+			ctx:     feeds.ContextWithLocale(context.Background(), "xx"),
+			want:    describe("th"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := feedstest.NewServer(t).Client(t, tt.options...)
+			data, err := c.FetchWeather(tt.ctx, "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.Summary != tt.want {
+				t.Errorf("Summary = %q, want %q", data.Summary, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleNormalization(t *testing.T) {
+	english, _ := feeds.DescribeWeatherCode(1, "en")
+	japanese, _ := feeds.DescribeWeatherCode(1, "ja")
+	traditional, _ := feeds.DescribeWeatherCode(1, "zh-Hant")
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{tag: "EN", want: english, ok: true},
+		{tag: "en-US", want: english, ok: true},
+		{tag: " ja_JP ", want: japanese, ok: true},
+		{tag: "zh-TW", want: traditional, ok: true},
+		{tag: "xx"},
+		{tag: "english"},
+	}
+	for _, tt := range tests {
+		got, ok := feeds.DescribeWeatherCode(1, tt.tag)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("DescribeWeatherCode(1, %q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Unsupported tags are an error when configured, unlike per-call ones
+	if _, err := feeds.NewClient(feeds.WithLocale("english")); !errors.Is(err, feeds.ErrUnsupportedLocale) {
+		t.Errorf("WithLocale(english): err = %v, want ErrUnsupportedLocale", err)
+	}
+}
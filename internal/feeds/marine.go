@@ -0,0 +1,278 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// marineEndpoint is the Open-Meteo marine API
+const marineEndpoint = "https://marine-api.open-meteo.com/v1/marine"
+
+// ErrNoMarineData is returned for places the marine model doesn't cover,
+// such as inland or landlocked coordinates
+var ErrNoMarineData = errors.New("no marine data at coordinates")
+
+// tideWindow is how far ahead MarineData.Tides reaches
+const tideWindow = 24 * time.Hour
+
+// MarineData is current sea conditions, with heights in metres, periods in
+// seconds and directions in degrees the waves come from
+type MarineData struct {
+	SeaSurfaceTemperatureC float64 `json:"seaSurfaceTemperatureC"`
+	WaveHeightM            float64 `json:"waveHeightM"`
+	WaveDirectionDeg       float64 `json:"waveDirectionDeg"`
+	WavePeriodS            float64 `json:"wavePeriodS"`
+	SwellHeightM           float64 `json:"swellHeightM"`
+	SwellDirectionDeg      float64 `json:"swellDirectionDeg"`
+	SwellPeriodS           float64 `json:"swellPeriodS"`
+
+	// Tides are the high and low tides of the next 24 hours, in time order
+	Tides []TideEvent `json:"tides"`
+
+	// Approximate and City mean the same as on WeatherData
+	Approximate bool   `json:"approximate,omitempty"`
+	City        string `json:"city,omitempty"`
+
+	// MissingFields lists expected fields absent from the response, and
+	// "tides" when the tide provider failed
+	MissingFields []string `json:"missingFields,omitempty"`
+
+	Provenance
+}
+
+// TideType tells high tide from low tide
+type TideType string
+
+const (
+	HighTide TideType = "high"
+	LowTide  TideType = "low"
+)
+
+// TideEvent is one high or low tide
+type TideEvent struct {
+	Time    time.Time `json:"time"`
+	Type    TideType  `json:"type"`
+	HeightM float64   `json:"heightM"`
+}
+
+// TideProvider returns the high and low tides at coords between from and to,
+// in time order
+type TideProvider interface {
+	Tides(ctx context.Context, coords Coordinates, from, to time.Time) ([]TideEvent, error)
+}
+
+// WithTideProvider sets where MarineData.Tides come from. By default they are
+// estimated from Open-Meteo's modelled sea level, which is fine for beach
+// dashboards but, lacking local harbour effects, not for navigation.
+func WithTideProvider(p TideProvider) Option {
+	return func(c *Client) {
+		c.tides = p
+	}
+}
+
+// asiaCoastalCities are gazetteer cities used for marine data in countries
+// whose reference city is inland; countries missing from both this and the
+// coast fail with ErrNoMarineData
+var asiaCoastalCities = map[string]string{
+	"CN": "Shanghai",
+	"IN": "Mumbai",
+	"KR": "Busan",
+	"TH": "Phuket",
+	"VN": "Da Nang",
+	"TW": "Kaohsiung",
+	"MY": "George Town",
+	"PK": "Karachi",
+	"BD": "Chittagong",
+	"MM": "Yangon",
+	"KH": "Sihanoukville",
+	"SA": "Jeddah",
+	"JO": "Aqaba",
+}
+
+// marineResponse represents the current and hourly blocks of an Open-Meteo
+// marine response
+type marineResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Current              struct {
+		Time                  string   `json:"time"`
+		SeaSurfaceTemperature *float64 `json:"sea_surface_temperature"`
+		WaveHeight            *float64 `json:"wave_height"`
+		WaveDirection         *float64 `json:"wave_direction"`
+		WavePeriod            *float64 `json:"wave_period"`
+		SwellHeight           *float64 `json:"swell_wave_height"`
+		SwellDirection        *float64 `json:"swell_wave_direction"`
+		SwellPeriod           *float64 `json:"swell_wave_period"`
+	} `json:"current"`
+	Hourly struct {
+		Time     []string   `json:"time"`
+		SeaLevel []*float64 `json:"sea_level_height_msl"`
+	} `json:"hourly"`
+}
+
+// FetchMarine calls FetchMarine on the default Client
+func FetchMarine(ctx context.Context, country string) (*MarineData, error) {
+	return defaultClient.FetchMarine(ctx, country)
+}
+
+// FetchMarine fetches sea conditions and tides for a country, at its main
+// coastal city when the reference city is inland
+func (c *Client) FetchMarine(ctx context.Context, country string) (*MarineData, error) {
+	if city, ok := asiaCoastalCities[normalizeCountry(country)]; ok {
+		if _, ok := c.referenceCity(country); !ok {
+			return c.FetchMarineForCity(ctx, country, city)
+		}
+	}
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.FetchMarineAt(ctx, coords)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, c.cityFor(country)
+	return data, nil
+}
+
+// FetchMarineForCity calls FetchMarineForCity on the default Client
+func FetchMarineForCity(ctx context.Context, country, city string) (*MarineData, error) {
+	return defaultClient.FetchMarineForCity(ctx, country, city)
+}
+
+// FetchMarineForCity fetches sea conditions and tides for a city in the
+// bundled gazetteer, as FetchWeatherForCity does for weather
+func (c *Client) FetchMarineForCity(ctx context.Context, country, city string) (*MarineData, error) {
+	entry, err := lookupCity(country, city)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.FetchMarineAt(ctx, entry.coords)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, entry.name
+	return data, nil
+}
+
+// FetchMarineAt calls FetchMarineAt on the default Client
+func FetchMarineAt(ctx context.Context, coords Coordinates) (*MarineData, error) {
+	return defaultClient.FetchMarineAt(ctx, coords)
+}
+
+// FetchMarineAt fetches sea conditions and tides at exact coordinates, which
+// should be on or near the coast
+func (c *Client) FetchMarineAt(ctx context.Context, coords Coordinates) (*MarineData, error) {
+	if err := ValidateCoordinates(coords.Lat, coords.Lon); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s?latitude=%.4f&longitude=%.4f&current=sea_surface_temperature,wave_height,wave_direction,wave_period,swell_wave_height,swell_wave_direction,swell_wave_period&hourly=sea_level_height_msl&timezone=auto&forecast_days=2",
+		marineEndpoint, coords.Lat, coords.Lon)
+
+	var apiResp marineResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	data, err := c.decodeMarine(&apiResp)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if c.tides != nil {
+		data.Tides, err = c.tides.Tides(ctx, coords, now, now.Add(tideWindow))
+	} else {
+		data.Tides, err = seaLevelTides(&apiResp, now, now.Add(tideWindow))
+	}
+	if err != nil {
+		logger.Warnf("%stides at %.4f,%.4f unavailable: %v", logPrefix(ctx), coords.Lat, coords.Lon, err)
+		data.Tides = nil
+		data.MissingFields = append(data.MissingFields, "tides")
+	}
+	return data, nil
+}
+
+// decodeMarine builds MarineData from the current block, failing with
+// ErrNoMarineData when every value is null
+func (c *Client) decodeMarine(apiResp *marineResponse) (*MarineData, error) {
+	cur := apiResp.Current
+	data := &MarineData{}
+	fields := []struct {
+		name string
+		src  *float64
+		dst  *float64
+	}{
+		{"sea_surface_temperature", cur.SeaSurfaceTemperature, &data.SeaSurfaceTemperatureC},
+		{"wave_height", cur.WaveHeight, &data.WaveHeightM},
+		{"wave_direction", cur.WaveDirection, &data.WaveDirectionDeg},
+		{"wave_period", cur.WavePeriod, &data.WavePeriodS},
+		{"swell_wave_height", cur.SwellHeight, &data.SwellHeightM},
+		{"swell_wave_direction", cur.SwellDirection, &data.SwellDirectionDeg},
+		{"swell_wave_period", cur.SwellPeriod, &data.SwellPeriodS},
+	}
+	for _, f := range fields {
+		if f.src == nil {
+			data.MissingFields = append(data.MissingFields, f.name)
+			continue
+		}
+		*f.dst = *f.src
+	}
+	if len(data.MissingFields) == len(fields) {
+		return nil, ErrNoMarineData
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+	observedAt, _ := time.ParseInLocation(openMeteoTimeLayout, cur.Time, loc)
+	data.Provenance = c.provenance(sourceOpenMeteo, observedAt)
+	return data, nil
+}
+
+// seaLevelTides finds the high and low tides between from and to as the
+// turning points of the hourly modelled sea level, refined between hours by
+// fitting a parabola through each turning point and its neighbours
+func seaLevelTides(apiResp *marineResponse, from, to time.Time) ([]TideEvent, error) {
+	h := apiResp.Hourly
+	n := min(len(h.Time), len(h.SeaLevel))
+	if n < 3 {
+		return nil, fmt.Errorf("%w: no hourly sea level", ErrDecode)
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+
+	var tides []TideEvent
+	for i := 1; i < n-1; i++ {
+		prev, cur, next := h.SeaLevel[i-1], h.SeaLevel[i], h.SeaLevel[i+1]
+		if prev == nil || cur == nil || next == nil {
+			continue
+		}
+		var typ TideType
+		switch {
+		case *cur > *prev && *cur >= *next:
+			typ = HighTide
+		case *cur < *prev && *cur <= *next:
+			typ = LowTide
+		default:
+			continue
+		}
+		at, err := time.ParseInLocation(openMeteoTimeLayout, h.Time[i], loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: sea level time: %w", ErrDecode, err)
+		}
+
+		// Vertex of the parabola through the three hourly values
+		offset, height := 0.0, *cur
+		if curvature := *prev - 2**cur + *next; curvature != 0 {
+			offset = (*prev - *next) / (2 * curvature)
+			height = *cur - (*prev-*next)*offset/4
+		}
+		at = at.Add(time.Duration(offset * float64(time.Hour))).Truncate(time.Minute)
+		if at.Before(from) || !at.Before(to) {
+			continue
+		}
+		tides = append(tides, TideEvent{Time: at, Type: typ, HeightM: height})
+	}
+	return tides, nil
+}
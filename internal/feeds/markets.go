@@ -0,0 +1,202 @@
+package feeds
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+// yahooChartEndpoint is the Yahoo Finance chart API behind its quote pages
+const yahooChartEndpoint = "https://query1.finance.yahoo.com/v8/finance/chart/"
+
+// ErrNoMarketIndex is returned for countries without a tracked stock index
+var ErrNoMarketIndex = errors.New("no stock index for country")
+
+// MarketIndex is a country's benchmark equity index
+type MarketIndex struct {
+	Country string `json:"country"`
+	Name    string `json:"name"`
+	// Symbol is the Yahoo Finance ticker, e.g. "^N225"
+	Symbol string `json:"symbol"`
+}
+
+// IndexQuote is the latest level of a stock index
+type IndexQuote struct {
+	MarketIndex
+	Value float64 `json:"value"`
+	// Change and ChangePercent are against the previous close
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"changePercent"`
+	Currency      string  `json:"currency,omitempty"`
+
+	// Provenance.ObservedAt is the time of Value; quotes may be delayed by
+	// the provider
+	Provenance
+}
+
+// MarketProvider returns the latest quote for an index
+type MarketProvider interface {
+	Quote(ctx context.Context, index MarketIndex) (*IndexQuote, error)
+}
+
+// WithMarketProvider sets where index quotes come from instead of Yahoo
+// Finance, whose unofficial API has no terms of service or uptime guarantee
+func WithMarketProvider(p MarketProvider) Option {
+	return func(c *Client) {
+		c.markets = p
+	}
+}
+
+// asiaMarketIndices are the benchmark index of each country that has one on
+// Yahoo Finance
+var asiaMarketIndices = []MarketIndex{
+	{Country: "CN", Name: "SSE Composite", Symbol: "000001.SS"},
+	{Country: "HK", Name: "Hang Seng", Symbol: "^HSI"},
+	{Country: "ID", Name: "IDX Composite", Symbol: "^JKSE"},
+	{Country: "IN", Name: "Nifty 50", Symbol: "^NSEI"},
+	{Country: "JP", Name: "Nikkei 225", Symbol: "^N225"},
+	{Country: "KR", Name: "KOSPI", Symbol: "^KS11"},
+	{Country: "MY", Name: "FTSE Bursa Malaysia KLCI", Symbol: "^KLSE"},
+	{Country: "PH", Name: "PSEi", Symbol: "PSEI.PS"},
+	{Country: "SA", Name: "Tadawul All Share", Symbol: "^TASI.SR"},
+	{Country: "SG", Name: "Straits Times Index", Symbol: "^STI"},
+	{Country: "TH", Name: "SET Index", Symbol: "^SET.BK"},
+	{Country: "TW", Name: "TAIEX", Symbol: "^TWII"},
+}
+
+// MarketIndices returns the tracked stock indices, sorted by country
+func MarketIndices() []MarketIndex {
+	return slices.Clone(asiaMarketIndices)
+}
+
+// marketIndexFor returns the index of a country
+func marketIndexFor(country string) (MarketIndex, error) {
+	code := normalizeCountry(country)
+	if !IsSupportedCountry(code) {
+		return MarketIndex{}, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	i := slices.IndexFunc(asiaMarketIndices, func(m MarketIndex) bool { return m.Country == code })
+	if i < 0 {
+		return MarketIndex{}, fmt.Errorf("%w: %s", ErrNoMarketIndex, code)
+	}
+	return asiaMarketIndices[i], nil
+}
+
+// FetchMarketIndex calls FetchMarketIndex on the default Client
+func FetchMarketIndex(ctx context.Context, country string) (*IndexQuote, error) {
+	return defaultClient.FetchMarketIndex(ctx, country)
+}
+
+// FetchMarketIndex returns the latest quote of a country's benchmark index
+func (c *Client) FetchMarketIndex(ctx context.Context, country string) (*IndexQuote, error) {
+	index, err := marketIndexFor(country)
+	if err != nil {
+		return nil, err
+	}
+	return c.marketProvider().Quote(ctx, index)
+}
+
+// FetchMarketIndices calls FetchMarketIndices on the default Client
+func FetchMarketIndices(ctx context.Context) (map[string]*IndexQuote, error) {
+	return defaultClient.FetchMarketIndices(ctx)
+}
+
+// FetchMarketIndices fetches every tracked index concurrently, keyed by
+// country code. Failed indices are left out and their errors joined, so the
+// error may be non-nil alongside partial results.
+func (c *Client) FetchMarketIndices(ctx context.Context) (map[string]*IndexQuote, error) {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		quotes = make(map[string]*IndexQuote, len(asiaMarketIndices))
+		errs   []error
+	)
+	provider := c.marketProvider()
+	for _, index := range asiaMarketIndices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q, err := provider.Quote(ctx, index)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", index.Country, err))
+				return
+			}
+			quotes[index.Country] = q
+		}()
+	}
+	wg.Wait()
+	return quotes, errors.Join(errs...)
+}
+
+// marketProvider returns the configured provider, or Yahoo Finance
+func (c *Client) marketProvider() MarketProvider {
+	if c.markets != nil {
+		return c.markets
+	}
+	return yahooFinance{c}
+}
+
+// yahooFinance is the default MarketProvider. It goes through the Client so
+// rate limits, retries and circuit breakers apply.
+type yahooFinance struct {
+	c *Client
+}
+
+// yahooChartResponse represents the meta block of a Yahoo Finance chart
+type yahooChartResponse struct {
+	Chart struct {
+		Result []struct {
+			Meta struct {
+				Currency           string   `json:"currency"`
+				RegularMarketPrice *float64 `json:"regularMarketPrice"`
+				RegularMarketTime  int64    `json:"regularMarketTime"`
+				PreviousClose      *float64 `json:"previousClose"`
+				ChartPreviousClose *float64 `json:"chartPreviousClose"`
+			} `json:"meta"`
+		} `json:"result"`
+	} `json:"chart"`
+}
+
+func (y yahooFinance) Quote(ctx context.Context, index MarketIndex) (*IndexQuote, error) {
+	reqURL := yahooChartEndpoint + url.PathEscape(index.Symbol) + "?range=1d&interval=1d"
+	var apiResp yahooChartResponse
+	if err := y.c.getJSON(ctx, reqURL, y.c.maxCurrentBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	if len(apiResp.Chart.Result) == 0 {
+		return nil, fmt.Errorf("%w: no chart for %s", ErrDecode, index.Symbol)
+	}
+	meta := apiResp.Chart.Result[0].Meta
+	previous := cmp.Or(meta.PreviousClose, meta.ChartPreviousClose)
+	if meta.RegularMarketPrice == nil || previous == nil {
+		return nil, fmt.Errorf("%w: no price for %s", ErrDecode, index.Symbol)
+	}
+
+	q := &IndexQuote{
+		MarketIndex: index,
+		Value:       *meta.RegularMarketPrice,
+		Change:      roundHundredth(*meta.RegularMarketPrice - *previous),
+		Currency:    meta.Currency,
+		Provenance:  y.c.provenance(sourceYahooFinance, time.Time{}),
+	}
+	if *previous != 0 {
+		q.ChangePercent = roundHundredth((*meta.RegularMarketPrice - *previous) / *previous * 100)
+	}
+	if meta.RegularMarketTime > 0 {
+		q.ObservedAt = time.Unix(meta.RegularMarketTime, 0).UTC()
+	}
+	return q, nil
+}
+
+// roundHundredth rounds index points and percentages to two decimals
+func roundHundredth(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,190 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// metNoEndpoint is the MET Norway locationforecast API
+const metNoEndpoint = "https://api.met.no/weatherapi/locationforecast/2.0/compact"
+
+// metNoMaxBytes limits MET Norway responses, which carry several days of steps
+const metNoMaxBytes = 1 << 20
+
+// ErrMissingUserAgent is returned by NewMetNoProvider without a User-Agent,
+// which MET Norway's terms of service require
+var ErrMissingUserAgent = errors.New("MET Norway requires an identifying User-Agent")
+
+// MetNoProvider is a WeatherProvider backed by MET Norway (api.met.no),
+// which covers Asia and is a useful fallback for Open-Meteo
+type MetNoProvider struct {
+	userAgent  string
+	httpClient *http.Client
+}
+
+// NewMetNoProvider creates a MET Norway provider. userAgent must identify the
+// application and a contact, e.g. "reef-asia/1.0 ops@example.com".
+func NewMetNoProvider(userAgent string) (*MetNoProvider, error) {
+	if strings.TrimSpace(userAgent) == "" {
+		return nil, ErrMissingUserAgent
+	}
+	return &MetNoProvider{
+		userAgent:  userAgent,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}, nil
+}
+
+// metNoResponse represents the first timestep of a compact locationforecast
+type metNoResponse struct {
+	Properties struct {
+		Timeseries []struct {
+			Time string `json:"time"`
+			Data struct {
+				Instant struct {
+					Details struct {
+						AirTemperature   *float64 `json:"air_temperature"`
+						RelativeHumidity *float64 `json:"relative_humidity"`
+						WindSpeed        *float64 `json:"wind_speed"`
+					} `json:"details"`
+				} `json:"instant"`
+				Next1Hours *struct {
+					Summary struct {
+						SymbolCode string `json:"symbol_code"`
+					} `json:"summary"`
+				} `json:"next_1_hours"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"properties"`
+}
+
+// Fetch fetches the current timestep at coords. MET Norway has no apparent
+// temperature, so FeelsLikeC is always computed, and wind speed is in m/s.
+func (p *MetNoProvider) Fetch(ctx context.Context, coords Coordinates) (*WeatherData, error) {
+	url := fmt.Sprintf("%s?lat=%.4f&lon=%.4f", metNoEndpoint, coords.Lat, coords.Lon)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build met.no request: %w", err)
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	if id, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set("X-Request-ID", id)
+	}
+
+	start := time.Now()
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, &ProviderError{Host: req.URL.Host, Err: err}
+	}
+	defer resp.Body.Close()
+	logger.Debugf("%sGET %s status=%d dur=%s", logPrefix(ctx), url, resp.StatusCode, time.Since(start))
+	if resp.StatusCode != http.StatusOK {
+		return nil, &ProviderError{Host: req.URL.Host, StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	body, err := readBody(resp.Body, resp.ContentLength, metNoMaxBytes+1)
+	if err != nil {
+		return nil, &ProviderError{Host: req.URL.Host, Err: err}
+	}
+	if len(body) > metNoMaxBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, metNoMaxBytes)
+	}
+	return decodeMetNo(body)
+}
+
+// decodeMetNo builds WeatherData from the first timestep of a response
+func decodeMetNo(body []byte) (*WeatherData, error) {
+	var apiResp metNoResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	if len(apiResp.Properties.Timeseries) == 0 {
+		return nil, fmt.Errorf("%w: no met.no timeseries", ErrDecode)
+	}
+	step := apiResp.Properties.Timeseries[0].Data
+	details := step.Instant.Details
+	if details.AirTemperature == nil {
+		return nil, fmt.Errorf("%w: no met.no air temperature", ErrDecode)
+	}
+
+	humidity, wind := math.NaN(), 0.0
+	if details.RelativeHumidity != nil {
+		humidity = *details.RelativeHumidity
+	}
+	if details.WindSpeed != nil {
+		wind = *details.WindSpeed
+	}
+
+	data := &WeatherData{
+		TemperatureC:      *details.AirTemperature,
+		FeelsLikeC:        apparentTemperature(*details.AirTemperature, humidity, wind*kmhPerUnit[MetresPerSecond]),
+		WindSpeed:         wind,
+		WindSpeedUnit:     MetresPerSecond,
+		FeelsLikeComputed: true,
+	}
+	// An unparseable time leaves ObservedAt zero rather than failing the fallback
+	observedAt, _ := time.Parse(time.RFC3339, apiResp.Properties.Timeseries[0].Time)
+	data.Provenance = Provenance{Source: sourceMetNo, ObservedAt: observedAt, FetchedAt: time.Now()}
+	data.setHeat(humidity)
+	code, ok, symbol := -1, false, ""
+	if step.Next1Hours != nil {
+		symbol = step.Next1Hours.Summary.SymbolCode
+		code, ok = metNoWeatherCode(symbol)
+	}
+	if !ok {
+		data.Summary = "Unknown"
+		data.MissingFields = []string{"symbol_code"}
+		data.setCondition(false, true)
+		return data, nil
+	}
+	data.WeatherCode = code
+	data.Summary = weatherCodeDescriptions[code]
+	data.setCondition(true, !strings.HasSuffix(symbol, "_night"))
+	return data, nil
+}
+
+// metNoSymbolCodes maps MET Norway symbol codes, without their _day/_night/
+// _polartwilight suffix, to the closest WMO weather code
+var metNoSymbolCodes = map[string]int{
+	"clearsky":          0,
+	"fair":              1,
+	"partlycloudy":      2,
+	"cloudy":            3,
+	"fog":               45,
+	"lightrain":         61,
+	"rain":              63,
+	"heavyrain":         65,
+	"lightsleet":        66,
+	"sleet":             67,
+	"heavysleet":        67,
+	"lightsnow":         71,
+	"snow":              73,
+	"heavysnow":         75,
+	"lightrainshowers":  80,
+	"rainshowers":       81,
+	"heavyrainshowers":  82,
+	"lightsleetshowers": 85,
+	"sleetshowers":      85,
+	"heavysleetshowers": 86,
+	"lightsnowshowers":  85,
+	"snowshowers":       86,
+	"heavysnowshowers":  86,
+}
+
+// metNoWeatherCode converts a symbol code such as "rainshowers_day"; any
+// "...andthunder" symbol is a thunderstorm
+func metNoWeatherCode(symbol string) (int, bool) {
+	symbol, _, _ = strings.Cut(symbol, "_")
+	if strings.HasSuffix(symbol, "andthunder") {
+		return 95, true
+	}
+	code, ok := metNoSymbolCodes[symbol]
+	return code, ok
+}
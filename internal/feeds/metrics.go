@@ -0,0 +1,207 @@
+package feeds
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds in seconds of the request duration histogram
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// histogram is a cumulative-bucket histogram in the Prometheus style
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative; the last is +Inf
+	sum    float64
+	total  uint64
+}
+
+func (h *histogram) observe(v float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(durationBuckets)+1)
+	}
+	i, _ := slices.BinarySearch(durationBuckets, v)
+	h.counts[i]++
+	h.sum += v
+	h.total++
+}
+
+// metrics collects the Client's fetch instrumentation. Labels are small,
+// fixed sets: upstream hosts, supported countries and HTTP statuses.
+type metrics struct {
+	mu          sync.Mutex
+	durations   map[string]*histogram // by host
+	requests    map[[2]string]uint64  // by host and status
+	retries     map[string]uint64     // by host
+	fetches     map[[2]string]uint64  // by country and result
+	hosts       map[string]hostActivity
+	cacheHits   uint64
+	cacheMisses uint64
+	coalesced   uint64
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		durations: make(map[string]*histogram),
+		requests:  make(map[[2]string]uint64),
+		retries:   make(map[string]uint64),
+		fetches:   make(map[[2]string]uint64),
+		hosts:     make(map[string]hostActivity),
+	}
+}
+
+// observeRequest records one upstream request; status is the HTTP status or "error"
+func (m *metrics) observeRequest(host, status string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h := m.durations[host]
+	if h == nil {
+		h = &histogram{}
+		m.durations[host] = h
+	}
+	h.observe(d.Seconds())
+	m.requests[[2]string{host, status}]++
+
+	now := time.Now()
+	a := m.hosts[host]
+	a.lastStatus, a.lastAt = status, now
+	if !requestFailed(status) {
+		a.lastSuccessAt = now
+	}
+	m.hosts[host] = a
+}
+
+// hostActivity is when a host was last contacted and how it answered
+type hostActivity struct {
+	lastStatus    string
+	lastAt        time.Time
+	lastSuccessAt time.Time
+}
+
+// hostActivity returns the latest activity and the request counts by
+// status of every host
+func (m *metrics) hostActivity() (map[string]hostActivity, map[string]map[string]uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[string]map[string]uint64)
+	for k, n := range m.requests {
+		if counts[k[0]] == nil {
+			counts[k[0]] = make(map[string]uint64)
+		}
+		counts[k[0]][k[1]] = n
+	}
+	return maps.Clone(m.hosts), counts
+}
+
+func (m *metrics) observeRetry(host string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[host]++
+}
+
+// observeFetch records a FetchWeather outcome for a country
+func (m *metrics) observeFetch(country string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fetches[[2]string{country, result}]++
+}
+
+func (m *metrics) observeCache(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.cacheHits++
+	} else {
+		m.cacheMisses++
+	}
+}
+
+// observeCoalesced records a fetch served by an identical one in flight
+func (m *metrics) observeCoalesced() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.coalesced++
+}
+
+// labelEscaper escapes Prometheus label values
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeText writes the metrics in the Prometheus text exposition format
+func (m *metrics) writeText(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP reef_feeds_request_duration_seconds Upstream request duration by provider host.")
+	fmt.Fprintln(w, "# TYPE reef_feeds_request_duration_seconds histogram")
+	for _, host := range slices.Sorted(maps.Keys(m.durations)) {
+		h, l := m.durations[host], labelEscaper.Replace(host)
+		var cumulative uint64
+		for i, le := range durationBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(w, "reef_feeds_request_duration_seconds_bucket{provider=\"%s\",le=\"%g\"} %d\n", l, le, cumulative)
+		}
+		fmt.Fprintf(w, "reef_feeds_request_duration_seconds_bucket{provider=\"%s\",le=\"+Inf\"} %d\n", l, h.total)
+		fmt.Fprintf(w, "reef_feeds_request_duration_seconds_sum{provider=\"%s\"} %g\n", l, h.sum)
+		fmt.Fprintf(w, "reef_feeds_request_duration_seconds_count{provider=\"%s\"} %d\n", l, h.total)
+	}
+
+	fmt.Fprintln(w, "# HELP reef_feeds_requests_total Upstream requests by provider host and HTTP status.")
+	fmt.Fprintln(w, "# TYPE reef_feeds_requests_total counter")
+	for _, k := range sortedPairs(m.requests) {
+		fmt.Fprintf(w, "reef_feeds_requests_total{provider=\"%s\",status=\"%s\"} %d\n", labelEscaper.Replace(k[0]), labelEscaper.Replace(k[1]), m.requests[k])
+	}
+
+	fmt.Fprintln(w, "# HELP reef_feeds_retries_total Upstream request retries by provider host.")
+	fmt.Fprintln(w, "# TYPE reef_feeds_retries_total counter")
+	for _, host := range slices.Sorted(maps.Keys(m.retries)) {
+		fmt.Fprintf(w, "reef_feeds_retries_total{provider=\"%s\"} %d\n", labelEscaper.Replace(host), m.retries[host])
+	}
+
+	fmt.Fprintln(w, "# HELP reef_feeds_weather_fetches_total Weather fetches by country and result.")
+	fmt.Fprintln(w, "# TYPE reef_feeds_weather_fetches_total counter")
+	for _, k := range sortedPairs(m.fetches) {
+		fmt.Fprintf(w, "reef_feeds_weather_fetches_total{country=\"%s\",result=\"%s\"} %d\n", labelEscaper.Replace(k[0]), k[1], m.fetches[k])
+	}
+
+	fmt.Fprintln(w, "# HELP reef_feeds_cache_requests_total Weather cache lookups by result.")
+	fmt.Fprintln(w, "# TYPE reef_feeds_cache_requests_total counter")
+	fmt.Fprintf(w, "reef_feeds_cache_requests_total{result=\"hit\"} %d\n", m.cacheHits)
+	fmt.Fprintf(w, "reef_feeds_cache_requests_total{result=\"miss\"} %d\n", m.cacheMisses)
+
+	fmt.Fprintln(w, "# HELP reef_feeds_coalesced_requests_total Fetches served by an identical fetch already in flight.")
+	fmt.Fprintln(w, "# TYPE reef_feeds_coalesced_requests_total counter")
+	fmt.Fprintf(w, "reef_feeds_coalesced_requests_total %d\n", m.coalesced)
+}
+
+// sortedPairs returns the keys of a two-label counter in sorted order
+func sortedPairs(m map[[2]string]uint64) [][2]string {
+	return slices.SortedFunc(maps.Keys(m), func(a, b [2]string) int {
+		return strings.Compare(a[0]+"\x00"+a[1], b[0]+"\x00"+b[1])
+	})
+}
+
+// MetricsHandler calls MetricsHandler on the default Client
+func MetricsHandler() http.Handler {
+	return defaultClient.MetricsHandler()
+}
+
+// MetricsHandler serves the Client's fetch metrics in the Prometheus text
+// format, for mounting at /metrics: request durations, statuses and retries
+// by provider host, weather fetch results by country and cache hits/misses
+func (c *Client) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.metrics.writeText(w)
+	})
+}
@@ -0,0 +1,70 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// ErrUnknownModel is returned when the configured weather model isn't one
+// Open-Meteo supports
+var ErrUnknownModel = errors.New("unknown weather model")
+
+// KnownModels lists the Open-Meteo weather models accepted by WithModel
+var KnownModels = []string{
+	"best_match",
+	"ecmwf_ifs04", "ecmwf_ifs025", "ecmwf_aifs025",
+	"cma_grapes_global",
+	"bom_access_global",
+	"gfs_seamless", "gfs_global", "gfs_graphcast025",
+	"jma_seamless", "jma_msm", "jma_gsm",
+	"kma_seamless", "kma_ldps", "kma_gdps",
+	"icon_seamless", "icon_global",
+	"gem_seamless", "gem_global",
+	"meteofrance_seamless",
+	"ukmo_seamless", "ukmo_global_deterministic_10km",
+}
+
+// WithModel requests forecasts from a specific weather model, e.g.
+// "jma_seamless" for Japan. Models not in KnownModels fail NewClient with
+// ErrInvalidConfig wrapping ErrUnknownModel.
+func WithModel(model string) Option {
+	return func(c *Client) {
+		c.model = model
+	}
+}
+
+// modelKey is the context key for a per-request weather model
+type modelKey struct{}
+
+// ContextWithModel returns a context whose forecast requests use model in
+// place of the Client's, e.g. "jma_seamless" for a Japanese city. An
+// unknown model fails those requests with ErrUnknownModel; "" keeps the
+// Client's model.
+func ContextWithModel(ctx context.Context, model string) context.Context {
+	return context.WithValue(ctx, modelKey{}, model)
+}
+
+// modelFor returns the model of a fetch: the context's when set, else the
+// Client's
+func (c *Client) modelFor(ctx context.Context) string {
+	if model, ok := ctx.Value(modelKey{}).(string); ok && model != "" {
+		return model
+	}
+	return c.model
+}
+
+// modelQuery validates the model of a fetch and returns its query
+// parameter, or "" when no model is set
+func (c *Client) modelQuery(ctx context.Context) (string, error) {
+	model := c.modelFor(ctx)
+	if model == "" {
+		return "", nil
+	}
+	if !slices.Contains(KnownModels, model) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownModel, model)
+	}
+	return "&models=" + url.QueryEscape(model), nil
+}
@@ -0,0 +1,58 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestWithModel(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   []feeds.Option
+		ctx       context.Context
+		wantModel string
+		wantErr   error
+	}{
+		{name: "default", ctx: context.Background()},
+		{name: "jma", options: []feeds.Option{feeds.WithModel("jma_seamless")}, ctx: context.Background(), wantModel: "jma_seamless"},
+		{
+			name:      "context model",
+			options:   []feeds.Option{feeds.WithModel("jma_seamless")},
+			ctx:       feeds.ContextWithModel(context.Background(), "gfs_seamless"),
+			wantModel: "gfs_seamless",
+		},
+		{name: "unknown context model", ctx: feeds.ContextWithModel(context.Background(), "bogus"), wantErr: feeds.ErrUnknownModel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			_, err := srv.Client(t, tt.options...).FetchWeather(tt.ctx, "JP")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				if n := len(srv.Requests()); n != 0 {
+					t.Errorf("%d upstream requests, want none", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := srv.Requests()[0].Query().Get("models"); got != tt.wantModel {
+				t.Errorf("models = %q, want %q", got, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestWithUnknownModel(t *testing.T) {
+	_, err := feeds.NewClient(feeds.WithModel("bogus"))
+	if !errors.Is(err, feeds.ErrInvalidConfig) || !errors.Is(err, feeds.ErrUnknownModel) {
+		t.Errorf("err = %v, want ErrInvalidConfig wrapping ErrUnknownModel", err)
+	}
+}
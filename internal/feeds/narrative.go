@@ -0,0 +1,389 @@
+package feeds
+
+import (
+	"math"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// narrativeQuery adds the hourly forecast narratives are written from to a
+// current request
+const narrativeQuery = "&hourly=temperature_2m,weather_code&forecast_hours=24"
+
+// WithNarratives writes an Outlook and Narrative for current conditions. It
+// adds a day of hourly temperatures and weather codes to every current
+// request, bulk refreshes included, making each decode about five times
+// slower with several times the allocations (see BenchmarkDecodeCurrent), so
+// it's off by default.
+func WithNarratives() Option {
+	return func(c *Client) {
+		c.narratives = true
+	}
+}
+
+// narrativeHours is the forecast_hours of narrativeQuery
+const narrativeHours = 24
+
+// minOutlookHours is the least forecast an Outlook is worth writing from
+const minOutlookHours = 3
+
+// OutlookCondition is the weather an Outlook is about
+type OutlookCondition string
+
+const (
+	OutlookClear        OutlookCondition = "clear"
+	OutlookPartlyCloudy OutlookCondition = "partly-cloudy"
+	OutlookCloudy       OutlookCondition = "cloudy"
+	OutlookFog          OutlookCondition = "fog"
+	OutlookDrizzle      OutlookCondition = "drizzle"
+	OutlookLightRain    OutlookCondition = "light-rain"
+	OutlookRain         OutlookCondition = "rain"
+	OutlookSnow         OutlookCondition = "snow"
+	OutlookHeavyRain    OutlookCondition = "heavy-rain"
+	OutlookThunderstorm OutlookCondition = "thunderstorm"
+)
+
+// wetRanks orders precipitation so a spell is named after its worst hour;
+// dry conditions aren't listed
+var wetRanks = map[OutlookCondition]int{
+	OutlookDrizzle:      1,
+	OutlookLightRain:    2,
+	OutlookRain:         3,
+	OutlookSnow:         4,
+	OutlookHeavyRain:    5,
+	OutlookThunderstorm: 6,
+}
+
+// outlookConditionFor classifies a WMO weather code, reporting false for
+// unknown codes
+func outlookConditionFor(code int) (OutlookCondition, bool) {
+	switch code {
+	case 0, 1:
+		return OutlookClear, true
+	case 2:
+		return OutlookPartlyCloudy, true
+	case 3:
+		return OutlookCloudy, true
+	case 45, 48:
+		return OutlookFog, true
+	case 51, 53, 55, 56, 57:
+		return OutlookDrizzle, true
+	case 61, 66, 80:
+		return OutlookLightRain, true
+	case 63, 81:
+		return OutlookRain, true
+	case 65, 67, 82:
+		return OutlookHeavyRain, true
+	case 71, 73, 75, 77, 85, 86:
+		return OutlookSnow, true
+	case 95, 96, 99:
+		return OutlookThunderstorm, true
+	default:
+		return "", false
+	}
+}
+
+// Outlook is the next day of hourly forecast boiled down to what a
+// Narrative says. It's kept on WeatherData, so a cached result can be
+// narrated in each caller's locale.
+type Outlook struct {
+	// From is the start of the first forecast hour, in the location's time
+	// zone; narratives say "this afternoon" and "tomorrow" relative to it
+	From time.Time `json:"from"`
+	// Condition is the first spell of precipitation when there is one, and
+	// otherwise the most common sky
+	Condition OutlookCondition `json:"condition"`
+	// StartsAt is when the precipitation begins, zero when it already has;
+	// EndsAt is when it stops, zero when that's beyond the outlook
+	StartsAt time.Time `json:"startsAt,omitzero"`
+	EndsAt   time.Time `json:"endsAt,omitzero"`
+	// HighC is the rest of today's high, given before 3pm; after that LowC
+	// is the low of the next 12 hours instead
+	HighC *float64 `json:"highC,omitempty"`
+	LowC  *float64 `json:"lowC,omitempty"`
+}
+
+// outlookHour is one hour an Outlook is written from
+type outlookHour struct {
+	start        time.Time
+	condition    OutlookCondition
+	temperatureC *float64
+}
+
+// outlookFor sums up hourly forecasts from the hour containing now on,
+// reporting false when too few hours have a known weather code
+func outlookFor(hours []outlookHour, now time.Time) (*Outlook, bool) {
+	var ahead []outlookHour
+	for _, h := range hours {
+		if h.start.Add(time.Hour).After(now) && h.condition != "" {
+			ahead = append(ahead, h)
+		}
+	}
+	if len(ahead) < minOutlookHours {
+		return nil, false
+	}
+	o := &Outlook{From: ahead[0].start}
+
+	first := -1
+	for i, h := range ahead {
+		if wetRanks[h.condition] > 0 {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		o.Condition = mostCommonCondition(ahead)
+	} else {
+		end := first
+		for end < len(ahead) && wetRanks[ahead[end].condition] > 0 {
+			if wetRanks[ahead[end].condition] > wetRanks[o.Condition] {
+				o.Condition = ahead[end].condition
+			}
+			end++
+		}
+		if first > 0 {
+			o.StartsAt = ahead[first].start
+		}
+		if end < len(ahead) {
+			o.EndsAt = ahead[end].start
+		}
+	}
+
+	if o.From.Hour() < 15 {
+		y, m, d := o.From.Date()
+		for _, h := range ahead {
+			if hy, hm, hd := h.start.Date(); hy == y && hm == m && hd == d && h.temperatureC != nil {
+				o.HighC = extreme(o.HighC, *h.temperatureC, math.Max)
+			}
+		}
+	} else {
+		for _, h := range ahead {
+			if h.start.Before(o.From.Add(12*time.Hour)) && h.temperatureC != nil {
+				o.LowC = extreme(o.LowC, *h.temperatureC, math.Min)
+			}
+		}
+	}
+	return o, true
+}
+
+// mostCommonCondition returns the condition of the most hours, the earliest
+// on ties
+func mostCommonCondition(hours []outlookHour) OutlookCondition {
+	counts := make(map[OutlookCondition]int)
+	var best OutlookCondition
+	for _, h := range hours {
+		counts[h.condition]++
+		if counts[h.condition] > counts[best] {
+			best = h.condition
+		}
+	}
+	return best
+}
+
+// extreme folds v into the running extreme cur with pick, math.Max or math.Min
+func extreme(cur *float64, v float64, pick func(a, b float64) float64) *float64 {
+	if cur != nil {
+		v = pick(*cur, v)
+	}
+	return &v
+}
+
+// dayPart is a part of a day narratives name
+type dayPart int
+
+const (
+	morning   dayPart = iota // 5am to noon
+	afternoon                // noon to 5pm
+	evening                  // 5pm to 9pm
+	night                    // 9pm to 5am, belonging to the evening before
+)
+
+// dayPhrase names when something happens relative to an Outlook's From
+type dayPhrase struct {
+	tomorrow bool
+	part     dayPart
+}
+
+// phraseFor returns when t falls relative to from
+func phraseFor(t, from time.Time) dayPhrase {
+	h := t.Hour()
+	var part dayPart
+	switch {
+	case h >= 5 && h < 12:
+		part = morning
+	case h >= 12 && h < 17:
+		part = afternoon
+	case h >= 17 && h < 21:
+		part = evening
+	default:
+		part = night
+	}
+	day := t
+	if part == night && h < 5 {
+		day = t.AddDate(0, 0, -1)
+	}
+	return dayPhrase{tomorrow: civilDays(from, day) > 0, part: part}
+}
+
+// civilDays counts calendar days from a to b by their own dates
+func civilDays(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	da := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	db := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da) / (24 * time.Hour))
+}
+
+// narrativeLocale is everything needed to narrate an Outlook in one
+// language. Sentences are the templates "until" (precipitation now that
+// stops), "throughout" (now and all day), "passing" (later, starting and
+// stopping within the same part of the day), "clearing" (later, then
+// stopping), "starting" (later, lasting), "dry", "high" and "low", executed
+// with narrativeFields.
+type narrativeLocale struct {
+	conditions map[OutlookCondition]string
+	// starts names when a spell starts, as in "this afternoon"; ends names
+	// when one stops, as in "clearing by evening"
+	starts    map[dayPhrase]string
+	ends      map[dayPhrase]string
+	sentences *template.Template
+}
+
+// narrativeFields are the values narrative templates are executed with
+type narrativeFields struct {
+	Condition  string
+	Start, End string
+	Temp       int
+}
+
+// Narrative renders the outlook as a sentence, such as "Light rain this
+// afternoon, clearing by evening; highs near 31°C", in locale or in English
+// when it has no narrative templates
+func (o *Outlook) Narrative(locale string) string {
+	tag, _ := matchLocale(locale)
+	l, ok := narrativeLocales[tag]
+	if !ok {
+		l = narrativeLocales["en"]
+	}
+
+	fields := narrativeFields{Condition: l.conditions[o.Condition]}
+	var sentence string
+	switch {
+	case wetRanks[o.Condition] == 0:
+		sentence = "dry"
+	case o.StartsAt.IsZero() && o.EndsAt.IsZero():
+		sentence = "throughout"
+	case o.StartsAt.IsZero():
+		sentence, fields.End = "until", l.starts[phraseFor(o.EndsAt, o.From)]
+	case o.EndsAt.IsZero():
+		sentence, fields.Start = "starting", l.starts[phraseFor(o.StartsAt, o.From)]
+	default:
+		start, end := phraseFor(o.StartsAt, o.From), phraseFor(o.EndsAt, o.From)
+		sentence, fields.Start, fields.End = "clearing", l.starts[start], l.ends[end]
+		if start == end {
+			sentence = "passing"
+		}
+	}
+
+	var b strings.Builder
+	if err := l.sentences.ExecuteTemplate(&b, sentence, fields); err != nil {
+		return ""
+	}
+	temp, name := o.HighC, "high"
+	if temp == nil {
+		temp, name = o.LowC, "low"
+	}
+	if temp != nil {
+		fields.Temp = int(math.Round(*temp))
+		if err := l.sentences.ExecuteTemplate(&b, name, fields); err != nil {
+			return ""
+		}
+	}
+	return b.String()
+}
+
+// narrativeLocales holds the narrative templates by summaryTranslations key
+var narrativeLocales = map[string]narrativeLocale{
+	"en": {
+		conditions: map[OutlookCondition]string{
+			OutlookClear:        "Clear",
+			OutlookPartlyCloudy: "Partly cloudy",
+			OutlookCloudy:       "Cloudy",
+			OutlookFog:          "Foggy",
+			OutlookDrizzle:      "Drizzle",
+			OutlookLightRain:    "Light rain",
+			OutlookRain:         "Rain",
+			OutlookSnow:         "Snow",
+			OutlookHeavyRain:    "Heavy rain",
+			OutlookThunderstorm: "Thunderstorms",
+		},
+		starts: map[dayPhrase]string{
+			{false, morning}:   "this morning",
+			{false, afternoon}: "this afternoon",
+			{false, evening}:   "this evening",
+			{false, night}:     "tonight",
+			{true, morning}:    "tomorrow morning",
+			{true, afternoon}:  "tomorrow afternoon",
+			{true, evening}:    "tomorrow evening",
+			{true, night}:      "tomorrow night",
+		},
+		ends: map[dayPhrase]string{
+			{false, morning}:   "morning",
+			{false, afternoon}: "afternoon",
+			{false, evening}:   "evening",
+			{false, night}:     "tonight",
+			{true, morning}:    "tomorrow morning",
+			{true, afternoon}:  "tomorrow afternoon",
+			{true, evening}:    "tomorrow evening",
+			{true, night}:      "tomorrow night",
+		},
+		sentences: template.Must(template.New("en").Parse(
+			`{{define "until"}}{{.Condition}} until {{.End}}{{end}}` +
+				`{{define "throughout"}}{{.Condition}} throughout the day{{end}}` +
+				`{{define "passing"}}{{.Condition}} {{.Start}}{{end}}` +
+				`{{define "clearing"}}{{.Condition}} {{.Start}}, clearing by {{.End}}{{end}}` +
+				`{{define "starting"}}{{.Condition}} starting {{.Start}}{{end}}` +
+				`{{define "dry"}}{{.Condition}} throughout the day{{end}}` +
+				`{{define "high"}}; highs near {{.Temp}}°C{{end}}` +
+				`{{define "low"}}; lows near {{.Temp}}°C{{end}}`)),
+	},
+	"ja": {
+		conditions: map[OutlookCondition]string{
+			OutlookClear:        "晴れ",
+			OutlookPartlyCloudy: "晴れ時々曇り",
+			OutlookCloudy:       "曇り",
+			OutlookFog:          "霧",
+			OutlookDrizzle:      "霧雨",
+			OutlookLightRain:    "小雨",
+			OutlookRain:         "雨",
+			OutlookSnow:         "雪",
+			OutlookHeavyRain:    "大雨",
+			OutlookThunderstorm: "雷雨",
+		},
+		starts: japaneseDayPhrases,
+		ends:   japaneseDayPhrases,
+		sentences: template.Must(template.New("ja").Parse(
+			`{{define "until"}}{{.End}}まで{{.Condition}}{{end}}` +
+				`{{define "throughout"}}一日中{{.Condition}}{{end}}` +
+				`{{define "passing"}}{{.Start}}に一時{{.Condition}}{{end}}` +
+				`{{define "clearing"}}{{.Start}}に{{.Condition}}、{{.End}}までにやむ見込み{{end}}` +
+				`{{define "starting"}}{{.Start}}から{{.Condition}}{{end}}` +
+				`{{define "dry"}}一日中{{.Condition}}{{end}}` +
+				`{{define "high"}}。最高気温は{{.Temp}}°C前後{{end}}` +
+				`{{define "low"}}。最低気温は{{.Temp}}°C前後{{end}}`)),
+	},
+}
+
+// japaneseDayPhrases serve for both starts and ends, as Japanese marks the
+// difference with particles in the templates
+var japaneseDayPhrases = map[dayPhrase]string{
+	{false, morning}:   "今朝",
+	{false, afternoon}: "今日の午後",
+	{false, evening}:   "今日の夕方",
+	{false, night}:     "今夜",
+	{true, morning}:    "明日の朝",
+	{true, afternoon}:  "明日の午後",
+	{true, evening}:    "明日の夕方",
+	{true, night}:      "明日の夜",
+}
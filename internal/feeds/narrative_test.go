@@ -0,0 +1,35 @@
+package feeds_test
+
+import (
+	"context"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestNarratives(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []feeds.Option
+		want    bool
+	}{
+		{name: "off by default"},
+		{name: "on", options: []feeds.Option{feeds.WithNarratives()}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			data, err := srv.Client(t, tt.options...).FetchWeather(context.Background(), "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := srv.Requests()[0].Query().Has("hourly"); got != tt.want {
+				t.Errorf("hourly requested = %v, want %v", got, tt.want)
+			}
+			if got := data.Narrative != "" && data.Outlook != nil; got != tt.want {
+				t.Errorf("Narrative = %q, Outlook = %v, want them set: %v", data.Narrative, data.Outlook, tt.want)
+			}
+		})
+	}
+}
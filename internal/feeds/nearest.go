@@ -0,0 +1,200 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// maxLabelDistanceKm is how far from a known city coordinates may be and
+// still be labeled with that city
+const maxLabelDistanceKm = 500
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances
+const earthRadiusKm = 6371.0
+
+// Representative city names for the coordinates in asiaCountryCoordinates
+var asiaCountryCities = map[string]string{
+	"JP": "Tokyo",
+	"CN": "Beijing",
+	"IN": "New Delhi",
+	"SG": "Singapore",
+	"HK": "Hong Kong",
+	"KR": "Seoul",
+	"TH": "Bangkok",
+	"ID": "Jakarta",
+	"MY": "Kuala Lumpur",
+	"PH": "Manila",
+	"VN": "Hanoi",
+	"TW": "Taipei",
+	"BD": "Dhaka",
+	"PK": "Islamabad",
+	"LK": "Colombo",
+	"NP": "Kathmandu",
+	"BT": "Thimphu",
+	"MV": "Malé",
+	"MM": "Naypyidaw",
+	"KH": "Phnom Penh",
+	"LA": "Vientiane",
+	"BN": "Bandar Seri Begawan",
+	"TL": "Dili",
+	"MN": "Ulaanbaatar",
+	"KZ": "Astana",
+	"UZ": "Tashkent",
+	"KG": "Bishkek",
+	"TJ": "Dushanbe",
+	"TM": "Ashgabat",
+	"AF": "Kabul",
+	"IR": "Tehran",
+	"IQ": "Baghdad",
+	"AE": "Abu Dhabi",
+	"SA": "Riyadh",
+	"QA": "Doha",
+	"KW": "Kuwait City",
+	"BH": "Manama",
+	"OM": "Muscat",
+	"JO": "Amman",
+	"LB": "Beirut",
+	"MO": "Macau",
+}
+
+// City names for the coordinates in asiaLargestCityCoordinates
+var asiaLargestCities = map[string]string{
+	"CN": "Shanghai",
+	"IN": "Mumbai",
+	"PH": "Quezon City",
+	"VN": "Ho Chi Minh City",
+	"PK": "Karachi",
+	"MM": "Yangon",
+	"KZ": "Almaty",
+	"AE": "Dubai",
+}
+
+// distanceKm returns the great-circle (haversine) distance between a and b
+func distanceKm(a, b Coordinates) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(b.Lat - a.Lat)
+	dLon := toRad(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Lat))*math.Cos(toRad(b.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// NearestCountry returns the supported country whose representative city is
+// closest to lat/lon, with the distance in kilometres
+func NearestCountry(lat, lon float64) (string, float64, error) {
+	target, err := NewCoordinates(lat, lon)
+	if err != nil {
+		return "", 0, err
+	}
+
+	best, bestDist := "", math.Inf(1)
+	for country, coords := range asiaCountryCoordinates {
+		d := distanceKm(target, coords)
+		// Break ties by code so the result is deterministic
+		if d < bestDist || (d == bestDist && country < best) {
+			best, bestDist = country, d
+		}
+	}
+	return best, bestDist, nil
+}
+
+// geohashAlphabet is the base32 alphabet of geohashes
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// nearestGeohashPrecision is the length of NearestCity.Geohash, cells of
+// about 150 m
+const nearestGeohashPrecision = 7
+
+// NearestCity is the gazetteer city closest to a location
+type NearestCity struct {
+	Country string  `json:"country"`
+	City    string  `json:"city"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	// DistanceKm is the great-circle distance from the location to the city
+	DistanceKm float64 `json:"distanceKm"`
+	// Geohash is the location's geohash, a coarse key for caching results of
+	// nearby positions
+	Geohash string `json:"geohash"`
+}
+
+// ResolveNearest returns the city of the bundled gazetteer closest to
+// lat/lon, across every supported country, for callers with a position from
+// GPS rather than a country. It fails with *ErrInvalidCoordinates for
+// out-of-range coordinates.
+func ResolveNearest(lat, lon float64) (*NearestCity, error) {
+	target, err := NewCoordinates(lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *NearestCity
+	for country, entries := range asiaCityGazetteer {
+		for _, e := range entries {
+			d := distanceKm(target, e.coords)
+			// Break ties by country and city so the result is deterministic
+			if best == nil || d < best.DistanceKm ||
+				(d == best.DistanceKm && (country < best.Country || (country == best.Country && e.name < best.City))) {
+				best = &NearestCity{Country: country, City: e.name, Lat: e.coords.Lat, Lon: e.coords.Lon, DistanceKm: d}
+			}
+		}
+	}
+	best.Geohash = geohash(target, nearestGeohashPrecision)
+	return best, nil
+}
+
+// geohash encodes coords as a geohash of precision characters
+func geohash(coords Coordinates, precision int) string {
+	latRange, lonRange := [2]float64{-90, 90}, [2]float64{-180, 180}
+	hash := make([]byte, 0, precision)
+	even, bit, ch := true, 0, 0
+	for len(hash) < precision {
+		// Bits alternate between longitude and latitude, longitude first
+		rng, v := &latRange, coords.Lat
+		if even {
+			rng, v = &lonRange, coords.Lon
+		}
+		mid := (rng[0] + rng[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		even = !even
+		if bit++; bit == 5 {
+			hash = append(hash, geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return string(hash)
+}
+
+// FetchWeatherByCoordsLabeled calls FetchWeatherByCoordsLabeled on the default Client
+func FetchWeatherByCoordsLabeled(ctx context.Context, lat, lon float64) (*WeatherData, string, error) {
+	return defaultClient.FetchWeatherByCoordsLabeled(ctx, lat, lon)
+}
+
+// FetchWeatherByCoordsLabeled fetches weather at lat/lon and returns a display
+// label naming the nearest gazetteer city, e.g. "Osaka, JP". Coordinates
+// further than 500 km from every known city are labeled with the coordinates
+// themselves.
+func (c *Client) FetchWeatherByCoordsLabeled(ctx context.Context, lat, lon float64) (*WeatherData, string, error) {
+	nearest, err := ResolveNearest(lat, lon)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := c.fetchCurrent(ctx, Coordinates{Lat: lat, Lon: lon})
+	if err != nil {
+		return nil, "", err
+	}
+
+	label := fmt.Sprintf("%.4f, %.4f", lat, lon)
+	if nearest.DistanceKm <= maxLabelDistanceKm {
+		label = fmt.Sprintf("%s, %s", nearest.City, nearest.Country)
+	}
+	return data, label, nil
+}
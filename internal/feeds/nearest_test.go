@@ -0,0 +1,46 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestFetchWeatherByCoordsLabeled(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		wantLabel string
+		wantErr   bool
+	}{
+		// The gazetteer has Osaka, so it's nearer than Tokyo
+		{name: "near Osaka", lat: 34.70, lon: 135.52, wantLabel: "Osaka, JP"},
+		{name: "mid Pacific", lat: 0, lon: -150, wantLabel: "0.0000, -150.0000"},
+		{name: "invalid", lat: 95, lon: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			data, label, err := srv.Client(t).FetchWeatherByCoordsLabeled(context.Background(), tt.lat, tt.lon)
+			if tt.wantErr {
+				var invalid *feeds.ErrInvalidCoordinates
+				if !errors.As(err, &invalid) {
+					t.Errorf("err = %v, want *ErrInvalidCoordinates", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if label != tt.wantLabel {
+				t.Errorf("label = %q, want %q", label, tt.wantLabel)
+			}
+			if data.TemperatureC != 9.4 {
+				t.Errorf("TemperatureC = %v, want the fixture's 9.4", data.TemperatureC)
+			}
+		})
+	}
+}
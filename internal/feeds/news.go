@@ -0,0 +1,350 @@
+package feeds
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// newsAPIEndpoint is the NewsAPI top headlines API
+const newsAPIEndpoint = "https://newsapi.org/v2/top-headlines"
+
+// maxHeadlines caps how many headlines FetchHeadlines returns
+const maxHeadlines = 30
+
+// ErrNoNewsSources is returned for countries without any configured news source
+var ErrNoNewsSources = errors.New("no news sources for country")
+
+// Headline is one news story, normalized across RSS, Atom and NewsAPI
+type Headline struct {
+	Title  string `json:"title"`
+	Source string `json:"source"`
+	URL    string `json:"url"`
+	// Published is zero when the source doesn't date its items
+	Published time.Time `json:"published,omitzero"`
+	// Language is a BCP 47 tag such as "ja" or "en-SG"
+	Language string `json:"language,omitempty"`
+}
+
+// NewsSource is an RSS or Atom feed of headlines
+type NewsSource struct {
+	Name string
+	URL  string
+	// Language is used for items when the feed doesn't declare one
+	Language string
+}
+
+// WithNewsSources replaces the built-in news sources of a country
+func WithNewsSources(country string, sources ...NewsSource) Option {
+	return func(c *Client) {
+		c.newsSources[normalizeCountry(country)] = slices.Clone(sources)
+	}
+}
+
+// WithNewsAPIKey adds NewsAPI top headlines to every country NewsAPI covers.
+// The key is sent in a header, so it doesn't appear in logs or recordings.
+func WithNewsAPIKey(key string) Option {
+	return func(c *Client) {
+		c.newsAPIKey = key
+	}
+}
+
+// googleNewsEditions are the Google News editions used as built-in sources,
+// by country and language
+var googleNewsEditions = map[string]string{
+	"JP": "ja",
+	"CN": "zh-Hans",
+	"IN": "en",
+	"SG": "en",
+	"HK": "zh-Hant",
+	"KR": "ko",
+	"TH": "th",
+	"ID": "id",
+	"MY": "en",
+	"PH": "en",
+	"VN": "vi",
+	"TW": "zh-Hant",
+	"BD": "bn",
+	"PK": "en",
+	"AE": "ar",
+	"SA": "ar",
+	"LB": "ar",
+}
+
+// newsAPICountries are the supported countries NewsAPI has top headlines for
+var newsAPICountries = []string{"AE", "CN", "HK", "ID", "IN", "JP", "KR", "MY", "PH", "SA", "SG", "TH", "TW"}
+
+// googleNewsSource returns the Google News top stories of a country's edition
+func googleNewsSource(country, lang string) NewsSource {
+	q := url.Values{}
+	q.Set("hl", lang)
+	q.Set("gl", country)
+	q.Set("ceid", country+":"+lang)
+	return NewsSource{Name: "Google News", URL: "https://news.google.com/rss?" + q.Encode(), Language: lang}
+}
+
+// newsSourcesFor returns the configured or built-in sources of a country
+func (c *Client) newsSourcesFor(country string) []NewsSource {
+	if sources, ok := c.newsSources[country]; ok {
+		return sources
+	}
+	if lang, ok := googleNewsEditions[country]; ok {
+		return []NewsSource{googleNewsSource(country, lang)}
+	}
+	return nil
+}
+
+// newsSourcesError reports WithNewsSources entries for unsupported countries
+// or without an absolute URL
+func (c *Client) newsSourcesError() error {
+	var errs []error
+	for country, sources := range c.newsSources {
+		if !IsSupportedCountry(country) {
+			errs = append(errs, fmt.Errorf("%w: news sources for %q", ErrUnsupportedCountry, country))
+		}
+		for _, src := range sources {
+			if u, err := url.Parse(src.URL); err != nil || !u.IsAbs() {
+				errs = append(errs, fmt.Errorf("news source %q for %s needs an absolute URL, got %q", src.Name, country, src.URL))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newsDocument is an RSS 2.0 or Atom document; which one is told by XMLName
+type newsDocument struct {
+	XMLName xml.Name
+	// Lang is the Atom feed's xml:lang
+	Lang    string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Title   string `xml:"title"`
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+		Lang      string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	} `xml:"entry"`
+	Channel struct {
+		Title    string `xml:"title"`
+		Language string `xml:"language"`
+		Items    []struct {
+			Title   string `xml:"title"`
+			Link    string `xml:"link"`
+			PubDate string `xml:"pubDate"`
+			// Source names the original publisher in aggregated feeds
+			Source string `xml:"source"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// newsAPIResponse represents a NewsAPI top headlines response
+type newsAPIResponse struct {
+	Articles []struct {
+		Source struct {
+			Name string `json:"name"`
+		} `json:"source"`
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		PublishedAt string `json:"publishedAt"`
+	} `json:"articles"`
+}
+
+// rssTimeLayouts are the pubDate formats seen in the wild, RFC 822 first
+var rssTimeLayouts = []string{time.RFC1123Z, time.RFC1123, "Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST", time.RFC3339}
+
+// FetchHeadlines calls FetchHeadlines on the default Client
+func FetchHeadlines(ctx context.Context, country string) ([]Headline, error) {
+	return defaultClient.FetchHeadlines(ctx, country)
+}
+
+// FetchHeadlines returns a country's top headlines from all its sources,
+// newest first and without duplicate URLs. Failed sources are logged and
+// skipped; only when all of them fail is an error returned.
+func (c *Client) FetchHeadlines(ctx context.Context, country string) ([]Headline, error) {
+	code := normalizeCountry(country)
+	if !IsSupportedCountry(code) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+
+	var fetchers []func(context.Context) ([]Headline, error)
+	for _, src := range c.newsSourcesFor(code) {
+		fetchers = append(fetchers, func(ctx context.Context) ([]Headline, error) {
+			return c.fetchNewsSource(ctx, src)
+		})
+	}
+	if c.newsAPIKey != "" && slices.Contains(newsAPICountries, code) {
+		fetchers = append(fetchers, func(ctx context.Context) ([]Headline, error) {
+			return c.fetchNewsAPI(ctx, code)
+		})
+	}
+	if len(fetchers) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoNewsSources, code)
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		headlines []Headline
+		errs      []error
+	)
+	for _, fetch := range fetchers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h, err := fetch(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			headlines = append(headlines, h...)
+		}()
+	}
+	wg.Wait()
+	if len(errs) == len(fetchers) {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		logger.Warnf("%snews source for %s failed: %v", logPrefix(ctx), code, err)
+	}
+	return mergeHeadlines(headlines), nil
+}
+
+// mergeHeadlines sorts headlines newest first, undated last, dropping repeated
+// URLs and everything past maxHeadlines
+func mergeHeadlines(headlines []Headline) []Headline {
+	slices.SortStableFunc(headlines, func(a, b Headline) int {
+		if a.Published.IsZero() != b.Published.IsZero() {
+			if a.Published.IsZero() {
+				return 1
+			}
+			return -1
+		}
+		return b.Published.Compare(a.Published)
+	})
+	seen := make(map[string]bool, len(headlines))
+	merged := headlines[:0]
+	for _, h := range headlines {
+		if seen[h.URL] {
+			continue
+		}
+		seen[h.URL] = true
+		merged = append(merged, h)
+	}
+	return merged[:min(len(merged), maxHeadlines)]
+}
+
+// fetchNewsSource fetches and parses one RSS or Atom source
+func (c *Client) fetchNewsSource(ctx context.Context, src NewsSource) ([]Headline, error) {
+	if err := c.injectFault(); err != nil {
+		return nil, err
+	}
+	body, err := c.getSharedBody(ctx, src.URL, c.maxForecastBytes)
+	if err != nil {
+		return nil, err
+	}
+	var doc newsDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrDecode, src.Name, err)
+	}
+	return decodeNews(&doc, src)
+}
+
+// decodeNews normalizes the items of an RSS or Atom document
+func decodeNews(doc *newsDocument, src NewsSource) ([]Headline, error) {
+	var headlines []Headline
+	switch doc.XMLName.Local {
+	case "rss":
+		lang := cmp.Or(doc.Channel.Language, src.Language)
+		for _, item := range doc.Channel.Items {
+			publisher := strings.TrimSpace(item.Source)
+			headlines = append(headlines, Headline{
+				// Aggregators append the publisher to the title as well
+				Title:     strings.TrimSuffix(strings.TrimSpace(item.Title), " - "+publisher),
+				Source:    cmp.Or(publisher, src.Name, doc.Channel.Title),
+				URL:       strings.TrimSpace(item.Link),
+				Published: parseNewsTime(item.PubDate, rssTimeLayouts...),
+				Language:  lang,
+			})
+		}
+	case "feed":
+		for _, entry := range doc.Entries {
+			h := Headline{
+				Title:     strings.TrimSpace(entry.Title),
+				Source:    cmp.Or(src.Name, doc.Title),
+				Published: parseNewsTime(cmp.Or(entry.Published, entry.Updated), time.RFC3339),
+				Language:  cmp.Or(entry.Lang, doc.Lang, src.Language),
+			}
+			for _, link := range entry.Links {
+				if link.Rel == "" || link.Rel == "alternate" {
+					h.URL = link.Href
+					break
+				}
+			}
+			headlines = append(headlines, h)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s: not RSS or Atom but <%s>", ErrDecode, src.Name, doc.XMLName.Local)
+	}
+	return slices.DeleteFunc(headlines, func(h Headline) bool { return h.Title == "" || h.URL == "" }), nil
+}
+
+// fetchNewsAPI fetches NewsAPI top headlines for a country
+func (c *Client) fetchNewsAPI(ctx context.Context, country string) ([]Headline, error) {
+	q := url.Values{}
+	q.Set("country", strings.ToLower(country))
+	var apiResp newsAPIResponse
+	if err := c.getJSON(ctx, newsAPIEndpoint+"?"+q.Encode(), c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+
+	headlines := make([]Headline, 0, len(apiResp.Articles))
+	for _, a := range apiResp.Articles {
+		if a.Title == "" || a.URL == "" {
+			continue
+		}
+		headlines = append(headlines, Headline{
+			Title:     a.Title,
+			Source:    cmp.Or(a.Source.Name, "NewsAPI"),
+			URL:       a.URL,
+			Published: parseNewsTime(a.PublishedAt, time.RFC3339),
+			// NewsAPI doesn't say; its top headlines are in the edition's main language
+			Language: googleNewsEditions[country],
+		})
+	}
+	return headlines, nil
+}
+
+// parseNewsTime parses s with the first matching layout, leaving
+// unparseable dates zero rather than dropping the item
+func parseNewsTime(s string, layouts ...string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// authorize adds credentials to requests for providers that need them
+func (c *Client) authorize(req *http.Request) {
+	if c.newsAPIKey != "" && req.URL.Host == "newsapi.org" {
+		req.Header.Set("X-Api-Key", c.newsAPIKey)
+	}
+	c.authorizeOpenMeteo(req)
+}
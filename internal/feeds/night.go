@@ -0,0 +1,48 @@
+package feeds
+
+import (
+	"maps"
+	"time"
+)
+
+// nightQuery adds what's needed to tell day from night to a current request,
+// which already asks for local times
+const nightQuery = "&daily=sunrise,sunset&forecast_days=1"
+
+// WithNightDescriptions sets Summary wording used between sunset and sunrise
+// for the given weather codes, e.g. {0: "Clear night"}. Codes not in the map
+// keep their usual description. The exact sunset moment counts as night and
+// the exact sunrise moment as day.
+func WithNightDescriptions(descriptions map[int]string) Option {
+	return func(c *Client) {
+		c.nightDescriptions = maps.Clone(descriptions)
+	}
+}
+
+// nightDescription returns the night wording for the observation when it
+// falls outside daylight and the code has night wording
+func (c *Client) nightDescription(apiResp *OpenMeteoResponse) (string, bool) {
+	description, ok := c.nightDescriptions[apiResp.Current.WeatherCode]
+	if !ok || len(apiResp.Daily.Sunrise) == 0 || len(apiResp.Daily.Sunset) == 0 {
+		return "", false
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+
+	observed, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Current.Time, loc)
+	if err != nil {
+		return "", false
+	}
+	sunrise, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Daily.Sunrise[0], loc)
+	if err != nil {
+		return "", false
+	}
+	sunset, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Daily.Sunset[0], loc)
+	if err != nil {
+		return "", false
+	}
+
+	if observed.Before(sunrise) || !observed.Before(sunset) {
+		return description, true
+	}
+	return "", false
+}
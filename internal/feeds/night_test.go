@@ -0,0 +1,45 @@
+package feeds_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestNightDescriptions(t *testing.T) {
+	night := feeds.WithNightDescriptions(map[int]string{0: "Clear night"})
+	tests := []struct {
+		name     string
+		observed string
+		options  []feeds.Option
+		want     string
+	}{
+		{name: "just after sunset", observed: "17:46", options: []feeds.Option{night}, want: "Clear night"},
+		{name: "at sunset", observed: "17:45", options: []feeds.Option{night}, want: "Clear night"},
+		{name: "just before sunset", observed: "17:44", options: []feeds.Option{night}, want: "Clear sky"},
+		{name: "at sunrise", observed: "06:50", options: []feeds.Option{night}, want: "Clear sky"},
+		{name: "before sunrise", observed: "06:49", options: []feeds.Option{night}, want: "Clear night"},
+		{name: "no night map", observed: "17:46", want: "Clear sky"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, []byte(fmt.Sprintf(
+				`{"timezone":"Asia/Tokyo","utc_offset_seconds":32400,`+
+					`"current":{"time":"2025-01-15T%s","temperature_2m":6,"apparent_temperature":4,"weather_code":0},`+
+					`"daily":{"sunrise":["2025-01-15T06:50"],"sunset":["2025-01-15T17:45"]}}`, tt.observed)))
+
+			data, err := srv.Client(t, tt.options...).FetchWeather(context.Background(), "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.Summary != tt.want {
+				t.Errorf("Summary at %s = %q, want %q", tt.observed, data.Summary, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultNowcastWindow is the nowcast window when none is given
+const DefaultNowcastWindow = 2 * time.Hour
+
+// maxNowcastWindow bounds nowcast windows to the range Open-Meteo's
+// 15-minutely forecast is worth relying on
+const maxNowcastWindow = 12 * time.Hour
+
+// nowcastSlot is the length of Open-Meteo's minutely_15 steps
+const nowcastSlot = 15 * time.Minute
+
+// rainThresholdMm is the least precipitation in one 15-minute step that
+// counts as rain, below which drops don't wet the ground
+const rainThresholdMm = 0.1
+
+// ErrInvalidNowcastWindow is returned for nowcast windows outside 15 minutes
+// to 12 hours
+var ErrInvalidNowcastWindow = errors.New("nowcast window must be between 15m and 12h")
+
+// RainIntensity is the American Meteorological Society category of a rain
+// rate
+type RainIntensity string
+
+const (
+	RainNone     RainIntensity = "none"
+	RainLight    RainIntensity = "light"
+	RainModerate RainIntensity = "moderate"
+	RainHeavy    RainIntensity = "heavy"
+	RainViolent  RainIntensity = "violent"
+)
+
+// rainIntensityFor categorizes a rain rate in mm per hour
+func rainIntensityFor(mmPerHour float64) RainIntensity {
+	switch {
+	case mmPerHour <= 0:
+		return RainNone
+	case mmPerHour < 2.5:
+		return RainLight
+	case mmPerHour < 7.6:
+		return RainModerate
+	case mmPerHour < 50:
+		return RainHeavy
+	default:
+		return RainViolent
+	}
+}
+
+// Nowcast answers whether it will rain soon, compactly enough for a push
+// notification
+type Nowcast struct {
+	// RainExpected is true when any 15 minutes of the window bring at least
+	// 0.1 mm; StartsAt and MinutesUntil then tell when the first of them
+	// begins, zero when it's raining already
+	RainExpected bool      `json:"rainExpected"`
+	StartsAt     time.Time `json:"startsAt,omitzero"`
+	MinutesUntil int       `json:"minutesUntil"`
+	// Intensity categorizes PeakMmPerHour, the heaviest 15 minutes of the
+	// window as an hourly rate, and is "none" without rain expected; TotalMm
+	// is the window's sum
+	Intensity     RainIntensity `json:"intensity"`
+	PeakMmPerHour float64       `json:"peakMmPerHour"`
+	TotalMm       float64       `json:"totalMm"`
+	// ProbabilityPercent is the highest hourly precipitation probability
+	// over the window
+	ProbabilityPercent float64 `json:"probabilityPercent"`
+	// Message is a one-line English summary, e.g. "Light rain in 40 minutes"
+	Message       string `json:"message"`
+	WindowMinutes int    `json:"windowMinutes"`
+
+	// Approximate and City mean the same as on WeatherData
+	Approximate bool   `json:"approximate,omitempty"`
+	City        string `json:"city,omitempty"`
+
+	Provenance
+}
+
+// nowcastResponse represents an Open-Meteo forecast response with
+// 15-minutely precipitation and hourly probabilities
+type nowcastResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Minutely15           struct {
+		Time          []string   `json:"time"`
+		Precipitation []*float64 `json:"precipitation"`
+	} `json:"minutely_15"`
+	Hourly struct {
+		Time        []string   `json:"time"`
+		Probability []*float64 `json:"precipitation_probability"`
+	} `json:"hourly"`
+}
+
+// FetchNowcast calls FetchNowcast on the default Client
+func FetchNowcast(ctx context.Context, country string, window time.Duration) (*Nowcast, error) {
+	return defaultClient.FetchNowcast(ctx, country, window)
+}
+
+// FetchNowcast tells whether rain is expected at a country's representative
+// city within window from now, zero meaning DefaultNowcastWindow
+func (c *Client) FetchNowcast(ctx context.Context, country string, window time.Duration) (*Nowcast, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	n, err := c.fetchNowcast(ctx, coords, window)
+	if err != nil {
+		return nil, err
+	}
+	n.Approximate, n.City = true, c.cityFor(country)
+	return n, nil
+}
+
+// FetchNowcastForCity calls FetchNowcastForCity on the default Client
+func FetchNowcastForCity(ctx context.Context, country, city string, window time.Duration) (*Nowcast, error) {
+	return defaultClient.FetchNowcastForCity(ctx, country, city, window)
+}
+
+// FetchNowcastForCity tells whether rain is expected at a gazetteer city
+// within window from now, zero meaning DefaultNowcastWindow
+func (c *Client) FetchNowcastForCity(ctx context.Context, country, city string, window time.Duration) (*Nowcast, error) {
+	entry, err := lookupCity(country, city)
+	if err != nil {
+		return nil, err
+	}
+	n, err := c.fetchNowcast(ctx, entry.coords, window)
+	if err != nil {
+		return nil, err
+	}
+	n.Approximate, n.City = true, entry.name
+	return n, nil
+}
+
+// FetchNowcastAt calls FetchNowcastAt on the default Client
+func FetchNowcastAt(ctx context.Context, coords Coordinates, window time.Duration) (*Nowcast, error) {
+	return defaultClient.FetchNowcastAt(ctx, coords, window)
+}
+
+// FetchNowcastAt tells whether rain is expected at exact coordinates within
+// window from now, zero meaning DefaultNowcastWindow
+func (c *Client) FetchNowcastAt(ctx context.Context, coords Coordinates, window time.Duration) (*Nowcast, error) {
+	if err := ValidateCoordinates(coords.Lat, coords.Lon); err != nil {
+		return nil, err
+	}
+	return c.fetchNowcast(ctx, coords, window)
+}
+
+// fetchNowcast fetches the precipitation steps covering window at coords
+func (c *Client) fetchNowcast(ctx context.Context, coords Coordinates, window time.Duration) (*Nowcast, error) {
+	if window == 0 {
+		window = DefaultNowcastWindow
+	}
+	if window < nowcastSlot || window > maxNowcastWindow {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNowcastWindow, window)
+	}
+
+	// One step and hour more than the window, as both start at the current one
+	steps := int(window/nowcastSlot) + 1
+	hours := int(window/time.Hour) + 2
+	url, err := c.forecastURL(ctx, coords, fmt.Sprintf("minutely_15=precipitation&hourly=precipitation_probability&timezone=auto&forecast_minutely_15=%d&forecast_hours=%d", steps, hours))
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp nowcastResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	return c.decodeNowcast(ctx, &apiResp, time.Now(), window)
+}
+
+// decodeNowcast summarizes the steps ending after now and starting within
+// window of it. Open-Meteo labels each step with the end of its 15 minutes.
+func (c *Client) decodeNowcast(ctx context.Context, apiResp *nowcastResponse, now time.Time, window time.Duration) (*Nowcast, error) {
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+	end := now.Add(window)
+	n := &Nowcast{
+		WindowMinutes: int(window / time.Minute),
+		Provenance:    c.provenance(c.openMeteoSource(ctx), time.Time{}),
+	}
+
+	m := apiResp.Minutely15
+	covered := false
+	for i, s := range m.Time {
+		stepEnd, err := time.ParseInLocation(openMeteoTimeLayout, s, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: nowcast time: %w", ErrDecode, err)
+		}
+		start := stepEnd.Add(-nowcastSlot)
+		if !stepEnd.After(now) || !start.Before(end) || i >= len(m.Precipitation) || m.Precipitation[i] == nil {
+			continue
+		}
+		covered = true
+		mm := *m.Precipitation[i]
+		n.TotalMm += mm
+		n.PeakMmPerHour = max(n.PeakMmPerHour, mm*float64(time.Hour/nowcastSlot))
+		if mm >= rainThresholdMm && !n.RainExpected {
+			n.RainExpected = true
+			n.StartsAt = start
+			if start.Before(now) {
+				n.StartsAt = now
+			}
+			n.MinutesUntil = int(n.StartsAt.Sub(now) / time.Minute)
+		}
+	}
+	if !covered {
+		return nil, fmt.Errorf("%w: no precipitation steps within the window", ErrInvalidUpstreamData)
+	}
+
+	h := apiResp.Hourly
+	for i, s := range h.Time {
+		hourStart, err := time.ParseInLocation(openMeteoTimeLayout, s, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: nowcast time: %w", ErrDecode, err)
+		}
+		if !hourStart.Add(time.Hour).After(now) || !hourStart.Before(end) || i >= len(h.Probability) || h.Probability[i] == nil {
+			continue
+		}
+		n.ProbabilityPercent = max(n.ProbabilityPercent, *h.Probability[i])
+	}
+
+	n.TotalMm = roundTenth(n.TotalMm)
+	n.PeakMmPerHour = roundTenth(n.PeakMmPerHour)
+	n.Intensity = RainNone
+	if n.RainExpected {
+		n.Intensity = rainIntensityFor(n.PeakMmPerHour)
+	}
+	n.Message = n.message()
+	return n, nil
+}
+
+// message phrases the nowcast for a notification
+func (n *Nowcast) message() string {
+	switch {
+	case !n.RainExpected:
+		return fmt.Sprintf("No rain expected in the next %s", windowPhrase(n.WindowMinutes))
+	case n.MinutesUntil == 0:
+		return fmt.Sprintf("%s rain now", capitalize(string(n.Intensity)))
+	default:
+		return fmt.Sprintf("%s rain in %d minutes", capitalize(string(n.Intensity)), n.MinutesUntil)
+	}
+}
+
+// windowPhrase spells a window like "2 hours" or "45 minutes"
+func windowPhrase(minutes int) string {
+	switch {
+	case minutes == 60:
+		return "hour"
+	case minutes%60 == 0:
+		return fmt.Sprintf("%d hours", minutes/60)
+	default:
+		return fmt.Sprintf("%d minutes", minutes)
+	}
+}
+
+// capitalize upper-cases the first letter of an ASCII word
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
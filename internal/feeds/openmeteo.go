@@ -0,0 +1,43 @@
+package feeds
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// openMeteoDomain is the domain of every Open-Meteo API host
+const openMeteoDomain = ".open-meteo.com"
+
+// customerHostPrefix turns an Open-Meteo API host into its commercial
+// counterpart, e.g. api.open-meteo.com into customer-api.open-meteo.com
+const customerHostPrefix = "customer-"
+
+// WithOpenMeteoAPIKey sends Open-Meteo requests to the commercial customer
+// endpoints, such as customer-api.open-meteo.com, authenticated with key.
+// The key is added only as requests are sent, so it doesn't appear in cache
+// keys, logs or recordings.
+func WithOpenMeteoAPIKey(key string) Option {
+	return func(c *Client) {
+		c.openMeteoAPIKey = key
+	}
+}
+
+// authorizeOpenMeteo points an Open-Meteo request at the customer endpoint
+// and adds the API key, when one is configured
+func (c *Client) authorizeOpenMeteo(req *http.Request) {
+	host := req.URL.Hostname()
+	if c.openMeteoAPIKey == "" || !strings.HasSuffix(host, openMeteoDomain) {
+		return
+	}
+	if !strings.HasPrefix(host, customerHostPrefix) {
+		req.URL.Host = customerHostPrefix + req.URL.Host
+		req.Host = ""
+	}
+	apikey := "apikey=" + url.QueryEscape(c.openMeteoAPIKey)
+	if req.URL.RawQuery == "" {
+		req.URL.RawQuery = apikey
+	} else {
+		req.URL.RawQuery += "&" + apikey
+	}
+}
@@ -0,0 +1,90 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// todayQuery adds today's daily extremes to a current request; sunrise and
+// sunset keep WithNightDescriptions working on the combined response
+const todayQuery = "&daily=temperature_2m_min,temperature_2m_max,precipitation_sum,sunrise,sunset&timezone=auto&forecast_days=1"
+
+// TodayOverview combines current conditions with today's daily extremes
+type TodayOverview struct {
+	Current         *WeatherData `json:"current"`
+	MinTemperatureC float64      `json:"minTemperatureC"`
+	MaxTemperatureC float64      `json:"maxTemperatureC"`
+	PrecipitationMm float64      `json:"precipitationMm"`
+}
+
+// todayResponse is the daily block of a TodayOverview request
+type todayResponse struct {
+	Daily struct {
+		MinTemperature []*float64 `json:"temperature_2m_min"`
+		MaxTemperature []*float64 `json:"temperature_2m_max"`
+		Precipitation  []*float64 `json:"precipitation_sum"`
+	} `json:"daily"`
+}
+
+// FetchTodayOverview calls FetchTodayOverview on the default Client
+func FetchTodayOverview(ctx context.Context, country string) (*TodayOverview, error) {
+	return defaultClient.FetchTodayOverview(ctx, country)
+}
+
+// FetchTodayOverview fetches current conditions and today's min/max
+// temperature and precipitation in a single request, e.g. for a card showing
+// "now 28°C, today 24–33°C". Overviews aren't cached.
+func (c *Client) FetchTodayOverview(ctx context.Context, country string) (*TodayOverview, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	wind, err := c.windSpeedQuery()
+	if err != nil {
+		return nil, err
+	}
+	url, err := c.forecastURL(ctx, coords, "current=temperature_2m,apparent_temperature,weather_code,relative_humidity_2m,wind_speed_10m,is_day"+wind+todayQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if err := c.getJSON(ctx, url, c.maxCurrentBytes, &raw); err != nil {
+		return nil, err
+	}
+	overview, err := c.decodeToday(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	overview.Current.Approximate, overview.Current.City = true, c.cityFor(country)
+	return overview, nil
+}
+
+// decodeToday builds a TodayOverview from a combined current and daily body
+func (c *Client) decodeToday(ctx context.Context, raw []byte) (*TodayOverview, error) {
+	current, err := c.decodeCurrent(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp todayResponse
+	if err := json.Unmarshal(raw, &apiResp); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	d := apiResp.Daily
+	if len(d.MinTemperature) == 0 || d.MinTemperature[0] == nil ||
+		len(d.MaxTemperature) == 0 || d.MaxTemperature[0] == nil {
+		return nil, fmt.Errorf("%w: no daily temperature extremes", ErrDecode)
+	}
+
+	overview := &TodayOverview{
+		Current:         current,
+		MinTemperatureC: *d.MinTemperature[0],
+		MaxTemperatureC: *d.MaxTemperature[0],
+	}
+	if len(d.Precipitation) > 0 && d.Precipitation[0] != nil {
+		overview.PrecipitationMm = *d.Precipitation[0]
+	}
+	return overview, nil
+}
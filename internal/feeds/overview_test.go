@@ -0,0 +1,47 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestFetchTodayOverview(t *testing.T) {
+	combined := `{"timezone":"Asia/Bangkok","timezone_abbreviation":"+07","utc_offset_seconds":25200,
+"current":{"time":"2025-01-15T14:00","temperature_2m":28,"apparent_temperature":31,"weather_code":2,"relative_humidity_2m":60,"wind_speed_10m":8,"is_day":1},
+"daily":{"time":["2025-01-15"],"temperature_2m_min":[24.1],"temperature_2m_max":[33.4],"precipitation_sum":[2.5],
+"sunrise":["2025-01-15T06:42"],"sunset":["2025-01-15T18:11"]}}`
+
+	srv := forecastServer(t, combined)
+	overview, err := srv.Client(t).FetchTodayOverview(context.Background(), "TH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overview.Current.TemperatureC != 28 || overview.Current.City != "Bangkok" {
+		t.Errorf("current %v°C in %q, want 28°C in Bangkok", overview.Current.TemperatureC, overview.Current.City)
+	}
+	if overview.MinTemperatureC != 24.1 || overview.MaxTemperatureC != 33.4 || overview.PrecipitationMm != 2.5 {
+		t.Errorf("today %v–%v°C, %vmm, want 24.1–33.4°C, 2.5mm",
+			overview.MinTemperatureC, overview.MaxTemperatureC, overview.PrecipitationMm)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("%d upstream requests, want one combined request", len(reqs))
+	}
+	q := reqs[0].Query()
+	if !strings.Contains(q.Get("current"), "temperature_2m") || !strings.Contains(q.Get("daily"), "temperature_2m_min") {
+		t.Errorf("current = %q, daily = %q, want both blocks requested", q.Get("current"), q.Get("daily"))
+	}
+}
+
+func TestFetchTodayOverviewWithoutDaily(t *testing.T) {
+	currentOnly := `{"current":{"time":"2025-01-15T14:00","temperature_2m":28,"apparent_temperature":31,"weather_code":2}}`
+	_, err := forecastServer(t, currentOnly).Client(t).FetchTodayOverview(context.Background(), "TH")
+	if !errors.Is(err, feeds.ErrDecode) {
+		t.Errorf("err = %v, want ErrDecode", err)
+	}
+}
@@ -0,0 +1,39 @@
+package feeds
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+)
+
+// WithExtraParams appends arbitrary query parameters to every forecast
+// request, for Open-Meteo features this package doesn't wrap yet. Values are
+// URL-escaped and added in key order. Parameters the package manages
+// (latitude, longitude and anything a request already sets) take precedence,
+// so an extra with the same name is dropped rather than overriding them.
+func WithExtraParams(params map[string]string) Option {
+	return func(c *Client) {
+		c.extraParams = maps.Clone(params)
+	}
+}
+
+// extraQuery returns the configured extra parameters not already set by
+// query, as a string ready to append to it
+func (c *Client) extraQuery(query string) string {
+	if len(c.extraParams) == 0 {
+		return ""
+	}
+	managed, _ := url.ParseQuery(query)
+
+	extra := url.Values{}
+	for _, key := range slices.Sorted(maps.Keys(c.extraParams)) {
+		if key == "latitude" || key == "longitude" || managed.Has(key) {
+			continue
+		}
+		extra.Set(key, c.extraParams[key])
+	}
+	if len(extra) == 0 {
+		return ""
+	}
+	return "&" + extra.Encode()
+}
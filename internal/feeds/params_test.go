@@ -0,0 +1,41 @@
+package feeds_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestWithExtraParams(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	c := srv.Client(t, feeds.WithExtraParams(map[string]string{
+		"cell_selection": "land",
+		"elevation":      "1200&models=gfs",
+		"latitude":       "0",
+		"current":        "snowfall",
+	}))
+	if _, err := c.FetchWeather(context.Background(), "JP"); err != nil {
+		t.Fatal(err)
+	}
+
+	u := srv.Requests()[0]
+	q := u.Query()
+	if got := q.Get("cell_selection"); got != "land" {
+		t.Errorf("cell_selection = %q, want land", got)
+	}
+	if got := q.Get("elevation"); got != "1200&models=gfs" {
+		t.Errorf("elevation = %q, want the escaped value intact", got)
+	}
+	if q.Has("models") {
+		t.Errorf("models = %q, want no parameter smuggled in by an unescaped value", q.Get("models"))
+	}
+	if got := q["latitude"]; len(got) != 1 || got[0] == "0" {
+		t.Errorf("latitude = %v, want only the package's", got)
+	}
+	if got := q["current"]; len(got) != 1 || strings.Contains(got[0], "snowfall") {
+		t.Errorf("current = %v, want only the package's", got)
+	}
+}
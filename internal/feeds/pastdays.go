@@ -0,0 +1,33 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxPastDays is the most history Open-Meteo's forecast API will include
+const maxPastDays = 92
+
+// ErrInvalidPastDays is returned when WithPastDays is outside 0 to 92
+var ErrInvalidPastDays = errors.New("past days must be between 0 and 92")
+
+// WithPastDays includes the last n days of history in forecast requests, so
+// ForecastAt can also answer for recent past hours. Values outside 0 to 92
+// fail NewClient with ErrInvalidConfig wrapping ErrInvalidPastDays.
+func WithPastDays(n int) Option {
+	return func(c *Client) {
+		c.pastDays = n
+	}
+}
+
+// pastDaysQuery validates the configured past days and returns its query
+// parameter, or "" when none are requested
+func (c *Client) pastDaysQuery() (string, error) {
+	if c.pastDays < 0 || c.pastDays > maxPastDays {
+		return "", fmt.Errorf("%w: %d", ErrInvalidPastDays, c.pastDays)
+	}
+	if c.pastDays == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("&past_days=%d", c.pastDays), nil
+}
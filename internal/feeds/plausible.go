@@ -0,0 +1,85 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrInvalidUpstreamData is returned when a response decodes but its
+// content can't be right, such as an empty current block, a missing
+// timestamp or out-of-range values, rather than passing zeros on as weather
+var ErrInvalidUpstreamData = errors.New("invalid upstream data")
+
+// ErrImplausibleData is returned when decoded values fall outside the
+// Client's plausible ranges, which usually means a parsing bug or bad
+// upstream data. It wraps ErrInvalidUpstreamData.
+var ErrImplausibleData = fmt.Errorf("implausible weather data: %w", ErrInvalidUpstreamData)
+
+// PlausibleRanges bounds the values a decoded response may contain (inclusive)
+type PlausibleRanges struct {
+	MinTemperatureC, MaxTemperatureC float64
+	MinHumidity, MaxHumidity         float64
+}
+
+// DefaultPlausibleRanges allows temperatures from -90 to 60°C and humidity
+// from 0 to 100%
+var DefaultPlausibleRanges = PlausibleRanges{
+	MinTemperatureC: -90,
+	MaxTemperatureC: 60,
+	MinHumidity:     0,
+	MaxHumidity:     100,
+}
+
+// WithPlausibleRanges replaces DefaultPlausibleRanges for decode validation
+func WithPlausibleRanges(r PlausibleRanges) Option {
+	return func(c *Client) {
+		c.plausible = r
+	}
+}
+
+// checkWeather checks the temperatures and humidity of data from a provider
+// that did its own decoding
+func (r PlausibleRanges) checkWeather(data *WeatherData) error {
+	if data == nil {
+		return fmt.Errorf("%w: no data", ErrInvalidUpstreamData)
+	}
+	temps := map[string]float64{"temperatureC": data.TemperatureC, "feelsLikeC": data.FeelsLikeC}
+	humidities := map[string]float64{}
+	if data.RelativeHumidity != 0 {
+		humidities["relativeHumidity"] = data.RelativeHumidity
+	}
+	return r.check(temps, humidities)
+}
+
+// check returns ErrImplausibleData naming every out-of-range temperature
+// and humidity value, keyed by API field name
+func (r PlausibleRanges) check(temperatures, humidities map[string]float64) error {
+	var errs []error
+	outOfRange := func(values map[string]float64, lo, hi float64) {
+		// Values are nearly always in range, so they're only sorted to
+		// report one that isn't
+		inRange := true
+		for _, v := range values {
+			if v < lo || v > hi {
+				inRange = false
+				break
+			}
+		}
+		if inRange {
+			return
+		}
+		for _, field := range slices.Sorted(maps.Keys(values)) {
+			if v := values[field]; v < lo || v > hi {
+				errs = append(errs, fmt.Errorf("%s=%g not within %g to %g", field, v, lo, hi))
+			}
+		}
+	}
+	outOfRange(temperatures, r.MinTemperatureC, r.MaxTemperatureC)
+	outOfRange(humidities, r.MinHumidity, r.MaxHumidity)
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrImplausibleData, errors.Join(errs...))
+}
@@ -0,0 +1,57 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestPlausibleRanges(t *testing.T) {
+	// current returns a current-conditions body with the given values
+	current := func(temp, humidity float64) string {
+		return fmt.Sprintf(`{"current":{"time":"2025-01-15T12:00","temperature_2m":%g,"apparent_temperature":20,`+
+			`"weather_code":1,"relative_humidity_2m":%g}}`, temp, humidity)
+	}
+	tests := []struct {
+		name      string
+		body      string
+		options   []feeds.Option
+		wantErr   bool
+		wantField string
+	}{
+		{name: "plausible", body: current(31, 80)},
+		{name: "absurd temperature", body: current(500, 80), wantErr: true, wantField: "temperature_2m=500"},
+		{name: "humidity over 100%", body: current(31, 140), wantErr: true, wantField: "relative_humidity_2m=140"},
+		{name: "record cold", body: current(-89.2, 30)},
+		{
+			name: "narrower configured range", body: current(31, 80), wantErr: true, wantField: "temperature_2m=31",
+			options: []feeds.Option{feeds.WithPlausibleRanges(feeds.PlausibleRanges{
+				MinTemperatureC: -20, MaxTemperatureC: 30, MinHumidity: 0, MaxHumidity: 100,
+			})},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := forecastServer(t, tt.body).Client(t, tt.options...).FetchWeather(context.Background(), "TH")
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if data == nil {
+					t.Fatal("no data")
+				}
+				return
+			}
+			if !errors.Is(err, feeds.ErrImplausibleData) || !errors.Is(err, feeds.ErrInvalidUpstreamData) {
+				t.Fatalf("err = %v, want ErrImplausibleData wrapping ErrInvalidUpstreamData", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantField) {
+				t.Errorf("err = %v, want it to name %s", err, tt.wantField)
+			}
+		})
+	}
+}
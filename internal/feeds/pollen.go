@@ -0,0 +1,242 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// pollenVariables are the Open-Meteo air quality pollen variables, in grains/m³
+const pollenVariables = "alder_pollen,birch_pollen,olive_pollen,grass_pollen,mugwort_pollen,ragweed_pollen"
+
+// pollenForecastDays is how many days of daily peaks PollenData carries
+const pollenForecastDays = 4
+
+// PollenLevel is a pollen count and its category
+type PollenLevel struct {
+	GrainsPerM3 float64 `json:"grainsPerM3"`
+	// Band is "None", "Low", "Moderate", "High" or "Very High", on the
+	// National Allergy Bureau scale for the pollen type
+	Band string `json:"band"`
+}
+
+// PollenDay is the peak hourly pollen level of one local day
+type PollenDay struct {
+	// Date is the local calendar date, at midnight in the location's timezone
+	Date  time.Time    `json:"date"`
+	Grass *PollenLevel `json:"grass,omitempty"`
+	Tree  *PollenLevel `json:"tree,omitempty"`
+	Weed  *PollenLevel `json:"weed,omitempty"`
+}
+
+// PollenData is the current pollen level by type, with daily peaks for the
+// next days. Tree pollen adds up alder, birch and olive, and weed pollen
+// mugwort and ragweed.
+type PollenData struct {
+	// Available is false where Open-Meteo has no pollen model, currently
+	// everywhere outside Europe; the levels are then nil and Days empty
+	Available bool `json:"available"`
+
+	// A level is nil when the model doesn't cover that type at the location
+	Grass *PollenLevel `json:"grass,omitempty"`
+	Tree  *PollenLevel `json:"tree,omitempty"`
+	Weed  *PollenLevel `json:"weed,omitempty"`
+	Days  []PollenDay  `json:"days,omitempty"`
+
+	// Approximate and City mean the same as on WeatherData
+	Approximate bool   `json:"approximate,omitempty"`
+	City        string `json:"city,omitempty"`
+
+	Provenance
+}
+
+// pollenValues are one time step of the pollen variables
+type pollenValues struct {
+	Alder   *float64 `json:"alder_pollen"`
+	Birch   *float64 `json:"birch_pollen"`
+	Olive   *float64 `json:"olive_pollen"`
+	Grass   *float64 `json:"grass_pollen"`
+	Mugwort *float64 `json:"mugwort_pollen"`
+	Ragweed *float64 `json:"ragweed_pollen"`
+}
+
+// pollenResponse represents the current and hourly blocks of an Open-Meteo
+// air quality response for the pollen variables
+type pollenResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Current              struct {
+		Time string `json:"time"`
+		pollenValues
+	} `json:"current"`
+	Hourly struct {
+		Time    []string   `json:"time"`
+		Alder   []*float64 `json:"alder_pollen"`
+		Birch   []*float64 `json:"birch_pollen"`
+		Olive   []*float64 `json:"olive_pollen"`
+		Grass   []*float64 `json:"grass_pollen"`
+		Mugwort []*float64 `json:"mugwort_pollen"`
+		Ragweed []*float64 `json:"ragweed_pollen"`
+	} `json:"hourly"`
+}
+
+// pollenBands are the National Allergy Bureau upper bounds (inclusive,
+// grains/m³) of Low, Moderate and High per pollen type; above is Very High
+var pollenBands = map[string][3]float64{
+	"grass": {4, 19, 199},
+	"tree":  {14, 89, 1499},
+	"weed":  {9, 49, 499},
+}
+
+// pollenBand returns the category of a pollen count of the given type
+func pollenBand(typ string, grains float64) string {
+	bounds := pollenBands[typ]
+	switch {
+	case grains <= 0:
+		return "None"
+	case grains <= bounds[0]:
+		return "Low"
+	case grains <= bounds[1]:
+		return "Moderate"
+	case grains <= bounds[2]:
+		return "High"
+	default:
+		return "Very High"
+	}
+}
+
+// pollenLevel sums the counts of the species of a pollen type, or returns
+// nil when the model covers none of them
+func pollenLevel(typ string, species ...*float64) *PollenLevel {
+	var total float64
+	covered := false
+	for _, v := range species {
+		if v != nil {
+			total += *v
+			covered = true
+		}
+	}
+	if !covered {
+		return nil
+	}
+	return &PollenLevel{GrainsPerM3: total, Band: pollenBand(typ, total)}
+}
+
+// levels returns the grass, tree and weed levels of one time step
+func (v pollenValues) levels() (grass, tree, weed *PollenLevel) {
+	return pollenLevel("grass", v.Grass), pollenLevel("tree", v.Alder, v.Birch, v.Olive), pollenLevel("weed", v.Mugwort, v.Ragweed)
+}
+
+// FetchPollen calls FetchPollen on the default Client
+func FetchPollen(ctx context.Context, country string) (*PollenData, error) {
+	return defaultClient.FetchPollen(ctx, country)
+}
+
+// FetchPollen fetches the pollen forecast for a country's representative
+// city. Regions without pollen data aren't an error: they return PollenData
+// with Available false.
+func (c *Client) FetchPollen(ctx context.Context, country string) (*PollenData, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchPollen(ctx, coords)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, c.cityFor(country)
+	return data, nil
+}
+
+// FetchPollenForCity calls FetchPollenForCity on the default Client
+func FetchPollenForCity(ctx context.Context, country, city string) (*PollenData, error) {
+	return defaultClient.FetchPollenForCity(ctx, country, city)
+}
+
+// FetchPollenForCity fetches the pollen forecast for a city in the bundled
+// gazetteer, as FetchWeatherForCity does for weather
+func (c *Client) FetchPollenForCity(ctx context.Context, country, city string) (*PollenData, error) {
+	entry, err := lookupCity(country, city)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchPollen(ctx, entry.coords)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, entry.name
+	return data, nil
+}
+
+// FetchPollenAt calls FetchPollenAt on the default Client
+func FetchPollenAt(ctx context.Context, coords Coordinates) (*PollenData, error) {
+	return defaultClient.FetchPollenAt(ctx, coords)
+}
+
+// FetchPollenAt fetches the pollen forecast at exact coordinates
+func (c *Client) FetchPollenAt(ctx context.Context, coords Coordinates) (*PollenData, error) {
+	if err := ValidateCoordinates(coords.Lat, coords.Lon); err != nil {
+		return nil, err
+	}
+	return c.fetchPollen(ctx, coords)
+}
+
+// fetchPollen fetches current and hourly pollen at the given coordinates
+func (c *Client) fetchPollen(ctx context.Context, coords Coordinates) (*PollenData, error) {
+	url := fmt.Sprintf("%s?latitude=%.4f&longitude=%.4f&current=%s&hourly=%s&timezone=auto&forecast_days=%d",
+		airQualityEndpoint, coords.Lat, coords.Lon, pollenVariables, pollenVariables, pollenForecastDays)
+
+	var apiResp pollenResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	return c.decodePollen(&apiResp)
+}
+
+// decodePollen builds PollenData from the current block and the daily peaks
+// of the hourly one
+func (c *Client) decodePollen(apiResp *pollenResponse) (*PollenData, error) {
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+	observedAt, _ := time.ParseInLocation(openMeteoTimeLayout, apiResp.Current.Time, loc)
+	data := &PollenData{Provenance: c.provenance(sourceOpenMeteo, observedAt)}
+	data.Grass, data.Tree, data.Weed = apiResp.Current.levels()
+
+	h := apiResp.Hourly
+	at := func(values []*float64, i int) *float64 {
+		if i < len(values) {
+			return values[i]
+		}
+		return nil
+	}
+	for i, s := range h.Time {
+		t, err := time.ParseInLocation(openMeteoTimeLayout, s, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: pollen time: %w", ErrDecode, err)
+		}
+		grass, tree, weed := pollenValues{
+			Alder: at(h.Alder, i), Birch: at(h.Birch, i), Olive: at(h.Olive, i),
+			Grass: at(h.Grass, i), Mugwort: at(h.Mugwort, i), Ragweed: at(h.Ragweed, i),
+		}.levels()
+		if grass == nil && tree == nil && weed == nil {
+			continue
+		}
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		if len(data.Days) == 0 || !data.Days[len(data.Days)-1].Date.Equal(date) {
+			data.Days = append(data.Days, PollenDay{Date: date})
+		}
+		day := &data.Days[len(data.Days)-1]
+		day.Grass, day.Tree, day.Weed = peakPollen(day.Grass, grass), peakPollen(day.Tree, tree), peakPollen(day.Weed, weed)
+	}
+
+	data.Available = data.Grass != nil || data.Tree != nil || data.Weed != nil || len(data.Days) > 0
+	return data, nil
+}
+
+// peakPollen returns the higher of two levels, either of which may be nil
+func peakPollen(a, b *PollenLevel) *PollenLevel {
+	if a == nil || (b != nil && b.GrainsPerM3 > a.GrainsPerM3) {
+		return b
+	}
+	return a
+}
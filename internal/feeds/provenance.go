@@ -0,0 +1,51 @@
+package feeds
+
+import (
+	"context"
+	"time"
+)
+
+// Provider names used in Provenance.Source
+const (
+	sourceOpenMeteo    = "Open-Meteo"
+	sourceMetNo        = "MET Norway"
+	sourceGDACS        = "GDACS"
+	sourceUSGS         = "USGS"
+	sourceCurrencyAPI  = "fawazahmed0/currency-api"
+	sourceNagerDate    = "Nager.Date"
+	sourceYahooFinance = "Yahoo Finance"
+	sourceStateDept    = "U.S. Department of State"
+)
+
+// Provenance says where and when feed data came from, so consumers can show
+// freshness and credit providers. Every fetched feed result embeds it but
+// Headline, whose Source and Published credit the publisher instead.
+type Provenance struct {
+	// Source is the provider, with the model where one was requested, e.g.
+	// "Open-Meteo" or "Open-Meteo (jma_seamless)"
+	Source string `json:"source"`
+	// ObservedAt is when the provider measured the values, zero for
+	// forecasts and data without a measurement time
+	ObservedAt time.Time `json:"observedAt,omitzero"`
+	// FetchedAt is when the data was fetched from upstream
+	FetchedAt time.Time `json:"fetchedAt,omitzero"`
+	// Replayed is true when the data is from a recording served under
+	// WithReplayDir rather than from upstream; FetchedAt is then when it
+	// was read
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// provenance returns the Provenance of data fetched from source just now
+func (c *Client) provenance(source string, observedAt time.Time) Provenance {
+	return Provenance{Source: source, ObservedAt: observedAt, FetchedAt: time.Now(), Replayed: c.replayDir != ""}
+}
+
+// openMeteoSource names Open-Meteo and the model of a fetch, for results of
+// the forecast API, the only one that takes a model
+func (c *Client) openMeteoSource(ctx context.Context) string {
+	model := c.modelFor(ctx)
+	if model == "" {
+		return sourceOpenMeteo
+	}
+	return sourceOpenMeteo + " (" + model + ")"
+}
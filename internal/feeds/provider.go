@@ -0,0 +1,61 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"reef-asia/internal/logger"
+)
+
+// WeatherProvider fetches current conditions at a point. A Client is itself
+// the Open-Meteo WeatherProvider; others can be chained behind it with
+// WithFallbackProviders.
+type WeatherProvider interface {
+	Fetch(ctx context.Context, coords Coordinates) (*WeatherData, error)
+}
+
+// WithFallbackProviders sets providers tried in order when Open-Meteo fails,
+// so an outage or invalid data from one upstream doesn't take the whole feed
+// down. Fallback results are checked against the plausible ranges but not cached.
+func WithFallbackProviders(providers ...WeatherProvider) Option {
+	return func(c *Client) {
+		c.fallbacks = providers
+	}
+}
+
+// Fetch implements WeatherProvider with FetchWeatherAt
+func (c *Client) Fetch(ctx context.Context, coords Coordinates) (*WeatherData, error) {
+	return c.FetchWeatherAt(ctx, coords)
+}
+
+// fetchCurrent fetches current conditions at the given coordinates, trying
+// each fallback provider in turn after Open-Meteo fails
+func (c *Client) fetchCurrent(ctx context.Context, coords Coordinates) (*WeatherData, error) {
+	data, err := c.fetchOpenMeteo(ctx, coords)
+	if err == nil || len(c.fallbacks) == 0 {
+		return data, err
+	}
+
+	errs := []error{fmt.Errorf("open-meteo: %w", err)}
+	for i, p := range c.fallbacks {
+		// Don't keep trying once the caller has given up
+		if ctx.Err() != nil {
+			break
+		}
+		logger.Warnf("%s%v; trying fallback %d", logPrefix(ctx), errs[len(errs)-1], i+1)
+		data, err := p.Fetch(ctx, coords)
+		if err == nil {
+			err = c.plausible.checkWeather(data)
+		}
+		if err == nil {
+			// Providers that don't classify the code get day icons
+			if data.Icon == "" {
+				data.setCondition(true, true)
+			}
+			return data, nil
+		}
+		errs = append(errs, fmt.Errorf("fallback %d: %w", i+1, err))
+	}
+	return nil, errors.Join(errs...)
+}
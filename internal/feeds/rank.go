@@ -0,0 +1,44 @@
+package feeds
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+)
+
+// CountryTemp is one entry of a temperature ranking
+type CountryTemp struct {
+	Country      string  `json:"country"`
+	City         string  `json:"city"`
+	TemperatureC float64 `json:"temperatureC"`
+}
+
+// RankByTemperature calls RankByTemperature on the default Client
+func RankByTemperature(ctx context.Context) ([]CountryTemp, error) {
+	return defaultClient.RankByTemperature(ctx)
+}
+
+// RankByTemperature fetches every supported country and returns them from
+// warmest to coldest. Failed countries are left out and reported through an
+// error wrapping ErrPartialFailure, alongside the ranking of the rest.
+func (c *Client) RankByTemperature(ctx context.Context) ([]CountryTemp, error) {
+	results, err := c.FetchAllSupported(ctx)
+	if errors.Is(err, ErrAllFailed) {
+		return nil, err
+	}
+
+	ranking := make([]CountryTemp, 0, len(results))
+	for country, data := range results {
+		ranking = append(ranking, CountryTemp{
+			Country:      country,
+			City:         c.cityFor(country),
+			TemperatureC: data.TemperatureC,
+		})
+	}
+	slices.SortFunc(ranking, func(a, b CountryTemp) int {
+		// Ties are broken by country code so the order is stable
+		return cmp.Or(cmp.Compare(b.TemperatureC, a.TemperatureC), cmp.Compare(a.Country, b.Country))
+	})
+	return ranking, err
+}
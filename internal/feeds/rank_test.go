@@ -0,0 +1,85 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestRankByTemperature(t *testing.T) {
+	// Every country at 20°C but three, one of them failing in some cases
+	weather := func(th countryWeather) map[string]countryWeather {
+		w := make(map[string]countryWeather)
+		for _, country := range feeds.SupportedCountries() {
+			w[country] = countryWeather{TempC: 20}
+		}
+		w["SG"] = countryWeather{TempC: 31}
+		w["MN"] = countryWeather{TempC: -18}
+		w["TH"] = th
+		return w
+	}
+	allFailed := make(map[string]countryWeather)
+	for _, country := range feeds.SupportedCountries() {
+		allFailed[country] = countryWeather{Status: http.StatusBadGateway}
+	}
+
+	tests := []struct {
+		name    string
+		weather map[string]countryWeather
+		// want is the head of the ranking, and last its tail
+		want, last []string
+		wantLen    int
+		wantErr    error
+	}{
+		{
+			name:    "all succeed",
+			weather: weather(countryWeather{TempC: 34}),
+			want:    []string{"TH", "SG"},
+			last:    []string{"MN"},
+			wantLen: len(feeds.SupportedCountries()),
+		},
+		{
+			name:    "some fail",
+			weather: weather(countryWeather{Status: http.StatusBadGateway}),
+			want:    []string{"SG"},
+			last:    []string{"MN"},
+			wantLen: len(feeds.SupportedCountries()) - 1,
+			wantErr: feeds.ErrPartialFailure,
+		},
+		{name: "all fail", weather: allFailed, wantErr: feeds.ErrAllFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The zero BreakerSettings turn the breaker off, which would otherwise
+			// open partway through a run of failures
+			c := stubClient(t, countryTransport(t, tt.weather), feeds.WithCircuitBreaker(feeds.BreakerSettings{}))
+
+			ranking, err := c.RankByTemperature(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(ranking) != tt.wantLen {
+				t.Fatalf("ranked %d countries, want %d", len(ranking), tt.wantLen)
+			}
+			for i, country := range tt.want {
+				if ranking[i].Country != country {
+					t.Errorf("ranking[%d] = %s, want %s", i, ranking[i].Country, country)
+				}
+			}
+			for i, country := range tt.last {
+				if got := ranking[len(ranking)-len(tt.last)+i].Country; got != country {
+					t.Errorf("ranking tail = %s, want %s", got, country)
+				}
+			}
+			for i := 1; i < len(ranking); i++ {
+				a, b := ranking[i-1], ranking[i]
+				if a.TemperatureC < b.TemperatureC || a.TemperatureC == b.TemperatureC && a.Country > b.Country {
+					t.Errorf("%+v ranked before %+v", a, b)
+				}
+			}
+		})
+	}
+}
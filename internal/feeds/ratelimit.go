@@ -0,0 +1,143 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request would exceed a provider's rate
+// limit and the limit fails fast, or when waiting for it would outlast the
+// context's deadline
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimit is a token bucket capping requests to one provider host. Every
+// upstream request, including retries and hedges, takes a token.
+type RateLimit struct {
+	// RPS is the sustained requests per second; zero disables the limit
+	RPS float64
+	// Burst is how many requests may go at once after a quiet spell (zero means 1)
+	Burst int
+	// FailFast returns ErrRateLimited at once when no token is available,
+	// instead of queueing the request until one is
+	FailFast bool
+}
+
+// WithRateLimit limits requests to every provider host without its own
+// WithHostRateLimit, each host with a separate bucket. There is no limit by
+// default; Open-Meteo's free tier allows 600 requests a minute.
+func WithRateLimit(l RateLimit) Option {
+	return func(c *Client) {
+		c.limiters.defaults = l
+	}
+}
+
+// WithHostRateLimit limits requests to one provider host, such as
+// "api.open-meteo.com", in place of the WithRateLimit default
+func WithHostRateLimit(host string, l RateLimit) Option {
+	return func(c *Client) {
+		c.limiters.hosts[host] = l
+	}
+}
+
+// tokenBucket holds the tokens of one host; tokens go negative to queue
+// requests that are waiting for their turn
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token, returning how long to wait before using it, or
+// false without taking one when the limit fails fast and none is available
+func (b *tokenBucket) reserve(now time.Time) (time.Duration, bool) {
+	burst := float64(max(b.limit.Burst, 1))
+	b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*b.limit.RPS)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	if b.limit.FailFast {
+		return 0, false
+	}
+	wait := time.Duration((1 - b.tokens) / b.limit.RPS * float64(time.Second))
+	b.tokens--
+	return wait, true
+}
+
+// rateLimiters holds a token bucket per upstream host
+type rateLimiters struct {
+	mu       sync.Mutex
+	defaults RateLimit
+	hosts    map[string]RateLimit
+	buckets  map[string]*tokenBucket
+}
+
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{hosts: make(map[string]RateLimit), buckets: make(map[string]*tokenBucket)}
+}
+
+// validate checks the default and per-host limits
+func (l *rateLimiters) validate() error {
+	var errs []error
+	check := func(name string, rl RateLimit) {
+		if rl.RPS < 0 || rl.Burst < 0 {
+			errs = append(errs, fmt.Errorf("rate limit for %s must not be negative, got %g rps and burst %d", name, rl.RPS, rl.Burst))
+		}
+	}
+	check("all hosts", l.defaults)
+	for host, rl := range l.hosts {
+		check(host, rl)
+	}
+	return errors.Join(errs...)
+}
+
+// wait blocks until a request to host is within its rate limit. It fails
+// with ErrRateLimited instead when the limit fails fast or when the wait
+// wouldn't end before ctx's deadline.
+func (l *rateLimiters) wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	limit, ok := l.hosts[host]
+	if !ok {
+		limit = l.defaults
+	}
+	if limit.RPS <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	b := l.buckets[host]
+	if b == nil || b.limit != limit {
+		b = &tokenBucket{limit: limit, tokens: float64(max(limit.Burst, 1)), last: time.Now()}
+		l.buckets[host] = b
+	}
+	now := time.Now()
+	delay, ok := b.reserve(now)
+	if ok && delay > 0 {
+		if deadline, has := ctx.Deadline(); has && now.Add(delay).After(deadline) {
+			b.tokens++ // hand the queued token back
+			ok = false
+		}
+	}
+	l.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrRateLimited, host)
+	}
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.tokens = min(float64(max(limit.Burst, 1)), b.tokens+1)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
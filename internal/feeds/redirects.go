@@ -0,0 +1,35 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrUnexpectedRedirect is returned by RejectRedirects when upstream redirects
+var ErrUnexpectedRedirect = errors.New("unexpected redirect")
+
+// RedirectPolicy decides whether to follow a redirect, as http.Client.CheckRedirect does
+type RedirectPolicy func(req *http.Request, via []*http.Request) error
+
+// FollowRedirects follows up to 10 redirects, the net/http default
+var FollowRedirects RedirectPolicy = func(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after %d redirects", len(via))
+	}
+	return nil
+}
+
+// RejectRedirects fails any redirect with ErrUnexpectedRedirect, which helps
+// catch misconfigured proxies early
+var RejectRedirects RedirectPolicy = func(req *http.Request, via []*http.Request) error {
+	return fmt.Errorf("%w: %s to %s", ErrUnexpectedRedirect, via[len(via)-1].URL.Redacted(), req.URL.Redacted())
+}
+
+// WithRedirectPolicy sets how upstream redirects are handled (default
+// FollowRedirects); pass RejectRedirects or a custom policy
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(c *Client) {
+		c.redirectPolicy = policy
+	}
+}
@@ -0,0 +1,64 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+// errOffHost is returned by the custom policy for redirects to another host
+var errOffHost = errors.New("redirect off host")
+
+func TestRedirectPolicy(t *testing.T) {
+	sameHost := feeds.RedirectPolicy(func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host != via[0].URL.Host {
+			return errOffHost
+		}
+		return nil
+	})
+	tests := []struct {
+		name     string
+		policy   feeds.RedirectPolicy
+		location string
+		wantErr  error
+	}{
+		{name: "follow", policy: feeds.FollowRedirects, location: "https://api.open-meteo.com/v1/moved"},
+		{name: "reject", policy: feeds.RejectRedirects, location: "https://api.open-meteo.com/v1/moved", wantErr: feeds.ErrUnexpectedRedirect},
+		{name: "custom, same host", policy: sameHost, location: "https://api.open-meteo.com/v1/moved"},
+		{name: "custom, other host", policy: sameHost, location: "https://mirror.example.com/v1/moved", wantErr: errOffHost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := feedstest.Fixture(feedstest.ForecastFixture)
+			var moved int
+			rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/v1/forecast" {
+					resp := stubResponse(req, http.StatusFound, nil)
+					resp.Header.Set("Location", tt.location+"?"+req.URL.RawQuery)
+					return resp, nil
+				}
+				moved++
+				return stubResponse(req, http.StatusOK, fixture), nil
+			})
+			c := stubClient(t, rt, feeds.WithRedirectPolicy(tt.policy))
+
+			data, err := c.FetchWeather(context.Background(), "JP")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			wantMoved := 1
+			if tt.wantErr != nil {
+				wantMoved = 0
+			} else if data.TemperatureC != 9.4 {
+				t.Errorf("TemperatureC = %v, want the redirect target's 9.4", data.TemperatureC)
+			}
+			if moved != wantMoved {
+				t.Errorf("redirect target requested %d times, want %d", moved, wantMoved)
+			}
+		})
+	}
+}
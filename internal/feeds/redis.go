@@ -0,0 +1,341 @@
+package feeds
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRedis wraps error replies from Redis
+var ErrRedis = errors.New("redis error")
+
+// redisTimeout bounds each Redis command whose context has no deadline,
+// including every Cache call
+const redisTimeout = 2 * time.Second
+
+// redisIdleConns is how many idle connections a RedisCache keeps
+const redisIdleConns = 8
+
+// redisKeyPrefix namespaces every key a RedisCache writes
+const redisKeyPrefix = "reef:"
+
+// tryLockScript takes a lock that's free, or extends one this replica
+// already holds, in one step
+const tryLockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2])`
+
+// unlockScript releases a lock only if this replica holds it
+const unlockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
+
+// RedisCache is a Cache shared by every replica pointed at the same Redis,
+// so one replica's fetch serves the others until it expires. It's also a
+// Coordinator for Refresher.Share. Connections are opened on first use and
+// pooled; it speaks just enough of the Redis protocol for GET, SET and
+// EVAL, with no client library needed.
+type RedisCache struct {
+	addr string
+	// tls is set for rediss:// URLs
+	tls      *tls.Config
+	username string
+	password string
+	db       int
+	// token identifies this replica's locks
+	token string
+	idle  chan *redisConn
+}
+
+// redisConn is one connection with its buffered reader
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewRedisCache returns a RedisCache for a URL like
+// "redis://:password@host:6379/0", or "rediss://" to connect over TLS; the
+// password and database are optional. Nothing is dialed until the first
+// command.
+func NewRedisCache(rawURL string) (*RedisCache, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("redis url: %w", err)
+	}
+	if (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+		return nil, fmt.Errorf("redis url %q: want redis://host:port or rediss://host:port", rawURL)
+	}
+	r := &RedisCache{addr: u.Host, idle: make(chan *redisConn, redisIdleConns)}
+	if u.Scheme == "rediss" {
+		r.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	if u.Port() == "" {
+		r.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		r.username = u.User.Username()
+		r.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if r.db, err = strconv.Atoi(db); err != nil || r.db < 0 {
+			return nil, fmt.Errorf("redis url %q: invalid database %q", rawURL, db)
+		}
+	}
+
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return nil, err
+	}
+	r.token = hex.EncodeToString(token[:])
+	return r, nil
+}
+
+func (r *RedisCache) Get(key string) (*WeatherData, bool, error) {
+	body, ok, err := r.GetBytes(context.Background(), key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	var data WeatherData
+	if err := json.Unmarshal(body, &data); err != nil {
+		// Another version's entry is a miss, and is replaced by the next Set
+		return nil, false, nil
+	}
+	return &data, true, nil
+}
+
+func (r *RedisCache) Set(key string, data *WeatherData, ttl time.Duration) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return r.SetBytes(context.Background(), key, body, ttl)
+}
+
+// GetBytes returns the value of key, reporting false when it's missing or
+// expired
+func (r *RedisCache) GetBytes(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", redisKeyPrefix+key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	body, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("%w: unexpected GET reply %T", ErrRedis, reply)
+	}
+	return body, true, nil
+}
+
+// SetBytes stores value under key for ttl
+func (r *RedisCache) SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := r.do(ctx, "SET", redisKeyPrefix+key, string(value), "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	return err
+}
+
+// TryLock takes the lock name for ttl unless another replica holds it,
+// reporting whether this replica now does. Calling it again while holding
+// the lock extends it.
+func (r *RedisCache) TryLock(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	reply, err := r.do(ctx, "EVAL", tryLockScript, "1", redisKeyPrefix+"lock:"+name, r.token, strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+// Unlock releases the lock name if this replica holds it, so another can
+// take over without waiting for it to expire
+func (r *RedisCache) Unlock(ctx context.Context, name string) error {
+	_, err := r.do(ctx, "EVAL", unlockScript, "1", redisKeyPrefix+"lock:"+name, r.token)
+	return err
+}
+
+// Close closes the idle connections; commands still in flight close theirs
+// when done
+func (r *RedisCache) Close() error {
+	for {
+		select {
+		case c := <-r.idle:
+			c.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends one command and returns its reply: a string, int64, []byte, nil
+// or []any. Error replies are returned as ErrRedis and leave the connection
+// usable; I/O errors discard it. The server may have closed a pooled
+// connection while it sat idle, so a command failing on one is retried once
+// on a new connection.
+func (r *RedisCache) do(ctx context.Context, args ...string) (any, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, redisTimeout)
+		defer cancel()
+	}
+	var c *redisConn
+	select {
+	case c = <-r.idle:
+	default:
+	}
+	if c != nil {
+		reply, err := r.send(ctx, c, args)
+		if err == nil || errors.Is(err, ErrRedis) {
+			return reply, err
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("redis %s: %w", args[0], err)
+		}
+	}
+
+	c, err := r.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := r.send(ctx, c, args)
+	if err != nil && !errors.Is(err, ErrRedis) {
+		return nil, fmt.Errorf("redis %s: %w", args[0], err)
+	}
+	return reply, err
+}
+
+// send runs one command on c, returning c to the pool unless an I/O error
+// closed it
+func (r *RedisCache) send(ctx context.Context, c *redisConn, args []string) (any, error) {
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		c.conn.Close()
+		return nil, err
+	}
+	reply, err := c.roundTrip(args)
+	if err != nil && !errors.Is(err, ErrRedis) {
+		c.conn.Close()
+		return nil, err
+	}
+	r.release(c)
+	return reply, err
+}
+
+// dial opens a new connection, over TLS for rediss:// URLs, then
+// authenticates and selects the database
+func (r *RedisCache) dial(ctx context.Context) (*redisConn, error) {
+	var nc net.Conn
+	var err error
+	if r.tls != nil {
+		d := tls.Dialer{Config: r.tls}
+		nc, err = d.DialContext(ctx, "tcp", r.addr)
+	} else {
+		var d net.Dialer
+		nc, err = d.DialContext(ctx, "tcp", r.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis dial: %w", err)
+	}
+	c := &redisConn{conn: nc, r: bufio.NewReader(nc)}
+	deadline, _ := ctx.Deadline()
+	_ = nc.SetDeadline(deadline)
+
+	var setup [][]string
+	switch {
+	case r.username != "":
+		setup = append(setup, []string{"AUTH", r.username, r.password})
+	case r.password != "":
+		setup = append(setup, []string{"AUTH", r.password})
+	}
+	if r.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(r.db)})
+	}
+	for _, args := range setup {
+		if _, err := c.roundTrip(args); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("redis %s: %w", args[0], err)
+		}
+	}
+	return c, nil
+}
+
+// release returns c to the idle pool, closing it when the pool is full
+func (r *RedisCache) release(c *redisConn) {
+	select {
+	case r.idle <- c:
+	default:
+		c.conn.Close()
+	}
+}
+
+// roundTrip writes args as a RESP array of bulk strings and reads the reply
+func (c *redisConn) roundTrip(args []string) (any, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// readReply reads one RESP2 reply
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("malformed reply %q", line)
+	}
+	kind, rest := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+':
+		return rest, nil
+	case '-':
+		return nil, fmt.Errorf("%w: %s", ErrRedis, rest)
+	case ':':
+		return strconv.ParseInt(rest, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(rest)
+		if err != nil || n < -1 {
+			return nil, fmt.Errorf("malformed bulk length %q", rest)
+		}
+		if n == -1 {
+			return nil, nil
+		}
+		body := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, body); err != nil {
+			return nil, err
+		}
+		return body[:n], nil
+	case '*':
+		n, err := strconv.Atoi(rest)
+		if err != nil || n < -1 {
+			return nil, fmt.Errorf("malformed array length %q", rest)
+		}
+		if n == -1 {
+			return nil, nil
+		}
+		items := make([]any, n)
+		for i := range items {
+			// An error element is still read whole, keeping the stream in step
+			if items[i], err = c.readReply(); err != nil && !errors.Is(err, ErrRedis) {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,302 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"sync"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// RefreshSpec schedules a registered feed for background refresh
+type RefreshSpec struct {
+	Feed string
+	// Interval is the time between refreshes; zero fetches once
+	Interval time.Duration
+	// Countries are fetched once each per interval with Params{"country": ...};
+	// leave empty for feeds that aren't per country, such as earthquakes or fx
+	Countries []string
+	// Params are passed to every fetch, alongside the country
+	Params Params
+}
+
+// Snapshot is the latest refreshed value of a feed
+type Snapshot struct {
+	// Value is the last successfully fetched value, kept across later failures
+	Value     any       `json:"value"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	// Err is the error of the most recent attempt, or nil if it succeeded
+	Err error `json:"-"`
+}
+
+// Refresher keeps feeds warm in memory by fetching them on a schedule, so
+// reads never wait on the network
+type Refresher struct {
+	registry *Registry
+	specs    []RefreshSpec
+
+	store *Store
+	coord Coordinator
+	// mu orders recording and publishing against Subscribe, so subscribers
+	// see every change exactly once
+	mu sync.RWMutex
+
+	subMu       sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// Update is a changed snapshot pushed to subscribers
+type Update struct {
+	Feed    string `json:"feed"`
+	Country string `json:"country,omitempty"`
+	Snapshot
+}
+
+// subscriber receives updates for a set of countries, or all when empty
+type subscriber struct {
+	countries map[string]struct{}
+	ch        chan Update
+}
+
+// subscriberBuffer is how many updates a slow subscriber may fall behind
+// before further updates are dropped for it
+const subscriberBuffer = 16
+
+// snapshotKey identifies a refreshed feed and country
+type snapshotKey struct {
+	feed, country string
+}
+
+// NewRefresher creates a Refresher for feeds in registry, or the default
+// Registry when nil. Nothing is fetched until Run.
+func NewRefresher(registry *Registry, specs ...RefreshSpec) *Refresher {
+	if registry == nil {
+		registry = defaultRegistry
+	}
+	return &Refresher{
+		registry:    registry,
+		specs:       specs,
+		store:       NewStore(),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Run refreshes every spec immediately and then on its interval until ctx is
+// done, and returns once all refreshes have stopped
+func (r *Refresher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, spec := range r.specs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.runSpec(ctx, spec)
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *Refresher) runSpec(ctx context.Context, spec RefreshSpec) {
+	// Without a positive interval the feed is fetched once
+	if spec.Interval <= 0 {
+		r.refresh(ctx, spec)
+		return
+	}
+	ticker := time.NewTicker(spec.Interval)
+	defer ticker.Stop()
+	for {
+		r.refresh(ctx, spec)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh fetches every country of spec once
+func (r *Refresher) refresh(ctx context.Context, spec RefreshSpec) {
+	countries := spec.Countries
+	if len(countries) == 0 {
+		countries = []string{""}
+	}
+	lead := r.leads(ctx, spec)
+	for _, country := range countries {
+		country := normalizeCountry(country)
+		if !lead {
+			if value, ok := r.loadShared(ctx, spec.Feed, country); ok {
+				r.record(spec.Feed, country, value, nil)
+				continue
+			}
+		}
+
+		params := maps.Clone(spec.Params)
+		if params == nil {
+			params = Params{}
+		}
+		if country != "" {
+			params["country"] = country
+		}
+
+		value, err := r.registry.Fetch(ctx, spec.Feed, params)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Warnf("%srefresh %s %s failed: %v", logPrefix(ctx), spec.Feed, country, err)
+		}
+		r.record(spec.Feed, country, value, err)
+		if lead && r.coord != nil && err == nil {
+			r.share(ctx, spec, country, value)
+		}
+	}
+}
+
+// Pending lists the feeds, as "feed" or "feed/country", that haven't been
+// refreshed once yet, successfully or not; a Refresher with none pending
+// has warmed its Store
+func (r *Refresher) Pending() []string {
+	var pending []string
+	for _, spec := range r.specs {
+		countries := spec.Countries
+		if len(countries) == 0 {
+			countries = []string{""}
+		}
+		for _, country := range countries {
+			if _, ok := r.store.Get(spec.Feed, country); ok {
+				continue
+			}
+			if country == "" {
+				pending = append(pending, spec.Feed)
+			} else {
+				pending = append(pending, spec.Feed+"/"+normalizeCountry(country))
+			}
+		}
+	}
+	return pending
+}
+
+// record stores a refresh result, keeping the previous value on failure,
+// and publishes changed values
+func (r *Refresher) record(feed, country string, value any, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if snap, changed := r.store.Record(feed, country, value, err); changed {
+		r.publish(Update{Feed: feed, Country: country, Snapshot: snap})
+	}
+}
+
+// sameContent reports whether two feed values are equal apart from when
+// they were fetched, so a refresh that only bumps Provenance.FetchedAt isn't
+// published as an update
+func sameContent(a, b any) bool {
+	normalize := func(v any) any {
+		body, err := json.Marshal(v)
+		if err != nil {
+			return v
+		}
+		var out any
+		if err := json.Unmarshal(body, &out); err != nil {
+			return v
+		}
+		return withoutFetchedAt(out)
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// withoutFetchedAt deletes every "fetchedAt" key from decoded JSON
+func withoutFetchedAt(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		delete(v, "fetchedAt")
+		for k, child := range v {
+			v[k] = withoutFetchedAt(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = withoutFetchedAt(child)
+		}
+	}
+	return v
+}
+
+// publish sends u to every subscriber interested in its country without
+// blocking; a subscriber whose buffer is full misses the update
+func (r *Refresher) publish(u Update) {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	for sub := range r.subscribers {
+		if !sub.wants(u.Country) {
+			continue
+		}
+		select {
+		case sub.ch <- u:
+		default:
+			logger.Debugf("refresh update %s %s dropped for a slow subscriber", u.Feed, u.Country)
+		}
+	}
+}
+
+func (s *subscriber) wants(country string) bool {
+	if len(s.countries) == 0 || country == "" {
+		return true
+	}
+	_, ok := s.countries[country]
+	return ok
+}
+
+// Subscribe returns a channel of updates for the given countries, or every
+// country when none are given; feeds that aren't per country are always
+// included. The channel first receives the current successful snapshot of each
+// matching feed and then one Update whenever a refresh yields a changed value.
+// Call cancel to unsubscribe, which closes the channel.
+func (r *Refresher) Subscribe(countries ...string) (updates <-chan Update, cancel func()) {
+	sub := &subscriber{countries: make(map[string]struct{}, len(countries))}
+	for _, country := range countries {
+		sub.countries[normalizeCountry(country)] = struct{}{}
+	}
+
+	// Holding mu while registering means no refresh can land between the
+	// current snapshots and the first published update
+	r.mu.RLock()
+	var current []Update
+	r.store.each(func(key snapshotKey, snap Snapshot) {
+		if !snap.FetchedAt.IsZero() && sub.wants(key.country) {
+			current = append(current, Update{Feed: key.feed, Country: key.country, Snapshot: snap})
+		}
+	})
+	sub.ch = make(chan Update, len(current)+subscriberBuffer)
+	for _, u := range current {
+		sub.ch <- u
+	}
+	r.subMu.Lock()
+	r.subscribers[sub] = struct{}{}
+	r.subMu.Unlock()
+	r.mu.RUnlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			r.subMu.Lock()
+			defer r.subMu.Unlock()
+			delete(r.subscribers, sub)
+			close(sub.ch)
+		})
+	}
+}
+
+// Get returns the latest snapshot of a feed for a country ("" for feeds that
+// aren't per country) without blocking on the network. It reports false until
+// the first refresh attempt has finished.
+func (r *Refresher) Get(feed, country string) (Snapshot, bool) {
+	return r.store.Get(feed, country)
+}
+
+// Store returns the Store the Refresher records into, for Snapshot and
+// per-country reads
+func (r *Refresher) Store() *Store {
+	return r.store
+}
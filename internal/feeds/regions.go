@@ -0,0 +1,328 @@
+package feeds
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"reef-asia/internal/logger"
+)
+
+var (
+	// ErrUnknownRegion is returned for subdivision codes outside the built-in set
+	ErrUnknownRegion = errors.New("unknown region")
+	// ErrNoRegions is returned by regional summaries of countries without
+	// built-in regions
+	ErrNoRegions = errors.New("no regions for country")
+)
+
+// Region is a province, state or other first-level subdivision, whose
+// conditions are those at its main city
+type Region struct {
+	// Code is the ISO 3166-2 subdivision code, such as "CN-51"
+	Code    string  `json:"code"`
+	Country string  `json:"country"`
+	Name    string  `json:"name"`
+	City    string  `json:"city"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+// Coordinates returns the coordinates of the region's city
+func (r Region) Coordinates() Coordinates {
+	return Coordinates{Lat: r.Lat, Lon: r.Lon}
+}
+
+// regionEntry is one region of asiaRegions
+type regionEntry struct {
+	name   string
+	city   string
+	coords Coordinates
+}
+
+// asiaRegions maps ISO 3166-2 subdivision codes to each region's main city,
+// most finely for large countries, where one city says little about the
+// rest. China's use the numeric GB/T 2260 codes.
+var asiaRegions = map[string]regionEntry{
+	"JP-13": {"Tokyo", "Tokyo", Coordinates{Lat: 35.6762, Lon: 139.6503}},
+	"JP-27": {"Osaka", "Osaka", Coordinates{Lat: 34.6937, Lon: 135.5023}},
+
+	"CN-11": {"Beijing", "Beijing", Coordinates{Lat: 39.9042, Lon: 116.4074}},
+	"CN-21": {"Liaoning", "Shenyang", Coordinates{Lat: 41.8057, Lon: 123.4315}},
+	"CN-23": {"Heilongjiang", "Harbin", Coordinates{Lat: 45.8038, Lon: 126.5350}},
+	"CN-31": {"Shanghai", "Shanghai", Coordinates{Lat: 31.2304, Lon: 121.4737}},
+	"CN-32": {"Jiangsu", "Nanjing", Coordinates{Lat: 32.0603, Lon: 118.7969}},
+	"CN-33": {"Zhejiang", "Hangzhou", Coordinates{Lat: 30.2741, Lon: 120.1551}},
+	"CN-37": {"Shandong", "Jinan", Coordinates{Lat: 36.6512, Lon: 117.1201}},
+	"CN-42": {"Hubei", "Wuhan", Coordinates{Lat: 30.5928, Lon: 114.3055}},
+	"CN-44": {"Guangdong", "Guangzhou", Coordinates{Lat: 23.1291, Lon: 113.2644}},
+	"CN-46": {"Hainan", "Haikou", Coordinates{Lat: 20.0440, Lon: 110.1999}},
+	"CN-50": {"Chongqing", "Chongqing", Coordinates{Lat: 29.5630, Lon: 106.5516}},
+	"CN-51": {"Sichuan", "Chengdu", Coordinates{Lat: 30.5728, Lon: 104.0668}},
+	"CN-53": {"Yunnan", "Kunming", Coordinates{Lat: 25.0389, Lon: 102.7183}},
+	"CN-54": {"Tibet", "Lhasa", Coordinates{Lat: 29.6520, Lon: 91.1721}},
+	"CN-61": {"Shaanxi", "Xi'an", Coordinates{Lat: 34.3416, Lon: 108.9398}},
+	"CN-65": {"Xinjiang", "Ürümqi", Coordinates{Lat: 43.8256, Lon: 87.6168}},
+
+	"IN-AS": {"Assam", "Guwahati", Coordinates{Lat: 26.1445, Lon: 91.7362}},
+	"IN-BR": {"Bihar", "Patna", Coordinates{Lat: 25.5941, Lon: 85.1376}},
+	"IN-DL": {"Delhi", "Delhi", Coordinates{Lat: 28.6139, Lon: 77.2090}},
+	"IN-GJ": {"Gujarat", "Ahmedabad", Coordinates{Lat: 23.0225, Lon: 72.5714}},
+	"IN-JK": {"Jammu and Kashmir", "Srinagar", Coordinates{Lat: 34.0837, Lon: 74.7973}},
+	"IN-KA": {"Karnataka", "Bengaluru", Coordinates{Lat: 12.9716, Lon: 77.5946}},
+	"IN-KL": {"Kerala", "Thiruvananthapuram", Coordinates{Lat: 8.5241, Lon: 76.9366}},
+	"IN-MH": {"Maharashtra", "Mumbai", Coordinates{Lat: 19.0760, Lon: 72.8777}},
+	"IN-MP": {"Madhya Pradesh", "Bhopal", Coordinates{Lat: 23.2599, Lon: 77.4126}},
+	"IN-PB": {"Punjab", "Ludhiana", Coordinates{Lat: 30.9010, Lon: 75.8573}},
+	"IN-RJ": {"Rajasthan", "Jaipur", Coordinates{Lat: 26.9124, Lon: 75.7873}},
+	"IN-TG": {"Telangana", "Hyderabad", Coordinates{Lat: 17.3850, Lon: 78.4867}},
+	"IN-TN": {"Tamil Nadu", "Chennai", Coordinates{Lat: 13.0827, Lon: 80.2707}},
+	"IN-UP": {"Uttar Pradesh", "Lucknow", Coordinates{Lat: 26.8467, Lon: 80.9462}},
+	"IN-WB": {"West Bengal", "Kolkata", Coordinates{Lat: 22.5726, Lon: 88.3639}},
+
+	"ID-AC": {"Aceh", "Banda Aceh", Coordinates{Lat: 5.5483, Lon: 95.3238}},
+	"ID-BA": {"Bali", "Denpasar", Coordinates{Lat: -8.6500, Lon: 115.2167}},
+	"ID-JB": {"West Java", "Bandung", Coordinates{Lat: -6.9175, Lon: 107.6191}},
+	"ID-JI": {"East Java", "Surabaya", Coordinates{Lat: -7.2575, Lon: 112.7521}},
+	"ID-JK": {"Jakarta", "Jakarta", Coordinates{Lat: -6.2088, Lon: 106.8456}},
+	"ID-JT": {"Central Java", "Semarang", Coordinates{Lat: -6.9667, Lon: 110.4167}},
+	"ID-KI": {"East Kalimantan", "Samarinda", Coordinates{Lat: -0.5022, Lon: 117.1536}},
+	"ID-NT": {"East Nusa Tenggara", "Kupang", Coordinates{Lat: -10.1772, Lon: 123.6070}},
+	"ID-PA": {"Papua", "Jayapura", Coordinates{Lat: -2.5337, Lon: 140.7181}},
+	"ID-SA": {"North Sulawesi", "Manado", Coordinates{Lat: 1.4748, Lon: 124.8421}},
+	"ID-SN": {"South Sulawesi", "Makassar", Coordinates{Lat: -5.1477, Lon: 119.4327}},
+	"ID-SS": {"South Sumatra", "Palembang", Coordinates{Lat: -2.9761, Lon: 104.7754}},
+	"ID-SU": {"North Sumatra", "Medan", Coordinates{Lat: 3.5952, Lon: 98.6722}},
+	"ID-YO": {"Yogyakarta", "Yogyakarta", Coordinates{Lat: -7.7956, Lon: 110.3695}},
+
+	"KR-11": {"Seoul", "Seoul", Coordinates{Lat: 37.5665, Lon: 126.9780}},
+	"TH-10": {"Bangkok", "Bangkok", Coordinates{Lat: 13.7563, Lon: 100.5018}},
+	"MY-14": {"Kuala Lumpur", "Kuala Lumpur", Coordinates{Lat: 3.1390, Lon: 101.6869}},
+	"PH-00": {"Metro Manila", "Metro Manila", Coordinates{Lat: 14.5995, Lon: 120.9842}},
+	"VN-HN": {"Hanoi", "Hanoi", Coordinates{Lat: 21.0278, Lon: 105.8342}},
+	"VN-SG": {"Ho Chi Minh City", "Ho Chi Minh City", Coordinates{Lat: 10.8231, Lon: 106.6297}},
+}
+
+// LookupRegion returns the built-in region for an ISO 3166-2 subdivision
+// code such as "CN-51"; unknown codes return ErrUnknownRegion
+func LookupRegion(code string) (Region, error) {
+	code = normalizeCountry(code)
+	e, ok := asiaRegions[code]
+	if !ok {
+		return Region{}, fmt.Errorf("%w: %q", ErrUnknownRegion, code)
+	}
+	country, _, _ := strings.Cut(code, "-")
+	return Region{Code: code, Country: country, Name: e.name, City: e.city, Lat: e.coords.Lat, Lon: e.coords.Lon}, nil
+}
+
+// Regions returns a country's built-in regions sorted by code, or nil for
+// countries without any
+func Regions(country string) []Region {
+	prefix := normalizeCountry(country) + "-"
+	var regions []Region
+	for _, code := range slices.Sorted(maps.Keys(asiaRegions)) {
+		if strings.HasPrefix(code, prefix) {
+			r, _ := LookupRegion(code)
+			regions = append(regions, r)
+		}
+	}
+	return regions
+}
+
+// RegionCoordinates returns the coordinates for an ISO 3166-2 subdivision
+// code such as "JP-13"
+func RegionCoordinates(region string) (Coordinates, error) {
+	r, err := LookupRegion(region)
+	if err != nil {
+		return Coordinates{}, err
+	}
+	return r.Coordinates(), nil
+}
+
+// FetchWeatherForRegion calls FetchWeatherForRegion on the default Client
+func FetchWeatherForRegion(ctx context.Context, region string) (*WeatherData, error) {
+	return defaultClient.FetchWeatherForRegion(ctx, region)
+}
+
+// FetchWeatherForRegion fetches current conditions for an ISO 3166-2
+// subdivision such as "CN-31"; unknown regions return ErrUnknownRegion
+func (c *Client) FetchWeatherForRegion(ctx context.Context, region string) (*WeatherData, error) {
+	r, err := LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchCurrent(ctx, r.Coordinates())
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, r.City
+	return data, nil
+}
+
+// FetchAirQualityForRegion calls FetchAirQualityForRegion on the default Client
+func FetchAirQualityForRegion(ctx context.Context, region string) (*AirQualityData, error) {
+	return defaultClient.FetchAirQualityForRegion(ctx, region)
+}
+
+// FetchAirQualityForRegion fetches current air quality for an ISO 3166-2
+// subdivision, advising by its country's AQI standard
+func (c *Client) FetchAirQualityForRegion(ctx context.Context, region string) (*AirQualityData, error) {
+	r, err := LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.fetchAirQuality(ctx, r.Coordinates(), r.Country)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, r.City
+	return data, nil
+}
+
+// RegionalSummary is a country's conditions region by region, with the
+// extremes across them
+type RegionalSummary struct {
+	Country string             `json:"country"`
+	Regions []RegionConditions `json:"regions"`
+	// Temperature and AQI range over the regions whose weather or air
+	// quality was fetched, and are nil when none was
+	Temperature *RegionalRange `json:"temperature,omitempty"`
+	AQI         *RegionalRange `json:"aqi,omitempty"`
+}
+
+// RegionalRange is the lowest and highest value across regions, and where
+type RegionalRange struct {
+	Min       float64 `json:"min"`
+	MinRegion string  `json:"minRegion"`
+	Max       float64 `json:"max"`
+	MaxRegion string  `json:"maxRegion"`
+}
+
+// include widens the range to value at region, starting it when nil
+func (r *RegionalRange) include(region string, value float64) *RegionalRange {
+	if r == nil {
+		return &RegionalRange{Min: value, MinRegion: region, Max: value, MaxRegion: region}
+	}
+	if value < r.Min {
+		r.Min, r.MinRegion = value, region
+	}
+	if value > r.Max {
+		r.Max, r.MaxRegion = value, region
+	}
+	return r
+}
+
+// RegionConditions are one region's weather and air quality. A section that
+// failed is left empty and its error recorded, as on CountryCard.
+type RegionConditions struct {
+	Region
+	Weather    *WeatherData    `json:"weather,omitempty"`
+	AirQuality *AirQualityData `json:"airQuality,omitempty"`
+	// Errors holds the error of each failed section, WeatherSection or
+	// AirQualitySection. In JSON they become "errors", describing only the
+	// kind of failure.
+	Errors map[string]error `json:"-"`
+}
+
+// MarshalJSON adds "errors", mapping failed sections to the kind of failure
+func (rc RegionConditions) MarshalJSON() ([]byte, error) {
+	type plain RegionConditions
+	errs := make(map[string]string, len(rc.Errors))
+	for section, err := range rc.Errors {
+		errs[section] = failureKind(err)
+	}
+	return json.Marshal(struct {
+		plain
+		Errors map[string]string `json:"errors,omitempty"`
+	}{plain: plain(rc), Errors: errs})
+}
+
+// FetchRegionalSummary calls FetchRegionalSummary on the default Client
+func FetchRegionalSummary(ctx context.Context, country string) (*RegionalSummary, error) {
+	return defaultClient.FetchRegionalSummary(ctx, country)
+}
+
+// FetchRegionalSummary fetches weather and air quality for every built-in
+// region of a country concurrently, such as CN's provinces or IN's states,
+// with the temperature and AQI extremes among them. Countries without
+// regions return ErrNoRegions. Failed sections are recorded per region, as
+// on CountryCard; only a summary with every fetch failed returns an error.
+func (c *Client) FetchRegionalSummary(ctx context.Context, country string) (*RegionalSummary, error) {
+	code := normalizeCountry(country)
+	if !IsSupportedCountry(code) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	regions := Regions(code)
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoRegions, code)
+	}
+
+	summary := &RegionalSummary{Country: code, Regions: make([]RegionConditions, len(regions))}
+	sem := make(chan struct{}, streamConcurrency)
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	for i, r := range regions {
+		rc := &summary.Regions[i]
+		rc.Region = r
+		sections := map[string]func(context.Context) error{
+			WeatherSection: func(ctx context.Context) (err error) {
+				rc.Weather, err = c.FetchWeatherForRegion(ctx, r.Code)
+				return err
+			},
+			AirQualitySection: func(ctx context.Context) (err error) {
+				rc.AirQuality, err = c.FetchAirQualityForRegion(ctx, r.Code)
+				return err
+			},
+		}
+		for section, fetch := range sections {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+				}
+				// Each section sets only its own field
+				err := ctx.Err()
+				if err == nil {
+					err = fetch(ctx)
+				}
+				if err != nil {
+					logger.Warnf("%sregions %s %s failed: %v", logPrefix(ctx), r.Code, section, err)
+					mu.Lock()
+					defer mu.Unlock()
+					if rc.Errors == nil {
+						rc.Errors = make(map[string]error)
+					}
+					rc.Errors[section] = err
+					errs = append(errs, fmt.Errorf("%s %s: %w", r.Code, section, err))
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	if len(errs) == 2*len(regions) {
+		slices.SortFunc(errs, func(a, b error) int { return cmp.Compare(a.Error(), b.Error()) })
+		return nil, errors.Join(errs...)
+	}
+	for _, rc := range summary.Regions {
+		if rc.Weather != nil {
+			summary.Temperature = summary.Temperature.include(rc.Code, rc.Weather.TemperatureC)
+		}
+		if rc.AirQuality != nil {
+			summary.AQI = summary.AQI.include(rc.Code, float64(rc.AirQuality.AQI))
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,60 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestRegionCoordinates(t *testing.T) {
+	tests := []struct {
+		region  string
+		want    feeds.Coordinates
+		wantErr error
+	}{
+		{region: "JP-13", want: feeds.Coordinates{Lat: 35.6762, Lon: 139.6503}},
+		{region: "cn-31", want: feeds.Coordinates{Lat: 31.2304, Lon: 121.4737}},
+		{region: "JP-99", wantErr: feeds.ErrUnknownRegion},
+		{region: "JP", wantErr: feeds.ErrUnknownRegion},
+	}
+	for _, tt := range tests {
+		got, err := feeds.RegionCoordinates(tt.region)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.region, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: %+v, want %+v", tt.region, got, tt.want)
+		}
+	}
+}
+
+func TestFetchWeatherForRegion(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	c := srv.Client(t)
+
+	data, err := c.FetchWeatherForRegion(context.Background(), "CN-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := srv.Requests()[0].Query()
+	lat, _ := strconv.ParseFloat(q.Get("latitude"), 64)
+	lon, _ := strconv.ParseFloat(q.Get("longitude"), 64)
+	if lat != 31.2304 || lon != 121.4737 {
+		t.Errorf("requested %v,%v, want Shanghai's 31.2304,121.4737", lat, lon)
+	}
+	if !data.Approximate || data.City != "Shanghai" {
+		t.Errorf("Approximate = %v, City = %q, want true, Shanghai", data.Approximate, data.City)
+	}
+
+	if _, err := c.FetchWeatherForRegion(context.Background(), "CN-99"); !errors.Is(err, feeds.ErrUnknownRegion) {
+		t.Errorf("unknown region: err = %v, want ErrUnknownRegion", err)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("%d upstream requests, want none for the unknown region", n)
+	}
+}
@@ -0,0 +1,363 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnknownFeed is returned when fetching a feed name that isn't registered
+	ErrUnknownFeed = errors.New("unknown feed")
+	// ErrDuplicateFeed is returned when registering a name that's already taken
+	ErrDuplicateFeed = errors.New("feed already registered")
+	// ErrInvalidParams is returned when feed parameters are missing or malformed
+	ErrInvalidParams = errors.New("invalid feed parameters")
+)
+
+// Params are the string parameters a Fetcher is called with, such as
+// "country" or "city", so feeds can be driven from query strings
+type Params map[string]string
+
+// Fetcher is a named feed that can be fetched generically
+type Fetcher interface {
+	Name() string
+	Fetch(ctx context.Context, params Params) (any, error)
+}
+
+// FetcherFunc adapts a function to a Fetcher with the given name
+func FetcherFunc(name string, fn func(context.Context, Params) (any, error)) Fetcher {
+	return fetcherFunc{name: name, fn: fn}
+}
+
+type fetcherFunc struct {
+	name string
+	fn   func(context.Context, Params) (any, error)
+}
+
+func (f fetcherFunc) Name() string { return f.name }
+
+func (f fetcherFunc) Fetch(ctx context.Context, params Params) (any, error) {
+	return f.fn(ctx, params)
+}
+
+// Registry holds feeds by name
+type Registry struct {
+	mu    sync.RWMutex
+	feeds map[string]Fetcher
+}
+
+// NewRegistry creates a Registry holding the built-in feeds backed by client,
+// or the default Client when nil:
+//
+//	weather      country, optional city; or region (ISO 3166-2); or lat and lon
+//	forecast     country, optional days (default 7)
+//	nowcast      country, optional city; or lat and lon; optional window (default 2h)
+//	airquality   country, optional city; or region (ISO 3166-2)
+//	pollen       country, optional city; or lat and lon for exact coordinates
+//	daylight     country, optional city
+//	marine       country, optional city; or lat and lon for exact coordinates
+//	history      country, date (YYYY-MM-DD)
+//	typhoons     country
+//	earthquakes  optional minMagnitude (default 4.5), since (RFC 3339, default 24h ago)
+//	fx           optional base (default USD)
+//	holidays     country, optional year (default this year)
+//	news         country
+//	travel       country
+//	markets      optional country (default every tracked index)
+//	clock        optional country (default every supported country)
+//	card         country; weather, air quality, fx, clock and holidays at once
+//	nearest      lat and lon; the closest gazetteer city
+//	regions      country; weather and air quality per region, with extremes
+func NewRegistry(client *Client) *Registry {
+	if client == nil {
+		client = defaultClient
+	}
+	r := &Registry{feeds: make(map[string]Fetcher)}
+	for _, f := range client.builtinFeeds() {
+		r.feeds[f.Name()] = client.tracedFeed(f)
+	}
+	return r
+}
+
+// Register adds a feed; names must be unique
+func (r *Registry) Register(f Fetcher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.feeds[f.Name()]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateFeed, f.Name())
+	}
+	r.feeds[f.Name()] = f
+	return nil
+}
+
+// Unregister removes a feed, such as a built-in one a deployment doesn't serve
+func (r *Registry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.feeds[name]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownFeed, name)
+	}
+	delete(r.feeds, name)
+	return nil
+}
+
+// Names returns the registered feed names in sorted order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.feeds))
+}
+
+// Fetch fetches a registered feed by name
+func (r *Registry) Fetch(ctx context.Context, name string, params Params) (any, error) {
+	r.mu.RLock()
+	f, ok := r.feeds[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFeed, name)
+	}
+	return f.Fetch(ctx, params)
+}
+
+// defaultRegistry holds the built-in feeds for the default Client
+var defaultRegistry = NewRegistry(nil)
+
+// RegisterFeed calls Register on the default Registry
+func RegisterFeed(f Fetcher) error {
+	return defaultRegistry.Register(f)
+}
+
+// FeedNames calls Names on the default Registry
+func FeedNames() []string {
+	return defaultRegistry.Names()
+}
+
+// FetchFeed calls Fetch on the default Registry
+func FetchFeed(ctx context.Context, name string, params Params) (any, error) {
+	return defaultRegistry.Fetch(ctx, name, params)
+}
+
+// builtinFeeds adapts the Client's fetchers to the Fetcher interface
+func (c *Client) builtinFeeds() []Fetcher {
+	return []Fetcher{
+		FetcherFunc("weather", func(ctx context.Context, p Params) (any, error) {
+			coords, ok, err := p.coordinates()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return c.FetchWeatherAt(ctx, coords)
+			}
+			if region := p["region"]; region != "" {
+				return c.FetchWeatherForRegion(ctx, region)
+			}
+			if city := p["city"]; city != "" {
+				return c.FetchWeatherForCity(ctx, p["country"], city)
+			}
+			return c.FetchWeather(ctx, p["country"])
+		}),
+		FetcherFunc("forecast", func(ctx context.Context, p Params) (any, error) {
+			days, err := p.intParam("days", 7)
+			if err != nil {
+				return nil, err
+			}
+			return c.FetchForecast(ctx, p["country"], days)
+		}),
+		FetcherFunc("nowcast", func(ctx context.Context, p Params) (any, error) {
+			window, err := p.durationParam("window", DefaultNowcastWindow)
+			if err != nil {
+				return nil, err
+			}
+			coords, ok, err := p.coordinates()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return c.FetchNowcastAt(ctx, coords, window)
+			}
+			if city := p["city"]; city != "" {
+				return c.FetchNowcastForCity(ctx, p["country"], city, window)
+			}
+			return c.FetchNowcast(ctx, p["country"], window)
+		}),
+		FetcherFunc("airquality", func(ctx context.Context, p Params) (any, error) {
+			if region := p["region"]; region != "" {
+				return c.FetchAirQualityForRegion(ctx, region)
+			}
+			if city := p["city"]; city != "" {
+				return c.FetchAirQualityForCity(ctx, p["country"], city)
+			}
+			return c.FetchAirQuality(ctx, p["country"])
+		}),
+		FetcherFunc("pollen", func(ctx context.Context, p Params) (any, error) {
+			coords, ok, err := p.coordinates()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return c.FetchPollenAt(ctx, coords)
+			}
+			if city := p["city"]; city != "" {
+				return c.FetchPollenForCity(ctx, p["country"], city)
+			}
+			return c.FetchPollen(ctx, p["country"])
+		}),
+		FetcherFunc("daylight", func(ctx context.Context, p Params) (any, error) {
+			if city := p["city"]; city != "" {
+				return c.FetchDaylightForCity(ctx, p["country"], city)
+			}
+			return c.FetchDaylight(ctx, p["country"])
+		}),
+		FetcherFunc("marine", func(ctx context.Context, p Params) (any, error) {
+			coords, ok, err := p.coordinates()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return c.FetchMarineAt(ctx, coords)
+			}
+			if city := p["city"]; city != "" {
+				return c.FetchMarineForCity(ctx, p["country"], city)
+			}
+			return c.FetchMarine(ctx, p["country"])
+		}),
+		FetcherFunc("history", func(ctx context.Context, p Params) (any, error) {
+			date, err := time.Parse(openMeteoDateLayout, p["date"])
+			if err != nil {
+				return nil, fmt.Errorf("%w: date: %w", ErrInvalidParams, err)
+			}
+			return c.FetchHistoricalWeather(ctx, p["country"], date)
+		}),
+		FetcherFunc("typhoons", func(ctx context.Context, p Params) (any, error) {
+			return c.FetchTyphoonWarnings(ctx, p["country"])
+		}),
+		FetcherFunc("earthquakes", func(ctx context.Context, p Params) (any, error) {
+			minMag := 4.5
+			if s := p["minMagnitude"]; s != "" {
+				v, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: minMagnitude: %w", ErrInvalidParams, err)
+				}
+				minMag = v
+			}
+			since := time.Now().Add(-24 * time.Hour)
+			if s := p["since"]; s != "" {
+				t, err := time.Parse(time.RFC3339, s)
+				if err != nil {
+					return nil, fmt.Errorf("%w: since: %w", ErrInvalidParams, err)
+				}
+				since = t
+			}
+			return c.FetchEarthquakes(ctx, minMag, since)
+		}),
+		FetcherFunc("fx", func(ctx context.Context, p Params) (any, error) {
+			base := p["base"]
+			if base == "" {
+				base = "USD"
+			}
+			return c.FetchExchangeRates(ctx, base)
+		}),
+		FetcherFunc("holidays", func(ctx context.Context, p Params) (any, error) {
+			year, err := p.intParam("year", time.Now().Year())
+			if err != nil {
+				return nil, err
+			}
+			return c.FetchHolidays(ctx, p["country"], year)
+		}),
+		FetcherFunc("news", func(ctx context.Context, p Params) (any, error) {
+			return c.FetchHeadlines(ctx, p["country"])
+		}),
+		FetcherFunc("travel", func(ctx context.Context, p Params) (any, error) {
+			return c.FetchTravelAdvisory(ctx, p["country"])
+		}),
+		FetcherFunc("markets", func(ctx context.Context, p Params) (any, error) {
+			if country := p["country"]; country != "" {
+				return c.FetchMarketIndex(ctx, country)
+			}
+			return c.FetchMarketIndices(ctx)
+		}),
+		FetcherFunc("clock", func(ctx context.Context, p Params) (any, error) {
+			if country := p["country"]; country != "" {
+				return c.LocalClock(ctx, country)
+			}
+			return c.LocalClocks(ctx)
+		}),
+		FetcherFunc("card", func(ctx context.Context, p Params) (any, error) {
+			return c.Compose(ctx, p["country"])
+		}),
+		FetcherFunc("nearest", func(ctx context.Context, p Params) (any, error) {
+			lat, err := p.floatParam("lat")
+			if err != nil {
+				return nil, err
+			}
+			lon, err := p.floatParam("lon")
+			if err != nil {
+				return nil, err
+			}
+			return ResolveNearest(lat, lon)
+		}),
+		FetcherFunc("regions", func(ctx context.Context, p Params) (any, error) {
+			return c.FetchRegionalSummary(ctx, p["country"])
+		}),
+	}
+}
+
+// coordinates parses lat and lon, reporting false when neither is given so
+// the country is used instead
+func (p Params) coordinates() (Coordinates, bool, error) {
+	if p["lat"] == "" && p["lon"] == "" {
+		return Coordinates{}, false, nil
+	}
+	lat, err := p.floatParam("lat")
+	if err != nil {
+		return Coordinates{}, true, err
+	}
+	lon, err := p.floatParam("lon")
+	if err != nil {
+		return Coordinates{}, true, err
+	}
+	return Coordinates{Lat: lat, Lon: lon}, true, nil
+}
+
+// floatParam parses a required float parameter
+func (p Params) floatParam(key string) (float64, error) {
+	v, err := strconv.ParseFloat(p[key], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", ErrInvalidParams, key, err)
+	}
+	return v, nil
+}
+
+// durationParam parses an optional duration parameter such as "90m"
+func (p Params) durationParam(key string, fallback time.Duration) (time.Duration, error) {
+	s, ok := p[key]
+	if !ok || s == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", ErrInvalidParams, key, err)
+	}
+	return d, nil
+}
+
+// intParam parses an optional integer parameter
+func (p Params) intParam(key string, fallback int) (int, error) {
+	s, ok := p[key]
+	if !ok || s == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", ErrInvalidParams, key, err)
+	}
+	return n, nil
+}
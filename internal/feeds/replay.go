@@ -0,0 +1,66 @@
+package feeds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"reef-asia/internal/logger"
+)
+
+// ErrNoReplay is returned in replay mode when no recording exists for a request
+var ErrNoReplay = errors.New("no recorded response")
+
+// WithRecordDir saves every successful API response body to dir so it can
+// later be served with WithReplayDir
+func WithRecordDir(dir string) Option {
+	return func(c *Client) {
+		c.recordDir = dir
+	}
+}
+
+// WithReplayDir serves API responses from recordings in dir instead of the
+// network, marking results Replayed in their Provenance. Requests without a
+// recording fail with ErrNoReplay.
+func WithReplayDir(dir string) Option {
+	return func(c *Client) {
+		c.replayDir = dir
+	}
+}
+
+// recordingName returns the file name for a request; the URL encodes the
+// coordinates and parameters, so each country and query gets its own file
+func recordingName(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:8]) + ".json"
+}
+
+// record saves body for url; failures are logged rather than failing the fetch
+func (c *Client) record(ctx context.Context, url string, body []byte) {
+	if err := os.MkdirAll(c.recordDir, 0o755); err != nil {
+		logger.Warnf("%srecord %s: %v", logPrefix(ctx), url, err)
+		return
+	}
+	path := filepath.Join(c.recordDir, recordingName(url))
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		logger.Warnf("%srecord %s: %v", logPrefix(ctx), url, err)
+	}
+}
+
+// replay returns the recorded body for url
+func (c *Client) replay(ctx context.Context, url string) ([]byte, error) {
+	path := filepath.Join(c.replayDir, recordingName(url))
+	body, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s", ErrNoReplay, url)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read recording: %w", err)
+	}
+	logger.Infof("%sserving replayed response %s for %s", logPrefix(ctx), path, url)
+	return body, nil
+}
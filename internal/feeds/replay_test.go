@@ -0,0 +1,44 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorded, err := feedstest.NewServer(t).Client(t, feeds.WithRecordDir(dir)).FetchWeather(ctx, "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded.Replayed {
+		t.Error("recorded result marked Replayed")
+	}
+
+	offline := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("request to %s with the network disabled", req.URL)
+		return nil, errors.New("network disabled")
+	})
+	c := stubClient(t, offline, feeds.WithReplayDir(dir))
+	replayed, err := c.FetchWeather(ctx, "JP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replayed.Replayed {
+		t.Error("replayed result not marked Replayed")
+	}
+	if replayed.TemperatureC != recorded.TemperatureC || replayed.Summary != recorded.Summary {
+		t.Errorf("replayed %.1f°C %q, recorded %.1f°C %q", replayed.TemperatureC, replayed.Summary, recorded.TemperatureC, recorded.Summary)
+	}
+
+	if _, err := c.FetchWeather(ctx, "SG"); !errors.Is(err, feeds.ErrNoReplay) {
+		t.Errorf("unrecorded country: err = %v, want ErrNoReplay", err)
+	}
+}
@@ -0,0 +1,26 @@
+package feeds
+
+import "context"
+
+// requestIDKey is the context key for the caller's request ID
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a context carrying id, which the Client adds
+// to its log lines and sends upstream as X-Request-ID
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set by ContextWithRequestID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// logPrefix tags Client log lines with the request ID when there is one
+func logPrefix(ctx context.Context) string {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return "[feeds] request_id=" + id + " "
+	}
+	return "[feeds] "
+}
@@ -0,0 +1,50 @@
+package feeds_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+	"reef-asia/internal/logger"
+)
+
+func TestRequestIDInLogsAndHeader(t *testing.T) {
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	defer logger.SetLevel(logger.GetLevel())
+	log.SetOutput(&logs)
+	logger.SetLevel("debug")
+
+	srv := feedstest.NewServer(t)
+	var sent []string
+	upstream := srv.Transport()
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		sent = append(sent, req.Header.Get("X-Request-ID"))
+		return upstream.RoundTrip(req)
+	})
+	c := srv.Client(t, feeds.WithTransport(rt))
+
+	ctx := feeds.ContextWithRequestID(context.Background(), "req-8f2c")
+	if _, err := c.FetchWeather(ctx, "JP"); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || sent[0] != "req-8f2c" {
+		t.Errorf("X-Request-ID sent %q, want [req-8f2c]", sent)
+	}
+	var tagged int
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		if strings.Contains(line, "[feeds] request_id=req-8f2c ") {
+			tagged++
+		} else if strings.Contains(line, "[feeds]") {
+			t.Errorf("log line without the request ID: %s", line)
+		}
+	}
+	if tagged == 0 {
+		t.Errorf("no log line carries the request ID:\n%s", logs.String())
+	}
+}
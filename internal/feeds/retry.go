@@ -0,0 +1,187 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// RetryPolicy controls how failed upstream requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries; 1 disables retries
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubling for each
+	// later one up to MaxDelay; each wait is jittered between zero and the backoff
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// RetryableStatus lists HTTP statuses worth retrying; network errors
+	// are always retried, DNS failures included, except for hosts DNS
+	// reports don't exist
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy makes three attempts, retrying rate limiting and
+// transient server errors
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	BaseDelay:       200 * time.Millisecond,
+	MaxDelay:        2 * time.Second,
+	RetryableStatus: []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy for every upstream request. A
+// Retry-After header on a retryable response is honored in place of the backoff.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// RetryBudget caps retries across every request of a Client, so an outage
+// doesn't multiply load by the retry attempts of each concurrent request.
+// It's a token bucket: each retry takes a token, and once they run out
+// failed requests return their error without retrying.
+type RetryBudget struct {
+	// PerSecond is the sustained retries per second
+	PerSecond float64
+	// Burst is how many retries may go at once after a quiet spell (zero means 1)
+	Burst int
+}
+
+// WithRetryBudget limits retries across the Client to budget; there is no
+// budget by default, so each request retries up to its RetryPolicy
+func WithRetryBudget(budget RetryBudget) Option {
+	return func(c *Client) {
+		c.retryBudget = &retryBudget{bucket: tokenBucket{
+			limit:  RateLimit{RPS: budget.PerSecond, Burst: budget.Burst, FailFast: true},
+			tokens: float64(max(budget.Burst, 1)),
+			last:   time.Now(),
+		}}
+	}
+}
+
+// retryBudget is the token bucket of a RetryBudget; nil allows every retry
+type retryBudget struct {
+	mu     sync.Mutex
+	bucket tokenBucket
+}
+
+// take reports whether a retry is within the budget, taking a token if so
+func (b *retryBudget) take() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.bucket.reserve(time.Now())
+	return ok
+}
+
+// WithRandSource draws retry jitter from src instead of the runtime's
+// randomly seeded source, e.g. rand.NewPCG(1, 2) for repeatable delays in
+// tests. The Client serializes its use of src; a nil src fails NewClient.
+func WithRandSource(src rand.Source) Option {
+	return func(c *Client) {
+		c.rand = &lockedRand{}
+		if src != nil {
+			c.rand.r = rand.New(src)
+		}
+	}
+}
+
+// lockedRand makes a *rand.Rand safe for concurrent requests
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// jitter returns a wait between zero and backoff inclusive
+func (c *Client) jitter(backoff time.Duration) time.Duration {
+	if c.rand == nil {
+		return rand.N(backoff + 1)
+	}
+	c.rand.mu.Lock()
+	defer c.rand.mu.Unlock()
+	return time.Duration(c.rand.r.Int64N(int64(backoff) + 1))
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(h string) time.Duration {
+	if h == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(h); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// fetchBodyRetrying calls fetchBody under the retry policy. It never waits
+// past ctx's deadline: when the next wait wouldn't end in time it returns
+// the last error straight away.
+func (c *Client) fetchBodyRetrying(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	backoff := c.retry.BaseDelay
+	for attempt := 1; ; attempt++ {
+		body, err := c.fetchBody(ctx, url, maxBytes)
+		if err == nil || attempt >= c.retry.MaxAttempts || !c.retryable(ctx, err) {
+			return body, err
+		}
+		if !c.retryBudget.take() {
+			logger.Debugf("%sretry budget exhausted, not retrying GET %s: %v", logPrefix(ctx), url, err)
+			return body, err
+		}
+
+		wait := c.jitter(backoff)
+		var pe *ProviderError
+		if errors.As(err, &pe) && pe.RetryAfter > 0 {
+			wait = pe.RetryAfter
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+		logger.Debugf("%sretrying GET %s in %s (attempt %d): %v", logPrefix(ctx), url, wait, attempt+1, err)
+		if u, err := neturl.Parse(url); err == nil {
+			c.metrics.observeRetry(u.Host)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+		backoff = min(backoff*2, c.retry.MaxDelay)
+	}
+}
+
+// retryable reports whether err is worth another attempt
+func (c *Client) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrResponseTooLarge) {
+		return false
+	}
+	var pe *ProviderError
+	if errors.As(err, &pe) {
+		// A DNS server that's slow or not up yet may answer next time, but
+		// one saying the host doesn't exist won't change its mind
+		var dnsErr *net.DNSError
+		if errors.As(pe.Err, &dnsErr) && dnsErr.IsNotFound && !dnsErr.IsTemporary {
+			return false
+		}
+		return pe.StatusCode == 0 || slices.Contains(c.retry.RetryableStatus, pe.StatusCode)
+	}
+	// The local rate limit won't have a token again straight away
+	return !errors.Is(err, ErrRateLimited)
+}
@@ -0,0 +1,190 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+// fastRetries makes three attempts without waiting noticeably
+var fastRetries = feeds.RetryPolicy{
+	MaxAttempts:     3,
+	BaseDelay:       time.Millisecond,
+	MaxDelay:        time.Millisecond,
+	RetryableStatus: feeds.DefaultRetryPolicy.RetryableStatus,
+}
+
+func TestRetryDNSErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		dnsErr       *net.DNSError
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{name: "temporary", dnsErr: &net.DNSError{Err: "server misbehaving", Name: "api.open-meteo.com", IsTemporary: true}, wantAttempts: 2},
+		{name: "timeout", dnsErr: &net.DNSError{Err: "i/o timeout", Name: "api.open-meteo.com", IsTimeout: true}, wantAttempts: 2},
+		{name: "no such host", dnsErr: &net.DNSError{Err: "no such host", Name: "api.open-meteo.com", IsNotFound: true}, wantAttempts: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := feedstest.NewServer(t).Transport()
+			// The first lookup fails as a dialer would report it
+			var attempts atomic.Int32
+			rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if attempts.Add(1) == 1 {
+					return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: &net.OpError{Op: "dial", Net: "tcp", Err: tt.dnsErr}}
+				}
+				return upstream.RoundTrip(req)
+			})
+			c := stubClient(t, rt, feeds.WithRetryPolicy(fastRetries))
+
+			_, err := c.FetchWeather(context.Background(), "JP")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error: %v", err, tt.wantErr)
+			}
+			if n := attempts.Load(); n != tt.wantAttempts {
+				t.Errorf("%d attempts, want %d", n, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsDeadline(t *testing.T) {
+	const deadline = 200 * time.Millisecond
+	upstream := feedstest.NewServer(t).Transport()
+	tests := []struct {
+		name string
+		// respond answers attempt n, counting from 1
+		respond      func(req *http.Request, n int32) (*http.Response, error)
+		wantAttempts int32
+		wantErr      error
+	}{
+		{
+			name: "flaky within the deadline",
+			respond: func(req *http.Request, n int32) (*http.Response, error) {
+				if n < 3 {
+					return stubResponse(req, http.StatusServiceUnavailable, nil), nil
+				}
+				return upstream.RoundTrip(req)
+			},
+			wantAttempts: 3,
+		},
+		{
+			// The five second wait would end past the deadline, so the 503 is
+			// returned without waiting
+			name: "next wait past the deadline",
+			respond: func(req *http.Request, _ int32) (*http.Response, error) {
+				resp := stubResponse(req, http.StatusServiceUnavailable, nil)
+				resp.Header.Set("Retry-After", "5")
+				return resp, nil
+			},
+			wantAttempts: 1,
+			wantErr:      feeds.ErrProviderUnavailable,
+		},
+		{
+			name: "attempt stalls until the deadline",
+			respond: func(req *http.Request, _ int32) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			},
+			wantAttempts: 1,
+			wantErr:      context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return tt.respond(req, attempts.Add(1))
+			})
+			policy := fastRetries
+			policy.MaxAttempts = 5
+			c := stubClient(t, rt, feeds.WithRetryPolicy(policy))
+
+			ctx, cancel := context.WithTimeout(context.Background(), deadline)
+			defer cancel()
+			start := time.Now()
+			_, err := c.FetchWeather(ctx, "JP")
+			elapsed := time.Since(start)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if n := attempts.Load(); n != tt.wantAttempts {
+				t.Errorf("%d attempts, want %d", n, tt.wantAttempts)
+			}
+			if elapsed > deadline+100*time.Millisecond {
+				t.Errorf("fetch took %v, want it bounded by the %v deadline", elapsed, deadline)
+			}
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	srv := feedstest.NewServer(t)
+	srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusServiceUnavailable, nil)
+	// A budget of two retries that never refills
+	c := srv.Client(t,
+		feeds.WithRetryPolicy(fastRetries),
+		feeds.WithRetryBudget(feeds.RetryBudget{Burst: 2}),
+		feeds.WithCircuitBreaker(feeds.BreakerSettings{}),
+	)
+
+	for i, want := range []int{3, 1, 1} {
+		before := len(srv.Requests())
+		if _, err := c.FetchWeather(context.Background(), "JP"); err == nil {
+			t.Fatalf("fetch %d succeeded against a 503", i+1)
+		}
+		if got := len(srv.Requests()) - before; got != want {
+			t.Errorf("fetch %d made %d attempts, want %d", i+1, got, want)
+		}
+	}
+}
+
+func TestRetryBudgetInvalid(t *testing.T) {
+	_, err := feeds.NewClient(feeds.WithRetryBudget(feeds.RetryBudget{PerSecond: -1}))
+	if !errors.Is(err, feeds.ErrInvalidConfig) {
+		t.Errorf("err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestWithRandSource(t *testing.T) {
+	draw := func(src rand.Source) []time.Duration {
+		t.Helper()
+		c, err := feeds.NewClient(feeds.WithRandSource(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var waits []time.Duration
+		for backoff := 200 * time.Millisecond; backoff <= 2*time.Second; backoff *= 2 {
+			wait := c.Jitter(backoff)
+			if wait < 0 || wait > backoff {
+				t.Errorf("wait %v outside 0 to %v", wait, backoff)
+			}
+			waits = append(waits, wait)
+		}
+		return waits
+	}
+
+	first, second := draw(rand.NewPCG(1, 2)), draw(rand.NewPCG(1, 2))
+	if !slices.Equal(first, second) {
+		t.Errorf("waits from the same seed differ: %v and %v", first, second)
+	}
+	if other := draw(rand.NewPCG(3, 4)); slices.Equal(first, other) {
+		t.Errorf("waits from different seeds are both %v", first)
+	}
+
+	if _, err := feeds.NewClient(feeds.WithRandSource(nil)); !errors.Is(err, feeds.ErrInvalidConfig) {
+		t.Errorf("nil source: err = %v, want ErrInvalidConfig", err)
+	}
+}
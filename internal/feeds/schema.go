@@ -0,0 +1,336 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownSchemaVersion is returned for schema versions this build can't
+// serve
+var ErrUnknownSchemaVersion = errors.New("unknown schema version")
+
+// SchemaVersion is a version of the JSON schema of feed payloads. Fields
+// added after the first version carry a `schema:"N"` tag naming the version
+// that introduced them, and MarshalSchema leaves them out of older versions,
+// so clients pinned to one keep getting exactly the fields they know.
+type SchemaVersion int
+
+const (
+	// SchemaV1 is the payloads as served before versioning, without a
+	// version field
+	SchemaV1 SchemaVersion = 1
+	// SchemaV2 adds the weather narrative and outlook and the air quality
+	// advice, and a "schemaVersion" field to every object payload
+	SchemaV2 SchemaVersion = 2
+
+	// CurrentSchema is the newest version, served when a client doesn't ask
+	// for one
+	CurrentSchema = SchemaV2
+)
+
+// SchemaVersions lists the versions MarshalSchema serves, oldest first
+var SchemaVersions = []SchemaVersion{SchemaV1, SchemaV2}
+
+// ParseSchemaVersion parses a version written like "2" or "v2"
+func ParseSchemaVersion(s string) (SchemaVersion, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "v"))
+	if err != nil || n < int(SchemaV1) || n > int(CurrentSchema) {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownSchemaVersion, s)
+	}
+	return SchemaVersion(n), nil
+}
+
+func (v SchemaVersion) String() string {
+	return "v" + strconv.Itoa(int(v))
+}
+
+// schemaVersionKey is the version field of object payloads from SchemaV2
+const schemaVersionKey = "schemaVersion"
+
+// MarshalSchema marshals v as JSON in the given schema version, dropping
+// fields newer than it. From SchemaV2, a struct payload also gets a leading
+// "schemaVersion" field. Structs with their own MarshalJSON still lose
+// their newer fields, provided it writes them under their usual names;
+// other values that marshal themselves, such as the json.RawMessage values
+// Refresher.Share loads from other replicas, are written as they are.
+func MarshalSchema(v any, version SchemaVersion) ([]byte, error) {
+	if version < SchemaV1 || version > CurrentSchema {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSchemaVersion, version)
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(v)
+	if version < CurrentSchema && hasNewerFields(rv, version) {
+		tree, err := decodeOrdered(body)
+		if err != nil {
+			return nil, err
+		}
+		pruneSchema(tree, rv, version)
+		if body, err = json.Marshal(tree); err != nil {
+			return nil, err
+		}
+	}
+	if version >= SchemaV2 && isStruct(rv) && len(body) > 1 && body[0] == '{' {
+		field := `{"` + schemaVersionKey + `":` + strconv.Itoa(int(version))
+		if body[1] != '}' {
+			field += ","
+		}
+		body = append([]byte(field), body[1:]...)
+	}
+	return body, nil
+}
+
+// fieldSchema returns the version that introduced a struct field, SchemaV1
+// for untagged ones
+func fieldSchema(f reflect.StructField) SchemaVersion {
+	n, err := strconv.Atoi(f.Tag.Get("schema"))
+	if err != nil {
+		return SchemaV1
+	}
+	return SchemaVersion(n)
+}
+
+var (
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// opaque reports whether values of t are written as they are: t, or a
+// pointer to it, marshals itself and isn't a struct
+func opaque(t reflect.Type) bool {
+	if t.Kind() == reflect.Struct {
+		return false
+	}
+	return t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// indirect follows pointers and interfaces to the value they hold, which
+// is invalid for nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isStruct(v reflect.Value) bool {
+	v = indirect(v)
+	return v.IsValid() && v.Kind() == reflect.Struct
+}
+
+// hasNewerFields reports whether v holds a struct field newer than version,
+// so most payloads skip the decode that pruning needs
+func hasNewerFields(v reflect.Value, version SchemaVersion) bool {
+	v = indirect(v)
+	if !v.IsValid() || opaque(v.Type()) {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			if !f.IsExported() && !f.Anonymous {
+				continue
+			}
+			if fieldSchema(f) > version || hasNewerFields(v.Field(i), version) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if hasNewerFields(v.Index(i), version) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for it := v.MapRange(); it.Next(); {
+			if hasNewerFields(it.Value(), version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pruneSchema removes the fields of v newer than version from node, the
+// decoded JSON of v
+func pruneSchema(node any, v reflect.Value, version SchemaVersion) {
+	v = indirect(v)
+	if !v.IsValid() || opaque(v.Type()) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		obj, ok := node.(*orderedObject)
+		if !ok {
+			return
+		}
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" || (!f.IsExported() && !f.Anonymous) {
+				continue
+			}
+			if f.Anonymous && name == "" && indirect(v.Field(i)).Kind() == reflect.Struct {
+				// Promoted fields share the outer object
+				pruneSchema(obj, v.Field(i), version)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if fieldSchema(f) > version {
+				obj.delete(name)
+				continue
+			}
+			if child, ok := obj.get(name); ok {
+				pruneSchema(child, v.Field(i), version)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := node.([]any)
+		if !ok {
+			return
+		}
+		for i := range min(v.Len(), len(items)) {
+			pruneSchema(items[i], v.Index(i), version)
+		}
+	case reflect.Map:
+		obj, ok := node.(*orderedObject)
+		if !ok {
+			return
+		}
+		for it := v.MapRange(); it.Next(); {
+			if child, ok := obj.get(mapKey(it.Key())); ok {
+				pruneSchema(child, it.Value(), version)
+			}
+		}
+	}
+}
+
+// mapKey returns the JSON object key encoding/json writes for a map key
+func mapKey(k reflect.Value) string {
+	if k.CanInterface() {
+		if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+			text, _ := tm.MarshalText()
+			return string(text)
+		}
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10)
+	default:
+		return k.String()
+	}
+}
+
+// orderedObject is a decoded JSON object that keeps its key order, so a
+// pruned payload reads like the original
+type orderedObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *orderedObject) get(key string) (any, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+func (o *orderedObject) delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrdered decodes JSON with objects as *orderedObject and numbers as
+// json.Number, so re-encoding changes nothing but what's pruned
+func decodeOrdered(body []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	return decodeValue(dec)
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := &orderedObject{values: make(map[string]any)}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := obj.values[key]; !dup {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = value
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		items := []any{}
+		for dec.More() {
+			item, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+	default:
+		return tok, nil
+	}
+}
@@ -0,0 +1,81 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// onceLease is how long leadership and shared values of feeds refreshed
+// only once last
+const onceLease = time.Minute
+
+// Coordinator lets replicas of a Refresher share its work: per feed, the
+// replica holding a lock refreshes from upstream and shares the values, and
+// the others read them. RedisCache is a Coordinator.
+type Coordinator interface {
+	// TryLock takes or extends the lock name for ttl, reporting whether
+	// this replica holds it
+	TryLock(ctx context.Context, name string, ttl time.Duration) (bool, error)
+	GetBytes(ctx context.Context, key string) ([]byte, bool, error)
+	SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Share coordinates the Refresher with replicas sharing coord, so each feed
+// is fetched upstream by one of them. A replica that doesn't lead a feed
+// records the leader's JSON values as json.RawMessage, and fetches itself
+// while none are shared or coord fails. Call it before Run.
+func (r *Refresher) Share(coord Coordinator) {
+	r.coord = coord
+}
+
+// lease is how long a replica leads spec and its shared values last: two
+// intervals, so one late refresh doesn't hand leadership over
+func lease(spec RefreshSpec) time.Duration {
+	if spec.Interval <= 0 {
+		return onceLease
+	}
+	return 2 * spec.Interval
+}
+
+// leads reports whether this replica should refresh spec from upstream.
+// Without a Coordinator, or when it fails, every replica does.
+func (r *Refresher) leads(ctx context.Context, spec RefreshSpec) bool {
+	if r.coord == nil {
+		return true
+	}
+	ok, err := r.coord.TryLock(ctx, "refresh:"+spec.Feed, lease(spec))
+	if err != nil {
+		logger.Warnf("%srefresh %s: leader lock failed, fetching anyway: %v", logPrefix(ctx), spec.Feed, err)
+		return true
+	}
+	return ok
+}
+
+// sharedKey is the Coordinator key of a feed's shared value
+func sharedKey(feed, country string) string {
+	return "feed:" + feed + ":" + country
+}
+
+// loadShared returns the value the leader shared for a feed
+func (r *Refresher) loadShared(ctx context.Context, feed, country string) (json.RawMessage, bool) {
+	body, ok, err := r.coord.GetBytes(ctx, sharedKey(feed, country))
+	if err != nil {
+		logger.Warnf("%srefresh %s %s: reading shared value failed: %v", logPrefix(ctx), feed, country, err)
+		return nil, false
+	}
+	return body, ok
+}
+
+// share publishes a value this replica refreshed as leader
+func (r *Refresher) share(ctx context.Context, spec RefreshSpec, country string, value any) {
+	body, err := json.Marshal(value)
+	if err == nil {
+		err = r.coord.SetBytes(ctx, sharedKey(spec.Feed, country), body, lease(spec))
+	}
+	if err != nil {
+		logger.Warnf("%srefresh %s %s: sharing value failed: %v", logPrefix(ctx), spec.Feed, country, err)
+	}
+}
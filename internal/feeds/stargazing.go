@@ -0,0 +1,97 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Thresholds for GoodStargazing: an hour is clear when its cloud cover is at
+// most StargazingMaxCloudCover percent and its weather group is GroupClear,
+// and a night is good when at least StargazingMinClearShare of its hours are
+// clear
+var (
+	StargazingMaxCloudCover = 20.0
+	StargazingMinClearShare = 0.5
+)
+
+// stargazingResponse represents the hourly cloud cover and sun times for tonight
+type stargazingResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Hourly               struct {
+		Time        []string   `json:"time"`
+		CloudCover  []*float64 `json:"cloud_cover"`
+		WeatherCode []*int     `json:"weather_code"`
+	} `json:"hourly"`
+	Daily struct {
+		Sunrise []string `json:"sunrise"`
+		Sunset  []string `json:"sunset"`
+	} `json:"daily"`
+}
+
+// GoodStargazing calls GoodStargazing on the default Client
+func GoodStargazing(ctx context.Context, country string) (bool, error) {
+	return defaultClient.GoodStargazing(ctx, country)
+}
+
+// GoodStargazing reports whether tonight, from today's sunset to tomorrow's
+// sunrise, looks clear enough for stargazing in a given country
+func (c *Client) GoodStargazing(ctx context.Context, country string) (bool, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return false, err
+	}
+
+	url, err := c.forecastURL(ctx, coords, "hourly=cloud_cover,weather_code&daily=sunrise,sunset&timezone=auto&forecast_days=2")
+	if err != nil {
+		return false, err
+	}
+
+	var apiResp stargazingResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return false, err
+	}
+	return goodStargazing(&apiResp)
+}
+
+// goodStargazing counts the clear hours starting between sunset and the next
+// sunrise; hours with missing values count as not clear
+func goodStargazing(apiResp *stargazingResponse) (bool, error) {
+	if len(apiResp.Daily.Sunset) == 0 || len(apiResp.Daily.Sunrise) < 2 {
+		return false, fmt.Errorf("%w: missing sunset/next sunrise", ErrDecode)
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+
+	sunset, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Daily.Sunset[0], loc)
+	if err != nil {
+		return false, fmt.Errorf("%w: sunset: %w", ErrDecode, err)
+	}
+	sunrise, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Daily.Sunrise[1], loc)
+	if err != nil {
+		return false, fmt.Errorf("%w: sunrise: %w", ErrDecode, err)
+	}
+
+	h := apiResp.Hourly
+	var night, clear int
+	n := min(len(h.Time), len(h.CloudCover), len(h.WeatherCode))
+	for i := 0; i < n; i++ {
+		start, err := time.ParseInLocation(openMeteoTimeLayout, h.Time[i], loc)
+		if err != nil {
+			return false, fmt.Errorf("%w: hourly time: %w", ErrDecode, err)
+		}
+		if start.Before(sunset) || !start.Before(sunrise) {
+			continue
+		}
+		night++
+		if cc, code := h.CloudCover[i], h.WeatherCode[i]; cc != nil && code != nil &&
+			*cc <= StargazingMaxCloudCover && WeatherGroupFor(*code) == GroupClear {
+			clear++
+		}
+	}
+	if night == 0 {
+		return false, fmt.Errorf("%w: no hourly data overnight", ErrDecode)
+	}
+	return float64(clear) >= StargazingMinClearShare*float64(night), nil
+}
@@ -0,0 +1,69 @@
+package feeds_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+// tonight is a Bangkok forecast from 16:00 to 08:00 with sunset at 18:00 and
+// sunrise at 06:00. The first clearHours of the twelve night hours are clear;
+// the rest of the night and both daytime ends are overcast.
+func tonight(clearHours int) string {
+	var times []string
+	var cloud []float64
+	var codes []int
+	for i := range 17 {
+		hour := 16 + i
+		day := 15 + hour/24
+		times = append(times, fmt.Sprintf("2025-01-%dT%02d:00", day, hour%24))
+		night := hour >= 18 && hour < 30
+		if night && hour-18 < clearHours {
+			cloud, codes = append(cloud, 5), append(codes, 0)
+		} else {
+			cloud, codes = append(cloud, 90), append(codes, 3)
+		}
+	}
+	hourly, _ := json.Marshal(map[string]any{"time": times, "cloud_cover": cloud, "weather_code": codes})
+	return `{"timezone":"Asia/Bangkok","timezone_abbreviation":"+07","utc_offset_seconds":25200,"hourly":` + string(hourly) +
+		`,"daily":{"sunrise":["2025-01-15T06:40","2025-01-16T06:00"],"sunset":["2025-01-15T18:00","2025-01-16T18:00"]}}`
+}
+
+func TestGoodStargazing(t *testing.T) {
+	tests := []struct {
+		name       string
+		clearHours int
+		want       bool
+	}{
+		{name: "clear overnight", clearHours: 12, want: true},
+		{name: "clear half the night", clearHours: 6, want: true},
+		{name: "clouding over early", clearHours: 5, want: false},
+		{name: "overcast", clearHours: 0, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := forecastServer(t, tonight(tt.clearHours))
+			got, err := srv.Client(t).GoodStargazing(context.Background(), "TH")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("GoodStargazing = %v, want %v", got, tt.want)
+			}
+			if q := srv.Requests()[0].Query(); q.Get("hourly") != "cloud_cover,weather_code" {
+				t.Errorf("hourly = %q, want cloud_cover,weather_code", q.Get("hourly"))
+			}
+		})
+	}
+}
+
+func TestGoodStargazingWithoutSunTimes(t *testing.T) {
+	noDaily := `{"hourly":{"time":["2025-01-15T20:00"],"cloud_cover":[0],"weather_code":[0]}}`
+	if _, err := forecastServer(t, noDaily).Client(t).GoodStargazing(context.Background(), "TH"); !errors.Is(err, feeds.ErrDecode) {
+		t.Errorf("err = %v, want ErrDecode", err)
+	}
+}
@@ -0,0 +1,110 @@
+package feeds
+
+import (
+	"sync"
+	"time"
+)
+
+// Store holds the latest value of every feed per country, safe for
+// concurrent readers while a Refresher or other writer records results.
+// Values are shared with every reader, so treat them as read-only.
+type Store struct {
+	mu        sync.RWMutex
+	snapshots map[snapshotKey]Snapshot
+}
+
+// StoreSnapshot is a consistent copy of a Store at one moment, holding every
+// feed that has a value
+type StoreSnapshot struct {
+	TakenAt time.Time `json:"takenAt"`
+	// Countries maps country codes to their feeds' snapshots by feed name
+	Countries map[string]map[string]Snapshot `json:"countries"`
+	// Global holds the feeds that aren't per country, by feed name
+	Global map[string]Snapshot `json:"global"`
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{snapshots: make(map[snapshotKey]Snapshot)}
+}
+
+// Record stores the result of fetching a feed for a country ("" for feeds
+// that aren't per country). A failure keeps the previous value and only
+// sets Err. It returns the stored snapshot and whether its value changed,
+// ignoring fetch times, as Refresher publishes only those.
+func (s *Store) Record(feed, country string, value any, err error) (Snapshot, bool) {
+	key := snapshotKey{feed: feed, country: normalizeCountry(country)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := s.snapshots[key]
+	snap.Err = err
+	changed := false
+	if err == nil {
+		changed = snap.FetchedAt.IsZero() || !sameContent(snap.Value, value)
+		snap.Value, snap.FetchedAt = value, time.Now()
+	}
+	s.snapshots[key] = snap
+	return snap, changed
+}
+
+// Get returns the latest snapshot of a feed for a country ("" for feeds that
+// aren't per country), reporting false when nothing was recorded for it
+func (s *Store) Get(feed, country string) (Snapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snap, ok := s.snapshots[snapshotKey{feed: feed, country: normalizeCountry(country)}]
+	return snap, ok
+}
+
+// Country returns the snapshots of a country's feeds that have a value, by
+// feed name
+func (s *Store) Country(country string) map[string]Snapshot {
+	country = normalizeCountry(country)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	feeds := make(map[string]Snapshot)
+	for key, snap := range s.snapshots {
+		if key.country == country && !snap.FetchedAt.IsZero() {
+			feeds[key.feed] = snap
+		}
+	}
+	return feeds
+}
+
+// Snapshot copies every feed with a value at once, so a full dashboard can
+// be rendered without results of different refreshes mixing mid-render
+func (s *Store) Snapshot() StoreSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := StoreSnapshot{
+		TakenAt:   time.Now(),
+		Countries: make(map[string]map[string]Snapshot),
+		Global:    make(map[string]Snapshot),
+	}
+	for key, feed := range s.snapshots {
+		if feed.FetchedAt.IsZero() {
+			continue
+		}
+		if key.country == "" {
+			snap.Global[key.feed] = feed
+			continue
+		}
+		if snap.Countries[key.country] == nil {
+			snap.Countries[key.country] = make(map[string]Snapshot)
+		}
+		snap.Countries[key.country][key.feed] = feed
+	}
+	return snap
+}
+
+// each calls fn with every stored snapshot under the read lock
+func (s *Store) each(fn func(key snapshotKey, snap Snapshot)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for key, snap := range s.snapshots {
+		fn(key, snap)
+	}
+}
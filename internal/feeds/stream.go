@@ -0,0 +1,70 @@
+package feeds
+
+import (
+	"context"
+	"sync"
+)
+
+// streamConcurrency bounds how many fetches a stream runs at once
+const streamConcurrency = 8
+
+// WeatherResult is the outcome of fetching one country in a batch
+type WeatherResult struct {
+	// Country is the code as given in the batch
+	Country string
+	// Substitute is the default country fetched in place of an empty or
+	// unknown Country, or "" when Country itself was fetched
+	Substitute string
+	Data       *WeatherData
+	Err        error
+}
+
+// FetchWeatherStream calls FetchWeatherStream on the default Client
+func FetchWeatherStream(ctx context.Context, countries []string) <-chan WeatherResult {
+	return defaultClient.FetchWeatherStream(ctx, countries)
+}
+
+// FetchWeatherStream fetches weather for each country concurrently and emits
+// each result as soon as it completes. Every country is emitted exactly once,
+// then the channel is closed. When ctx is cancelled no further fetches are
+// started: in-flight ones and those not yet started report the context error.
+func (c *Client) FetchWeatherStream(ctx context.Context, countries []string) <-chan WeatherResult {
+	// Buffered for every input so workers never block on a slow or absent reader
+	out := make(chan WeatherResult, len(countries))
+
+	go func() {
+		defer close(out)
+
+		sem := make(chan struct{}, streamConcurrency)
+		var wg sync.WaitGroup
+		for i, country := range countries {
+			if ctx.Err() == nil {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				for _, country := range countries[i:] {
+					out <- WeatherResult{Country: country, Err: err}
+				}
+				break
+			}
+			wg.Add(1)
+			go func(country string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				res := WeatherResult{Country: country}
+				fetch := country
+				if sub, ok := c.batchSubstitute(country); ok {
+					res.Substitute, fetch = sub, sub
+				}
+				res.Data, res.Err = c.FetchWeather(ctx, fetch)
+				out <- res
+			}(country)
+		}
+		wg.Wait()
+	}()
+
+	return out
+}
@@ -0,0 +1,89 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestFetchWeatherStream(t *testing.T) {
+	countries := []string{"JP", "SG", "TH", "XX", "VN"}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		// wantErr is the error every country reports, nil when only XX fails
+		wantErr error
+	}{
+		{name: "all fetched", ctx: context.Background()},
+		{name: "canceled", ctx: canceled, wantErr: context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := feedstest.NewServer(t).Client(t)
+
+			seen := make(map[string]int)
+			for res := range c.FetchWeatherStream(tt.ctx, countries) {
+				seen[res.Country]++
+				switch {
+				case tt.wantErr != nil:
+					if !errors.Is(res.Err, tt.wantErr) {
+						t.Errorf("%s: err = %v, want %v", res.Country, res.Err, tt.wantErr)
+					}
+				case res.Country == "XX":
+					if !errors.Is(res.Err, feeds.ErrUnsupportedCountry) {
+						t.Errorf("XX: err = %v, want ErrUnsupportedCountry", res.Err)
+					}
+				case res.Err != nil || res.Data == nil:
+					t.Errorf("%s: got %v, %v", res.Country, res.Data, res.Err)
+				}
+			}
+			for _, country := range countries {
+				if seen[country] != 1 {
+					t.Errorf("%s emitted %d times, want once", country, seen[country])
+				}
+			}
+			if len(seen) != len(countries) {
+				t.Errorf("emitted %d countries, want %d", len(seen), len(countries))
+			}
+		})
+	}
+}
+
+func TestFetchWeatherStreamDefaultCountry(t *testing.T) {
+	rt := countryTransport(t, map[string]countryWeather{"JP": {TempC: 9.4, Code: 1}, "SG": {TempC: 31, Code: 2}})
+	c := stubClient(t, rt, feeds.WithDefaultCountry("jp"))
+
+	results := make(map[string]feeds.WeatherResult)
+	for res := range c.FetchWeatherStream(context.Background(), []string{"SG", "XX", ""}) {
+		results[res.Country] = res
+	}
+	tests := []struct {
+		country        string
+		wantSubstitute string
+		wantTemp       float64
+	}{
+		{country: "SG", wantTemp: 31},
+		{country: "XX", wantSubstitute: "JP", wantTemp: 9.4},
+		{country: "", wantSubstitute: "JP", wantTemp: 9.4},
+	}
+	for _, tt := range tests {
+		res, ok := results[tt.country]
+		if !ok {
+			t.Errorf("%q: no result", tt.country)
+			continue
+		}
+		if res.Err != nil {
+			t.Errorf("%q: err = %v, want the default country fetched", tt.country, res.Err)
+			continue
+		}
+		if res.Substitute != tt.wantSubstitute || res.Data.TemperatureC != tt.wantTemp {
+			t.Errorf("%q: Substitute = %q, %v°C, want %q, %v°C", tt.country, res.Substitute, res.Data.TemperatureC, tt.wantSubstitute, tt.wantTemp)
+		}
+	}
+}
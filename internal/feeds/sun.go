@@ -0,0 +1,137 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultSafeSunUV is the UV index below which daylight hours are considered
+// safe unless WithSafeSunUVThreshold changes it
+const defaultSafeSunUV = 3.0
+
+// WithSafeSunUVThreshold sets the UV index below which SafeSunWindows
+// considers daylight hours safe (default 3)
+func WithSafeSunUVThreshold(uv float64) Option {
+	return func(c *Client) {
+		c.safeSunUV = uv
+	}
+}
+
+// openMeteoTimeLayout is the local time format Open-Meteo uses with timezone=auto
+const openMeteoTimeLayout = "2006-01-02T15:04"
+
+// TimeRange represents a span of local time from Start (inclusive) to End (exclusive)
+type TimeRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// sunForecastResponse represents the hourly UV and daily sun times from Open-Meteo
+type sunForecastResponse struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Hourly               struct {
+		Time    []string   `json:"time"`
+		UVIndex []*float64 `json:"uv_index"`
+	} `json:"hourly"`
+	Daily struct {
+		Sunrise []string `json:"sunrise"`
+		Sunset  []string `json:"sunset"`
+	} `json:"daily"`
+}
+
+// locations caches loaded IANA zones by name, as every bulk refresh asks for
+// the same few
+var locations sync.Map
+
+// responseLocation resolves the timezone reported by Open-Meteo, falling back
+// to a fixed offset when the IANA database is unavailable
+func responseLocation(name, abbr string, offsetSeconds int) *time.Location {
+	if name != "" {
+		if loc, ok := locations.Load(name); ok {
+			return loc.(*time.Location)
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			locations.Store(name, loc)
+			return loc
+		}
+	}
+	return time.FixedZone(abbr, offsetSeconds)
+}
+
+// SafeSunWindows calls SafeSunWindows on the default Client
+func SafeSunWindows(ctx context.Context, country string) ([]TimeRange, error) {
+	return defaultClient.SafeSunWindows(ctx, country)
+}
+
+// SafeSunWindows returns today's local daylight ranges where the UV index is
+// below the WithSafeSunUVThreshold threshold for a given country
+func (c *Client) SafeSunWindows(ctx context.Context, country string) ([]TimeRange, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := c.forecastURL(ctx, coords, "hourly=uv_index&daily=sunrise,sunset&timezone=auto&forecast_days=1")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp sunForecastResponse
+	if err := c.getJSON(ctx, url, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	return safeSunWindows(&apiResp, c.safeSunUV)
+}
+
+// safeSunWindows merges consecutive low-UV hours, clipped to sunrise and sunset
+func safeSunWindows(apiResp *sunForecastResponse, threshold float64) ([]TimeRange, error) {
+	if len(apiResp.Daily.Sunrise) == 0 || len(apiResp.Daily.Sunset) == 0 {
+		return nil, fmt.Errorf("%w: missing sunrise/sunset", ErrDecode)
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+
+	sunrise, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Daily.Sunrise[0], loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sunrise: %w", ErrDecode, err)
+	}
+	sunset, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Daily.Sunset[0], loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sunset: %w", ErrDecode, err)
+	}
+
+	var windows []TimeRange
+	n := min(len(apiResp.Hourly.Time), len(apiResp.Hourly.UVIndex))
+	for i := 0; i < n; i++ {
+		// Hours with no UV value are treated as unsafe
+		if uv := apiResp.Hourly.UVIndex[i]; uv == nil || *uv >= threshold {
+			continue
+		}
+		start, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Hourly.Time[i], loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: hourly time: %w", ErrDecode, err)
+		}
+		end := start.Add(time.Hour)
+
+		// Only keep the part of the hour when the sun is up
+		if start.Before(sunrise) {
+			start = sunrise
+		}
+		if end.After(sunset) {
+			end = sunset
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		if last := len(windows) - 1; last >= 0 && windows[last].End.Equal(start) {
+			windows[last].End = end
+			continue
+		}
+		windows = append(windows, TimeRange{Start: start, End: end})
+	}
+	return windows, nil
+}
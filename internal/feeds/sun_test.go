@@ -0,0 +1,63 @@
+package feeds_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+// uvCurve is a Tokyo day with UV of 3 or more from 09:00 to 15:00 and no
+// reading at 12:00
+const uvCurve = `{"timezone":"Asia/Tokyo","timezone_abbreviation":"JST","utc_offset_seconds":32400,
+"hourly":{"time":["2025-01-15T05:00","2025-01-15T06:00","2025-01-15T07:00","2025-01-15T08:00","2025-01-15T09:00",
+"2025-01-15T10:00","2025-01-15T11:00","2025-01-15T12:00","2025-01-15T13:00","2025-01-15T14:00","2025-01-15T15:00",
+"2025-01-15T16:00","2025-01-15T17:00","2025-01-15T18:00"],
+"uv_index":[0,0,1,2,4,6,7,null,5,3,2.5,1,0.5,0]},
+"daily":{"sunrise":["2025-01-15T06:30"],"sunset":["2025-01-15T17:45"]}}`
+
+func TestSafeSunWindows(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	at := func(hour, minute int) time.Time { return time.Date(2025, 1, 15, hour, minute, 0, 0, jst) }
+
+	tests := []struct {
+		name    string
+		options []feeds.Option
+		want    []feeds.TimeRange
+	}{
+		{
+			name: "default threshold",
+			want: []feeds.TimeRange{{Start: at(6, 30), End: at(9, 0)}, {Start: at(15, 0), End: at(17, 45)}},
+		},
+		{
+			name:    "higher threshold",
+			options: []feeds.Option{feeds.WithSafeSunUVThreshold(5)},
+			want:    []feeds.TimeRange{{Start: at(6, 30), End: at(10, 0)}, {Start: at(14, 0), End: at(17, 45)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			srv.Handle("https://api.open-meteo.com/v1/forecast", http.StatusOK, []byte(uvCurve))
+
+			windows, err := srv.Client(t, tt.options...).SafeSunWindows(context.Background(), "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(windows) != len(tt.want) {
+				t.Fatalf("windows = %v, want %v", windows, tt.want)
+			}
+			for i, w := range windows {
+				if !w.Start.Equal(tt.want[i].Start) || !w.End.Equal(tt.want[i].End) {
+					t.Errorf("window %d = %v to %v, want %v to %v", i, w.Start, w.End, tt.want[i].Start, tt.want[i].End)
+				}
+				if _, offset := w.Start.Zone(); offset != 9*60*60 {
+					t.Errorf("window %d starts at %v, want Tokyo time", i, w.Start)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package feeds
+
+import (
+	"fmt"
+	"math"
+)
+
+// TemperatureUnit identifies the scale a Temperature is expressed in
+type TemperatureUnit int
+
+const (
+	Celsius TemperatureUnit = iota
+	Fahrenheit
+	Kelvin
+)
+
+// Symbol returns the display symbol for the unit
+func (u TemperatureUnit) Symbol() string {
+	switch u {
+	case Fahrenheit:
+		return "°F"
+	case Kelvin:
+		return "K"
+	default:
+		return "°C"
+	}
+}
+
+// Temperature is a value tagged with its unit so it can't be mistaken for another scale
+type Temperature struct {
+	Value float64
+	Unit  TemperatureUnit
+}
+
+// C returns the temperature in degrees Celsius
+func (t Temperature) C() float64 {
+	switch t.Unit {
+	case Fahrenheit:
+		return (t.Value - 32) * 5 / 9
+	case Kelvin:
+		return t.Value - 273.15
+	default:
+		return t.Value
+	}
+}
+
+// F returns the temperature in degrees Fahrenheit
+func (t Temperature) F() float64 {
+	if t.Unit == Fahrenheit {
+		return t.Value
+	}
+	return t.C()*9/5 + 32
+}
+
+// K returns the temperature in kelvin
+func (t Temperature) K() float64 {
+	if t.Unit == Kelvin {
+		return t.Value
+	}
+	return t.C() + 273.15
+}
+
+// String formats the temperature in its own unit rounded half away from
+// zero to one decimal, e.g. "24.3°C"
+func (t Temperature) String() string {
+	v := math.Round(t.Value*10) / 10
+	if v == 0 {
+		v = 0 // avoid "-0.0"
+	}
+	return fmt.Sprintf("%.1f%s", v, t.Unit.Symbol())
+}
+
+// Units selects the measurement system values are displayed in
+type Units int
+
+const (
+	Metric Units = iota
+	Imperial
+)
+
+// FormatTemperature formats a Celsius value for display in units, e.g.
+// "24.3°C" for Metric or "75.7°F" for Imperial
+func FormatTemperature(celsius float64, units Units) string {
+	t := Temperature{Value: celsius, Unit: Celsius}
+	if units == Imperial {
+		t = Temperature{Value: t.F(), Unit: Fahrenheit}
+	}
+	return t.String()
+}
+
+// FormatTemperature formats TemperatureC for display in units
+func (w *WeatherData) FormatTemperature(units Units) string {
+	return FormatTemperature(w.TemperatureC, units)
+}
+
+// FormatFeelsLike formats FeelsLikeC for display in units
+func (w *WeatherData) FormatFeelsLike(units Units) string {
+	return FormatTemperature(w.FeelsLikeC, units)
+}
+
+// Temperature returns TemperatureC as a typed Temperature
+func (w *WeatherData) Temperature() Temperature {
+	return Temperature{Value: w.TemperatureC, Unit: Celsius}
+}
+
+// FeelsLike returns FeelsLikeC as a typed Temperature
+func (w *WeatherData) FeelsLike() Temperature {
+	return Temperature{Value: w.FeelsLikeC, Unit: Celsius}
+}
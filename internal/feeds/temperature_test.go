@@ -0,0 +1,71 @@
+package feeds_test
+
+import (
+	"math"
+	"testing"
+
+	"reef-asia/internal/feeds"
+)
+
+func TestTemperatureConversions(t *testing.T) {
+	tests := []struct {
+		temp    feeds.Temperature
+		c, f, k float64
+		str     string
+	}{
+		{temp: feeds.Temperature{Value: 100, Unit: feeds.Celsius}, c: 100, f: 212, k: 373.15, str: "100.0°C"},
+		{temp: feeds.Temperature{Value: -40, Unit: feeds.Fahrenheit}, c: -40, f: -40, k: 233.15, str: "-40.0°F"},
+		{temp: feeds.Temperature{Value: 273.15, Unit: feeds.Kelvin}, c: 0, f: 32, k: 273.15, str: "273.2K"},
+		{temp: feeds.Temperature{Value: 24.25, Unit: feeds.Celsius}, c: 24.25, f: 75.65, k: 297.4, str: "24.3°C"},
+		{temp: feeds.Temperature{Value: -0.04, Unit: feeds.Celsius}, c: -0.04, f: 31.928, k: 273.11, str: "0.0°C"},
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			if c, f, k := tt.temp.C(), tt.temp.F(), tt.temp.K(); !near(c, tt.c) || !near(f, tt.f) || !near(k, tt.k) {
+				t.Errorf("C, F, K = %v, %v, %v, want %v, %v, %v", c, f, k, tt.c, tt.f, tt.k)
+			}
+			if s := tt.temp.String(); s != tt.str {
+				t.Errorf("String = %q, want %q", s, tt.str)
+			}
+		})
+	}
+}
+
+func TestWeatherDataTemperature(t *testing.T) {
+	w := &feeds.WeatherData{TemperatureC: 30, FeelsLikeC: 35}
+	if got := w.Temperature(); got.Unit != feeds.Celsius || got.F() != 86 {
+		t.Errorf("Temperature = %v, %v°F, want 30°C, 86°F", got, got.F())
+	}
+	if got := w.FeelsLike(); got.Unit != feeds.Celsius || got.F() != 95 {
+		t.Errorf("FeelsLike = %v, %v°F, want 35°C, 95°F", got, got.F())
+	}
+}
+
+func TestFormatTemperature(t *testing.T) {
+	tests := []struct {
+		celsius float64
+		units   feeds.Units
+		want    string
+	}{
+		{celsius: 24.3, units: feeds.Metric, want: "24.3°C"},
+		{celsius: 24.3, units: feeds.Imperial, want: "75.7°F"},
+		{celsius: 24.25, units: feeds.Metric, want: "24.3°C"},
+		{celsius: -0.04, units: feeds.Metric, want: "0.0°C"},
+		{celsius: -40, units: feeds.Imperial, want: "-40.0°F"},
+		{celsius: 0, units: feeds.Imperial, want: "32.0°F"},
+	}
+	for _, tt := range tests {
+		if got := feeds.FormatTemperature(tt.celsius, tt.units); got != tt.want {
+			t.Errorf("FormatTemperature(%v, %v) = %q, want %q", tt.celsius, tt.units, got, tt.want)
+		}
+	}
+
+	w := &feeds.WeatherData{TemperatureC: 30, FeelsLikeC: 35.55}
+	if got := w.FormatTemperature(feeds.Imperial); got != "86.0°F" {
+		t.Errorf("FormatTemperature = %q, want 86.0°F", got)
+	}
+	if got := w.FormatFeelsLike(feeds.Metric); got != "35.6°C" {
+		t.Errorf("FormatFeelsLike = %q, want 35.6°C", got)
+	}
+}
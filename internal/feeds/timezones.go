@@ -0,0 +1,130 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	// Embed the IANA database so lookups work in minimal container images
+	_ "time/tzdata"
+)
+
+// ErrUnknownTimezone is returned for timezones that don't map to a known country
+var ErrUnknownTimezone = errors.New("unknown timezone")
+
+// IANA timezones for the supported countries
+var asiaCountryTimezones = map[string]string{
+	"JP": "Asia/Tokyo",
+	"CN": "Asia/Shanghai",
+	"IN": "Asia/Kolkata",
+	"SG": "Asia/Singapore",
+	"HK": "Asia/Hong_Kong",
+	"KR": "Asia/Seoul",
+	"TH": "Asia/Bangkok",
+	"ID": "Asia/Jakarta",
+	"MY": "Asia/Kuala_Lumpur",
+	"PH": "Asia/Manila",
+	"VN": "Asia/Ho_Chi_Minh",
+	"TW": "Asia/Taipei",
+	"BD": "Asia/Dhaka",
+	"PK": "Asia/Karachi",
+	"LK": "Asia/Colombo",
+	"NP": "Asia/Kathmandu",
+	"BT": "Asia/Thimphu",
+	"MV": "Indian/Maldives",
+	"MM": "Asia/Yangon",
+	"KH": "Asia/Phnom_Penh",
+	"LA": "Asia/Vientiane",
+	"BN": "Asia/Brunei",
+	"TL": "Asia/Dili",
+	"MN": "Asia/Ulaanbaatar",
+	"KZ": "Asia/Almaty",
+	"UZ": "Asia/Tashkent",
+	"KG": "Asia/Bishkek",
+	"TJ": "Asia/Dushanbe",
+	"TM": "Asia/Ashgabat",
+	"AF": "Asia/Kabul",
+	"IR": "Asia/Tehran",
+	"IQ": "Asia/Baghdad",
+	"AE": "Asia/Dubai",
+	"SA": "Asia/Riyadh",
+	"QA": "Asia/Qatar",
+	"KW": "Asia/Kuwait",
+	"BH": "Asia/Bahrain",
+	"OM": "Asia/Muscat",
+	"JO": "Asia/Amman",
+	"LB": "Asia/Beirut",
+	"MO": "Asia/Macau",
+}
+
+// Legacy IANA names still reported by some systems
+var timezoneAliases = map[string]string{
+	"Asia/Calcutta":   "Asia/Kolkata",
+	"Asia/Saigon":     "Asia/Ho_Chi_Minh",
+	"Japan":           "Asia/Tokyo",
+	"Hongkong":        "Asia/Hong_Kong",
+	"Singapore":       "Asia/Singapore",
+	"ROK":             "Asia/Seoul",
+	"ROC":             "Asia/Taipei",
+	"PRC":             "Asia/Shanghai",
+	"Asia/Rangoon":    "Asia/Yangon",
+	"Asia/Katmandu":   "Asia/Kathmandu",
+	"Asia/Dacca":      "Asia/Dhaka",
+	"Asia/Ulan_Bator": "Asia/Ulaanbaatar",
+	"Asia/Thimbu":     "Asia/Thimphu",
+	"Asia/Ashkhabad":  "Asia/Ashgabat",
+	"Asia/Macao":      "Asia/Macau",
+	"Iran":            "Asia/Tehran",
+}
+
+// CountryForTimezone returns the supported country whose timezone is tz
+func CountryForTimezone(tz string) (string, error) {
+	if canonical, ok := timezoneAliases[tz]; ok {
+		tz = canonical
+	}
+	for country, name := range asiaCountryTimezones {
+		if name == tz {
+			return country, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownTimezone, tz)
+}
+
+// FetchWeatherByTimezone calls FetchWeatherByTimezone on the default Client
+func FetchWeatherByTimezone(ctx context.Context, tz string) (*WeatherData, error) {
+	return defaultClient.FetchWeatherByTimezone(ctx, tz)
+}
+
+// FetchWeatherByTimezone fetches weather for the representative city of the
+// country using an IANA timezone such as "Asia/Tokyo"
+func (c *Client) FetchWeatherByTimezone(ctx context.Context, tz string) (*WeatherData, error) {
+	country, err := CountryForTimezone(tz)
+	if err != nil {
+		return nil, err
+	}
+	return c.FetchWeather(ctx, country)
+}
+
+// LocalTime calls LocalTime on the default Client
+func LocalTime(ctx context.Context, country string) (time.Time, *time.Location, error) {
+	return defaultClient.LocalTime(ctx, country)
+}
+
+// LocalTime returns the current local time and IANA location for a supported
+// country. Unknown countries always fail with ErrUnsupportedCountry, since a
+// fallback timezone would silently schedule at the wrong time.
+func (c *Client) LocalTime(ctx context.Context, country string) (time.Time, *time.Location, error) {
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, nil, err
+	}
+	name, ok := asiaCountryTimezones[normalizeCountry(country)]
+	if !ok {
+		return time.Time{}, nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("load timezone %s: %w", name, err)
+	}
+	return time.Now().In(loc), loc, nil
+}
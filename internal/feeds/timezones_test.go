@@ -0,0 +1,71 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestFetchWeatherByTimezone(t *testing.T) {
+	tests := []struct {
+		tz      string
+		wantLat string
+		wantLon string
+		wantErr error
+	}{
+		{tz: "Asia/Singapore", wantLat: "1.3521", wantLon: "103.8198"},
+		{tz: "Asia/Tokyo", wantLat: "35.6762", wantLon: "139.6503"},
+		{tz: "Europe/Paris", wantErr: feeds.ErrUnknownTimezone},
+		{tz: "", wantErr: feeds.ErrUnknownTimezone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tz, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			_, err := srv.Client(t).FetchWeatherByTimezone(context.Background(), tt.tz)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			q := srv.Requests()[0].Query()
+			if lat, lon := q.Get("latitude"), q.Get("longitude"); lat != tt.wantLat || lon != tt.wantLon {
+				t.Errorf("requested %s, %s, want %s, %s", lat, lon, tt.wantLat, tt.wantLon)
+			}
+		})
+	}
+}
+
+func TestCountryForTimezoneAlias(t *testing.T) {
+	if country, err := feeds.CountryForTimezone("Asia/Macao"); err != nil || country != "MO" {
+		t.Errorf("CountryForTimezone(Asia/Macao) = %q, %v, want MO", country, err)
+	}
+}
+
+func TestLocalTime(t *testing.T) {
+	before := time.Now()
+	now, loc, err := feeds.LocalTime(context.Background(), "jp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.String() != "Asia/Tokyo" {
+		t.Errorf("location = %s, want Asia/Tokyo", loc)
+	}
+	if _, offset := now.Zone(); offset != 9*60*60 {
+		t.Errorf("offset = %ds, want UTC+9", offset)
+	}
+	if now.Before(before) || now.Sub(before) > time.Minute {
+		t.Errorf("local time %v isn't about now (%v)", now, before)
+	}
+
+	if _, _, err := feeds.LocalTime(context.Background(), "XX"); !errors.Is(err, feeds.ErrUnsupportedCountry) {
+		t.Errorf("XX: err = %v, want ErrUnsupportedCountry", err)
+	}
+}
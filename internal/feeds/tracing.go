@@ -0,0 +1,113 @@
+package feeds
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the Client's spans
+const tracerName = "reef-asia/internal/feeds"
+
+// Span attributes without an OpenTelemetry semantic convention
+const (
+	feedAttr     = attribute.Key("reef.feed")
+	countryAttr  = attribute.Key("reef.country")
+	providerAttr = attribute.Key("reef.provider")
+	cacheHitAttr = attribute.Key("reef.cache_hit")
+)
+
+// WithTracerProvider records OpenTelemetry spans from tp: one per built-in
+// feed fetch, tagged with the feed, country and whether the weather cache
+// answered, and a client span per upstream request within it, tagged with
+// the provider host and HTTP status. Spans join the caller's trace through
+// ctx. Without it, or with nil, no spans are recorded.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracer = nil
+		if tp != nil {
+			c.tracer = tp.Tracer(tracerName)
+		}
+	}
+}
+
+// spanCountryKey is the context key for the country of the feed span, so
+// upstream request spans can be tagged with it too
+type spanCountryKey struct{}
+
+// startSpan starts a span when tracing is enabled, or else returns ctx
+// with a no-op span
+func (c *Client) startSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if c.tracer == nil {
+		return ctx, noop.Span{}
+	}
+	return c.tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// endSpan marks span failed when err is set, then ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedFeed wraps f in a span named after the feed, or returns it as-is
+// without tracing
+func (c *Client) tracedFeed(f Fetcher) Fetcher {
+	if c.tracer == nil {
+		return f
+	}
+	return FetcherFunc(f.Name(), func(ctx context.Context, p Params) (any, error) {
+		attrs := []attribute.KeyValue{feedAttr.String(f.Name())}
+		if country := p["country"]; country != "" {
+			country = normalizeCountry(country)
+			attrs = append(attrs, countryAttr.String(country))
+			ctx = context.WithValue(ctx, spanCountryKey{}, country)
+		}
+		ctx, span := c.startSpan(ctx, "feeds."+f.Name(), trace.SpanKindInternal, attrs...)
+		value, err := f.Fetch(ctx, p)
+		endSpan(span, err)
+		return value, err
+	})
+}
+
+// setCacheHit tags the current feed span with whether the cache answered
+func (c *Client) setCacheHit(ctx context.Context, hit bool) {
+	if c.tracer != nil {
+		trace.SpanFromContext(ctx).SetAttributes(cacheHitAttr.Bool(hit))
+	}
+}
+
+// startRequestSpan starts the client span of one upstream request. Only the
+// path is recorded, as some queries carry API keys.
+func (c *Client) startRequestSpan(ctx context.Context, req *http.Request) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		providerAttr.String(req.URL.Host),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+		semconv.URLPath(req.URL.Path),
+	}
+	if country, ok := ctx.Value(spanCountryKey{}).(string); ok {
+		attrs = append(attrs, countryAttr.String(country))
+	}
+	return c.startSpan(ctx, req.Method+" "+req.URL.Host, trace.SpanKindClient, attrs...)
+}
+
+// endRequestSpan records the outcome of an upstream request, failing the
+// span on transport errors and error statuses
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	if err == nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	endSpan(span, err)
+}
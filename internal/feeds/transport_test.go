@@ -0,0 +1,58 @@
+package feeds
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTransportOptions(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.corp:3128")
+	tests := []struct {
+		name        string
+		options     []Option
+		wantTLS     time.Duration
+		wantIdle    time.Duration
+		wantPerHost int
+		wantProxy   string
+	}{
+		{name: "defaults", wantTLS: 5 * time.Second, wantIdle: 90 * time.Second, wantPerHost: 10},
+		{
+			name: "configured",
+			options: []Option{
+				WithDialTimeout(time.Second),
+				WithTLSHandshakeTimeout(2 * time.Second),
+				WithIdleConnTimeout(time.Minute),
+				WithMaxIdleConnsPerHost(64),
+				WithProxy(proxy),
+			},
+			wantTLS: 2 * time.Second, wantIdle: time.Minute, wantPerHost: 64, wantProxy: proxy.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The dial timeout lives in the transport's DialContext closure,
+			// so only the settings on http.Transport are checked
+			tr, ok := c.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("transport is %T, want *http.Transport", c.httpClient.Transport)
+			}
+			if tr.TLSHandshakeTimeout != tt.wantTLS || tr.IdleConnTimeout != tt.wantIdle || tr.MaxIdleConnsPerHost != tt.wantPerHost {
+				t.Errorf("TLS handshake %v, idle %v, %d idle per host, want %v, %v, %d",
+					tr.TLSHandshakeTimeout, tr.IdleConnTimeout, tr.MaxIdleConnsPerHost, tt.wantTLS, tt.wantIdle, tt.wantPerHost)
+			}
+			if tt.wantProxy == "" {
+				return
+			}
+			req, _ := http.NewRequest(http.MethodGet, "https://api.open-meteo.com/v1/forecast", nil)
+			if got, err := tr.Proxy(req); err != nil || got.String() != tt.wantProxy {
+				t.Errorf("proxy = %v, %v, want %s", got, err, tt.wantProxy)
+			}
+		})
+	}
+}
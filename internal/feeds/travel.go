@@ -0,0 +1,197 @@
+package feeds
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stateDeptAdvisoriesURL is the U.S. Department of State travel advisories RSS feed
+const stateDeptAdvisoriesURL = "https://travel.state.gov/_res/rss/TAsTWs.xml"
+
+// ErrNoAdvisory is returned for countries the advisory source doesn't cover
+var ErrNoAdvisory = errors.New("no travel advisory for country")
+
+// Advisory is a government travel advisory for a country
+type Advisory struct {
+	Country string `json:"country"`
+	// Level runs from 1, exercise normal precautions, to 4, do not travel
+	Level int `json:"level"`
+	// Summary is the level's wording, e.g. "Exercise Increased Caution"
+	Summary string `json:"summary"`
+	// Details is the advisory text as plain text, usually covering entry
+	// requirements and the risks behind the level
+	Details string `json:"details,omitempty"`
+	URL     string `json:"url,omitempty"`
+	// Updated is when the source last changed the advisory, zero when undated
+	Updated time.Time `json:"updated,omitzero"`
+
+	Provenance
+}
+
+// AdvisorySource is an RSS feed of travel advisories with one item per
+// country, titled like "Japan - Level 1: Exercise Normal Precautions" as
+// the U.S. Department of State publishes them
+type AdvisorySource struct {
+	Name string
+	URL  string
+}
+
+// defaultAdvisorySource is the U.S. Department of State feed
+var defaultAdvisorySource = AdvisorySource{Name: sourceStateDept, URL: stateDeptAdvisoriesURL}
+
+// WithAdvisorySource replaces the U.S. Department of State as the source of
+// travel advisories, e.g. with a mirror or another government's feed in the
+// same format
+func WithAdvisorySource(src AdvisorySource) Option {
+	return func(c *Client) {
+		c.advisorySource = src
+	}
+}
+
+// advisoryNames are the names advisory titles use for the supported
+// countries
+var advisoryNames = map[string][]string{
+	"JP": {"Japan"},
+	"CN": {"China", "Mainland China"},
+	"IN": {"India"},
+	"SG": {"Singapore"},
+	"HK": {"Hong Kong", "Hong Kong SAR"},
+	"KR": {"South Korea", "Korea, South", "Republic of Korea"},
+	"TH": {"Thailand"},
+	"ID": {"Indonesia"},
+	"MY": {"Malaysia"},
+	"PH": {"Philippines", "The Philippines"},
+	"VN": {"Vietnam", "Viet Nam"},
+	"TW": {"Taiwan"},
+	"BD": {"Bangladesh"},
+	"PK": {"Pakistan"},
+	"LK": {"Sri Lanka"},
+	"NP": {"Nepal"},
+	"BT": {"Bhutan"},
+	"MV": {"Maldives"},
+	"MM": {"Burma", "Burma (Myanmar)", "Myanmar"},
+	"KH": {"Cambodia"},
+	"LA": {"Laos"},
+	"BN": {"Brunei"},
+	"TL": {"Timor-Leste", "East Timor"},
+	"MN": {"Mongolia"},
+	"KZ": {"Kazakhstan"},
+	"UZ": {"Uzbekistan"},
+	"KG": {"Kyrgyzstan", "Kyrgyz Republic"},
+	"TJ": {"Tajikistan"},
+	"TM": {"Turkmenistan"},
+	"AF": {"Afghanistan"},
+	"IR": {"Iran"},
+	"IQ": {"Iraq"},
+	"AE": {"United Arab Emirates"},
+	"SA": {"Saudi Arabia"},
+	"QA": {"Qatar"},
+	"KW": {"Kuwait"},
+	"BH": {"Bahrain"},
+	"OM": {"Oman"},
+	"JO": {"Jordan"},
+	"LB": {"Lebanon"},
+	"MO": {"Macau", "Macao", "Macau SAR"},
+}
+
+// advisoryTitle matches "<country> - Level <n>: <summary>"
+var advisoryTitle = regexp.MustCompile(`^(.+?)\s+-\s+Level\s+([1-4])\s*:\s*(.+)$`)
+
+// htmlBlockTag and htmlTag match the markup stripped from advisory
+// descriptions; block tags separate words, inline ones don't
+var (
+	htmlBlockTag = regexp.MustCompile(`(?i)</?(?:p|br|div|li|ul|ol|h[1-6]|tr|td|th|table)\b[^>]*>`)
+	htmlTag      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// advisoryDocument is an RSS 2.0 feed of advisories
+type advisoryDocument struct {
+	Channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// advisorySourceError reports a WithAdvisorySource source without an absolute URL
+func (c *Client) advisorySourceError() error {
+	if u, err := url.Parse(c.advisorySource.URL); err != nil || !u.IsAbs() {
+		return fmt.Errorf("advisory source %q needs an absolute URL, got %q", c.advisorySource.Name, c.advisorySource.URL)
+	}
+	return nil
+}
+
+// FetchTravelAdvisory calls FetchTravelAdvisory on the default Client
+func FetchTravelAdvisory(ctx context.Context, country string) (*Advisory, error) {
+	return defaultClient.FetchTravelAdvisory(ctx, country)
+}
+
+// FetchTravelAdvisory returns the current travel advisory for a supported
+// country from the advisory source. Countries the source has no advisory
+// for return ErrNoAdvisory.
+func (c *Client) FetchTravelAdvisory(ctx context.Context, country string) (*Advisory, error) {
+	code := normalizeCountry(country)
+	if !IsSupportedCountry(code) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	if err := c.injectFault(); err != nil {
+		return nil, err
+	}
+
+	src := c.advisorySource
+	body, err := c.getSharedBody(ctx, src.URL, c.maxForecastBytes)
+	if err != nil {
+		return nil, err
+	}
+	var doc advisoryDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrDecode, src.Name, err)
+	}
+
+	for _, item := range doc.Channel.Items {
+		m := advisoryTitle.FindStringSubmatch(strings.TrimSpace(item.Title))
+		if m == nil || !isAdvisoryName(code, m[1]) {
+			continue
+		}
+		level, _ := strconv.Atoi(m[2])
+		return &Advisory{
+			Country:    code,
+			Level:      level,
+			Summary:    strings.TrimSpace(m[3]),
+			Details:    plainText(item.Description),
+			URL:        strings.TrimSpace(item.Link),
+			Updated:    parseNewsTime(item.PubDate, rssTimeLayouts...),
+			Provenance: c.provenance(src.Name, time.Time{}),
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoAdvisory, code)
+}
+
+// isAdvisoryName reports whether an advisory title names country
+func isAdvisoryName(country, name string) bool {
+	name = strings.TrimSpace(name)
+	for _, n := range advisoryNames[country] {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// plainText strips the markup of an HTML description, collapsing whitespace
+func plainText(s string) string {
+	s = htmlBlockTag.ReplaceAllString(s, " ")
+	s = htmlTag.ReplaceAllString(s, "")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
@@ -0,0 +1,189 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gdacsTropicalCycloneURL lists current tropical cyclone events from GDACS
+const gdacsTropicalCycloneURL = "https://www.gdacs.org/gdacsapi/api/events/geteventlist/SEARCH?eventlist=TC"
+
+// gdacsTimeLayout is the UTC time format of GDACS event dates
+const gdacsTimeLayout = "2006-01-02T15:04:05"
+
+// TyphoonWarning is an active tropical cyclone affecting a country
+type TyphoonWarning struct {
+	Name string `json:"name"`
+	// Category is the JMA classification from the maximum sustained wind,
+	// e.g. "Severe Tropical Storm" or "Typhoon"
+	Category   string  `json:"category"`
+	MaxWindKmh float64 `json:"maxWindKmh"`
+	// AlertLevel is the GDACS impact level: Green, Orange or Red
+	AlertLevel string `json:"alertLevel"`
+	// PathSummary describes the storm and its projected track, as published by GDACS
+	PathSummary string    `json:"pathSummary"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	URL         string    `json:"url,omitempty"`
+
+	Provenance
+}
+
+// gdacsEventList represents the GeoJSON event list returned by GDACS
+type gdacsEventList struct {
+	Features []struct {
+		Properties struct {
+			EventType         string         `json:"eventtype"`
+			Name              string         `json:"eventname"`
+			AlertLevel        string         `json:"alertlevel"`
+			Description       string         `json:"description"`
+			FromDate          string         `json:"fromdate"`
+			ToDate            string         `json:"todate"`
+			IsCurrent         string         `json:"iscurrent"`
+			AffectedCountries []gdacsCountry `json:"affectedcountries"`
+			SeverityData      struct {
+				Severity     float64 `json:"severity"`
+				SeverityUnit string  `json:"severityunit"`
+			} `json:"severitydata"`
+			URL struct {
+				Report string `json:"report"`
+			} `json:"url"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// gdacsCountry is a country affected by a GDACS event
+type gdacsCountry struct {
+	ISO2 string `json:"iso2"`
+}
+
+// jmaCategory classifies a maximum sustained wind in km/h on the JMA scale
+func jmaCategory(windKmh float64) string {
+	switch {
+	case windKmh >= 118:
+		return "Typhoon"
+	case windKmh >= 89:
+		return "Severe Tropical Storm"
+	case windKmh >= 62:
+		return "Tropical Storm"
+	default:
+		return "Tropical Depression"
+	}
+}
+
+// FetchTyphoonWarnings calls FetchTyphoonWarnings on the default Client
+func FetchTyphoonWarnings(ctx context.Context, country string) ([]TyphoonWarning, error) {
+	return defaultClient.FetchTyphoonWarnings(ctx, country)
+}
+
+// FetchTyphoonWarnings returns the active tropical cyclones GDACS lists as
+// affecting a supported country, or none. Unknown countries return
+// ErrUnsupportedCountry regardless of the unknown country policy.
+func (c *Client) FetchTyphoonWarnings(ctx context.Context, country string) ([]TyphoonWarning, error) {
+	code := normalizeCountry(country)
+	if !IsSupportedCountry(code) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+
+	apiResp, err := c.gdacsEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.typhoonWarnings(apiResp, code)
+}
+
+// gdacsEvents returns the GDACS tropical cyclone event list. It's the same
+// whatever the country, so it's kept for the cache TTL and a refresh of
+// every country downloads and decodes it once.
+func (c *Client) gdacsEvents(ctx context.Context) (*gdacsEventList, error) {
+	if events, ok := c.typhoonEvents.get(); ok {
+		return events, nil
+	}
+	var apiResp gdacsEventList
+	if err := c.getJSON(ctx, gdacsTropicalCycloneURL, c.maxForecastBytes, &apiResp); err != nil {
+		return nil, err
+	}
+	if c.cacheTTL > 0 {
+		c.typhoonEvents.set(&apiResp, c.cacheTTL)
+	}
+	return &apiResp, nil
+}
+
+// eventListCache keeps the one GDACS event list in memory. The list is
+// shared read-only between callers, as typhoonWarnings only reads it.
+type eventListCache struct {
+	mu      sync.Mutex
+	events  *gdacsEventList
+	expires time.Time
+}
+
+// get returns the list while unexpired
+func (e *eventListCache) get() (*gdacsEventList, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.events == nil || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.events, true
+}
+
+func (e *eventListCache) set(events *gdacsEventList, ttl time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events, e.expires = events, time.Now().Add(ttl)
+}
+
+// typhoonWarnings picks the current tropical cyclone events affecting code
+func (c *Client) typhoonWarnings(apiResp *gdacsEventList, code string) ([]TyphoonWarning, error) {
+	var warnings []TyphoonWarning
+	for _, f := range apiResp.Features {
+		p := f.Properties
+		if p.EventType != "TC" || strings.EqualFold(p.IsCurrent, "false") {
+			continue
+		}
+		affected := slices.ContainsFunc(p.AffectedCountries, func(a gdacsCountry) bool {
+			return strings.EqualFold(a.ISO2, code)
+		})
+		if !affected {
+			continue
+		}
+
+		w := TyphoonWarning{
+			Name:        p.Name,
+			AlertLevel:  p.AlertLevel,
+			PathSummary: p.Description,
+			URL:         p.URL.Report,
+			Provenance:  c.provenance(sourceGDACS, time.Time{}),
+		}
+		// GDACS reports tropical cyclone severity as maximum sustained wind in km/h
+		if strings.EqualFold(p.SeverityData.SeverityUnit, "km/h") {
+			w.MaxWindKmh = p.SeverityData.Severity
+			w.Category = jmaCategory(w.MaxWindKmh)
+		}
+		var err error
+		if w.From, err = parseGDACSTime(p.FromDate); err != nil {
+			return nil, err
+		}
+		if w.To, err = parseGDACSTime(p.ToDate); err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, w)
+	}
+	return warnings, nil
+}
+
+// parseGDACSTime parses a GDACS event date, leaving empty ones zero
+func parseGDACSTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(gdacsTimeLayout, s, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: GDACS date: %w", ErrDecode, err)
+	}
+	return t, nil
+}
@@ -1,17 +1,110 @@
 package feeds
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
 	"time"
+
+	"reef-asia/internal/logger"
 )
 
+// ErrResponseTooLarge is returned when an API response exceeds the configured size limit
+var ErrResponseTooLarge = errors.New("weather API response too large")
+
 // WeatherData represents weather information
 type WeatherData struct {
-	Summary      string  `json:"summary"`
+	Summary     string `json:"summary"`
+	WeatherCode int    `json:"weatherCode"`
+	// Icon and Severity classify WeatherCode for display; Icon has night
+	// variants only for current conditions, as forecasts use day icons
+	Icon     Icon     `json:"icon"`
+	Severity Severity `json:"severity"`
+	// Locale is the matched locale Summary was translated into, empty for English
+	Locale string `json:"locale,omitempty"`
+	// Narrative sums up the next day in a sentence, such as "Light rain this
+	// afternoon, clearing by evening; highs near 31°C", in the fetch's locale
+	// where it has narrative templates and in English otherwise. Outlook is
+	// what it's written from. Both are empty without WithNarratives or an
+	// hourly forecast, as from fallback providers.
+	Narrative string   `json:"narrative,omitempty" schema:"2"`
+	Outlook   *Outlook `json:"outlook,omitempty" schema:"2"`
+
 	TemperatureC float64 `json:"temperatureC"`
 	FeelsLikeC   float64 `json:"feelsLikeC"`
+
+	WindSpeed     float64       `json:"windSpeed"`
+	WindSpeedUnit WindSpeedUnit `json:"windSpeedUnit"`
+
+	// RelativeHumidity is in percent
+	RelativeHumidity float64 `json:"relativeHumidity"`
+	UVIndex          float64 `json:"uvIndex"`
+	// HeatIndexC is the NWS heat index, the air temperature below 27°C or
+	// when humidity is unknown; HeatAdvisory is its risk category, empty
+	// below 80°F
+	HeatIndexC   float64      `json:"heatIndexC"`
+	HeatAdvisory HeatAdvisory `json:"heatAdvisory,omitempty"`
+
+	// FeelsLikeComputed is true when FeelsLikeC was derived locally because
+	// the API didn't report an apparent temperature
+	FeelsLikeComputed bool `json:"feelsLikeComputed,omitempty"`
+
+	// Approximate is true when the result is for a built-in representative
+	// city rather than the caller's exact coordinates; City names that city
+	Approximate bool   `json:"approximate,omitempty"`
+	City        string `json:"city,omitempty"`
+
+	// WindDirection is in degrees the wind blows from, SurfacePressureHPa in
+	// hectopascals, CloudCover in percent and PrecipitationMm the preceding
+	// hour's rain, showers and snow. They're nil unless requested with
+	// WithWeatherFields.
+	WindDirection      *float64 `json:"windDirection,omitempty"`
+	SurfacePressureHPa *float64 `json:"surfacePressureHPa,omitempty"`
+	CloudCover         *float64 `json:"cloudCover,omitempty"`
+	PrecipitationMm    *float64 `json:"precipitationMm,omitempty"`
+
+	// MissingFields lists expected API fields absent from the response
+	MissingFields []string `json:"missingFields,omitempty"`
+
+	Provenance
+	// Stale is true when the data is older than the cache TTL: being
+	// refreshed in the background under WithStaleWhileRevalidate, or the
+	// last-known-good result after a failed fetch under WithMaxStaleness.
+	// AgeSeconds is then how long ago it was fetched.
+	Stale      bool    `json:"stale,omitempty"`
+	AgeSeconds float64 `json:"ageSeconds,omitempty"`
+
+	// palette is the Client's color palette used by SuggestedColor
+	palette map[WeatherGroup]string
+	// cacheHit is true when the Cache answered; see WasCacheHit
+	cacheHit bool
+}
+
+// MarshalJSON adds imperial and normalized conversions alongside the source
+// values, so clients don't convert themselves: temperatureF and feelsLikeF,
+// and windSpeedKmh and windSpeedMph, each rounded to one decimal
+func (w WeatherData) MarshalJSON() ([]byte, error) {
+	type plain WeatherData
+	return json.Marshal(struct {
+		plain
+		TemperatureF float64 `json:"temperatureF"`
+		FeelsLikeF   float64 `json:"feelsLikeF"`
+		WindSpeedKmh float64 `json:"windSpeedKmh"`
+		WindSpeedMph float64 `json:"windSpeedMph"`
+	}{
+		plain:        plain(w),
+		TemperatureF: roundTenth(w.Temperature().F()),
+		FeelsLikeF:   roundTenth(w.FeelsLike().F()),
+		WindSpeedKmh: roundTenth(w.WindSpeedIn(KilometresPerHour)),
+		WindSpeedMph: roundTenth(w.WindSpeedIn(MilesPerHour)),
+	})
 }
 
 // Coordinates represents latitude and longitude
@@ -22,14 +115,120 @@ type Coordinates struct {
 
 // OpenMeteoResponse represents the API response from Open-Meteo
 type OpenMeteoResponse struct {
-	Current struct {
+	Timezone             string `json:"timezone"`
+	TimezoneAbbreviation string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds     int    `json:"utc_offset_seconds"`
+	Current              struct {
+		Time                string  `json:"time"`
 		Temperature         float64 `json:"temperature_2m"`
 		ApparentTemperature float64 `json:"apparent_temperature"`
 		WeatherCode         int     `json:"weather_code"`
+		RelativeHumidity    float64 `json:"relative_humidity_2m"`
+		WindSpeed           float64 `json:"wind_speed_10m"`
+		UVIndex             float64 `json:"uv_index"`
+		// IsDay is 1 between sunrise and sunset and 0 otherwise
+		IsDay *int `json:"is_day"`
+		// Optional fields, requested with WithWeatherFields
+		WindDirection   *float64 `json:"wind_direction_10m"`
+		SurfacePressure *float64 `json:"surface_pressure"`
+		CloudCover      *float64 `json:"cloud_cover"`
+		Precipitation   *float64 `json:"precipitation"`
 	} `json:"current"`
+	Daily struct {
+		Sunrise []string `json:"sunrise"`
+		Sunset  []string `json:"sunset"`
+	} `json:"daily"`
+	// Hourly is the next day's forecast Narrative is written from
+	Hourly struct {
+		Time        []string   `json:"time"`
+		Temperature []*float64 `json:"temperature_2m"`
+		WeatherCode []*int     `json:"weather_code"`
+	} `json:"hourly"`
 }
 
-// Asia country coordinates (major cities)
+// openMeteoWire is what decodeCurrent unmarshals a response into: the rest
+// of OpenMeteoResponse, with its "current" fields as pointers so a single
+// pass also tells which were present
+type openMeteoWire struct {
+	OpenMeteoResponse
+	Current struct {
+		Time                string   `json:"time"`
+		Temperature         *float64 `json:"temperature_2m"`
+		ApparentTemperature *float64 `json:"apparent_temperature"`
+		WeatherCode         *int     `json:"weather_code"`
+		RelativeHumidity    *float64 `json:"relative_humidity_2m"`
+		WindSpeed           *float64 `json:"wind_speed_10m"`
+		UVIndex             *float64 `json:"uv_index"`
+		IsDay               *int     `json:"is_day"`
+		WindDirection       *float64 `json:"wind_direction_10m"`
+		SurfacePressure     *float64 `json:"surface_pressure"`
+		CloudCover          *float64 `json:"cloud_cover"`
+		Precipitation       *float64 `json:"precipitation"`
+	} `json:"current"`
+}
+
+// has reports whether the response had a non-null "current" field
+func (w *openMeteoWire) has(field string) bool {
+	cur := &w.Current
+	switch field {
+	case "temperature_2m":
+		return cur.Temperature != nil
+	case "apparent_temperature":
+		return cur.ApparentTemperature != nil
+	case "weather_code":
+		return cur.WeatherCode != nil
+	case "relative_humidity_2m":
+		return cur.RelativeHumidity != nil
+	case "wind_speed_10m":
+		return cur.WindSpeed != nil
+	case "uv_index":
+		return cur.UVIndex != nil
+	case "is_day":
+		return cur.IsDay != nil
+	case string(WindDirectionField):
+		return cur.WindDirection != nil
+	case string(PressureField):
+		return cur.SurfacePressure != nil
+	case string(CloudCoverField):
+		return cur.CloudCover != nil
+	case string(PrecipitationField):
+		return cur.Precipitation != nil
+	}
+	return false
+}
+
+// response returns the decoded OpenMeteoResponse, missing fields as zero
+func (w *openMeteoWire) response() *OpenMeteoResponse {
+	resp := &w.OpenMeteoResponse
+	cur := &w.Current
+	resp.Current.Time = cur.Time
+	resp.Current.Temperature = valueOrZero(cur.Temperature)
+	resp.Current.ApparentTemperature = valueOrZero(cur.ApparentTemperature)
+	resp.Current.WeatherCode = valueOrZero(cur.WeatherCode)
+	resp.Current.RelativeHumidity = valueOrZero(cur.RelativeHumidity)
+	resp.Current.WindSpeed = valueOrZero(cur.WindSpeed)
+	resp.Current.UVIndex = valueOrZero(cur.UVIndex)
+	resp.Current.IsDay = cur.IsDay
+	resp.Current.WindDirection = cur.WindDirection
+	resp.Current.SurfacePressure = cur.SurfacePressure
+	resp.Current.CloudCover = cur.CloudCover
+	resp.Current.Precipitation = cur.Precipitation
+	return resp
+}
+
+func valueOrZero[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// requiredCurrentFields are the "current" fields WeatherData is built from
+var requiredCurrentFields = []string{"temperature_2m", "apparent_temperature", "weather_code", "relative_humidity_2m", "uv_index"}
+
+// Asia country coordinates (capitals, or the main city where that is more
+// representative, such as Colombo)
 var asiaCountryCoordinates = map[string]Coordinates{
 	"JP": {Lat: 35.6762, Lon: 139.6503}, // Tokyo
 	"CN": {Lat: 39.9042, Lon: 116.4074}, // Beijing
@@ -43,6 +242,47 @@ var asiaCountryCoordinates = map[string]Coordinates{
 	"PH": {Lat: 14.5995, Lon: 120.9842}, // Manila
 	"VN": {Lat: 21.0285, Lon: 105.8542}, // Hanoi
 	"TW": {Lat: 25.0330, Lon: 121.5654}, // Taipei
+	"BD": {Lat: 23.8103, Lon: 90.4125},  // Dhaka
+	"PK": {Lat: 33.6844, Lon: 73.0479},  // Islamabad
+	"LK": {Lat: 6.9271, Lon: 79.8612},   // Colombo
+	"NP": {Lat: 27.7172, Lon: 85.3240},  // Kathmandu
+	"BT": {Lat: 27.4728, Lon: 89.6390},  // Thimphu
+	"MV": {Lat: 4.1755, Lon: 73.5093},   // Malé
+	"MM": {Lat: 19.7633, Lon: 96.0785},  // Naypyidaw
+	"KH": {Lat: 11.5564, Lon: 104.9282}, // Phnom Penh
+	"LA": {Lat: 17.9757, Lon: 102.6331}, // Vientiane
+	"BN": {Lat: 4.9031, Lon: 114.9398},  // Bandar Seri Begawan
+	"TL": {Lat: -8.5569, Lon: 125.5603}, // Dili
+	"MN": {Lat: 47.8864, Lon: 106.9057}, // Ulaanbaatar
+	"KZ": {Lat: 51.1694, Lon: 71.4491},  // Astana
+	"UZ": {Lat: 41.2995, Lon: 69.2401},  // Tashkent
+	"KG": {Lat: 42.8746, Lon: 74.5698},  // Bishkek
+	"TJ": {Lat: 38.5598, Lon: 68.7870},  // Dushanbe
+	"TM": {Lat: 37.9601, Lon: 58.3261},  // Ashgabat
+	"AF": {Lat: 34.5553, Lon: 69.2075},  // Kabul
+	"IR": {Lat: 35.6892, Lon: 51.3890},  // Tehran
+	"IQ": {Lat: 33.3152, Lon: 44.3661},  // Baghdad
+	"AE": {Lat: 24.4539, Lon: 54.3773},  // Abu Dhabi
+	"SA": {Lat: 24.7136, Lon: 46.6753},  // Riyadh
+	"QA": {Lat: 25.2854, Lon: 51.5310},  // Doha
+	"KW": {Lat: 29.3759, Lon: 47.9774},  // Kuwait City
+	"BH": {Lat: 26.2285, Lon: 50.5860},  // Manama
+	"OM": {Lat: 23.5880, Lon: 58.3829},  // Muscat
+	"JO": {Lat: 31.9454, Lon: 35.9284},  // Amman
+	"LB": {Lat: 33.8938, Lon: 35.5018},  // Beirut
+	"MO": {Lat: 22.1987, Lon: 113.5439}, // Macau
+}
+
+// Largest cities for countries where it isn't the city above
+var asiaLargestCityCoordinates = map[string]Coordinates{
+	"CN": {Lat: 31.2304, Lon: 121.4737}, // Shanghai
+	"IN": {Lat: 19.0760, Lon: 72.8777},  // Mumbai
+	"PH": {Lat: 14.6760, Lon: 121.0437}, // Quezon City
+	"VN": {Lat: 10.8231, Lon: 106.6297}, // Ho Chi Minh City
+	"PK": {Lat: 24.8607, Lon: 67.0011},  // Karachi
+	"MM": {Lat: 16.8409, Lon: 96.1735},  // Yangon
+	"KZ": {Lat: 43.2220, Lon: 76.8512},  // Almaty
+	"AE": {Lat: 25.2048, Lon: 55.2708},  // Dubai
 }
 
 // Weather code to description mapping (WMO Weather interpretation codes)
@@ -56,9 +296,13 @@ var weatherCodeDescriptions = map[int]string{
 	51: "Light drizzle",
 	53: "Moderate drizzle",
 	55: "Dense drizzle",
+	56: "Light freezing drizzle",
+	57: "Dense freezing drizzle",
 	61: "Slight rain",
 	63: "Moderate rain",
 	65: "Heavy rain",
+	66: "Light freezing rain",
+	67: "Heavy freezing rain",
 	71: "Slight snow",
 	73: "Moderate snow",
 	75: "Heavy snow",
@@ -73,52 +317,431 @@ var weatherCodeDescriptions = map[int]string{
 	99: "Thunderstorm with heavy hail",
 }
 
-// FetchWeather fetches weather data for a given country using Open-Meteo API
-func FetchWeather(country string) (*WeatherData, error) {
-	// Get coordinates for the country
-	coords, ok := asiaCountryCoordinates[country]
+// WeatherCodeDescriptions returns a copy of the WMO code to description
+// mapping; changes to the returned map don't affect the package
+func WeatherCodeDescriptions() map[int]string {
+	return maps.Clone(weatherCodeDescriptions)
+}
+
+// describeWeatherCode converts a WMO weather code to its description
+func (c *Client) describeWeatherCode(code int) string {
+	description, ok := weatherCodeDescriptions[code]
 	if !ok {
-		// Default to Tokyo if country not found
-		coords = asiaCountryCoordinates["JP"]
+		return c.unknownDescription
 	}
+	return description
+}
 
-	// Build Open-Meteo API URL
-	url := fmt.Sprintf(
-		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&current=temperature_2m,apparent_temperature,weather_code",
-		coords.Lat, coords.Lon,
-	)
+// forecastEndpoint is the Open-Meteo forecast API all weather requests use
+const forecastEndpoint = "https://api.open-meteo.com/v1/forecast"
+
+// forecastURL builds a forecast request for coords with the given query,
+// adding the Client-wide parameters including the configured model
+func (c *Client) forecastURL(ctx context.Context, coords Coordinates, query string) (string, error) {
+	models, err := c.modelQuery(ctx)
+	if err != nil {
+		return "", err
+	}
+	return c.baseForecastURL(coords, query+models), nil
+}
+
+// baseForecastURL builds a forecast request for coords with the given query,
+// adding the Client-wide parameters other than the model
+func (c *Client) baseForecastURL(coords Coordinates, query string) string {
+	return fmt.Sprintf("%s?latitude=%.4f&longitude=%.4f&%s%s", forecastEndpoint, coords.Lat, coords.Lon, query, c.extraQuery(query))
+}
+
+// getJSON performs a GET request against url and decodes a JSON body of at
+// most maxBytes into out. Identical concurrent requests, with the same URL,
+// size limit and type of out, share one upstream request and one decode,
+// whether or not caching is on. Every caller gets a shallow copy of the
+// decoded value, so decoders must not modify the slices and maps in it.
+func (c *Client) getJSON(ctx context.Context, url string, maxBytes int64, out any) error {
+	target := reflect.ValueOf(out).Elem()
+	key := fmt.Sprintf("%s %d %s", target.Type(), maxBytes, url)
+	shared, err := c.requests.do(ctx, key, func() (any, error) {
+		v := reflect.New(target.Type()).Interface()
+		return v, c.fetchJSON(ctx, url, maxBytes, v)
+	})
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.ValueOf(shared).Elem())
+	return nil
+}
+
+// fetchJSON is getJSON for a single caller, hedging the request when
+// configured
+func (c *Client) fetchJSON(ctx context.Context, url string, maxBytes int64, out any) error {
+	if err := c.injectFault(); err != nil {
+		return err
+	}
+	if c.hedgeDelay > 0 {
+		return c.getJSONHedged(ctx, url, maxBytes, out)
+	}
+	return c.getJSONOnce(ctx, url, maxBytes, out)
+}
+
+// getJSONOnce performs a single GET request against url and decodes a JSON
+// body of at most maxBytes into out
+func (c *Client) getJSONOnce(ctx context.Context, url string, maxBytes int64, out any) error {
+	body, err := c.getBody(ctx, url, maxBytes)
+	if err != nil {
+		return err
+	}
+	// Raw callers decode the body themselves, so it's only checked rather
+	// than copied
+	if raw, ok := out.(*json.RawMessage); ok {
+		if !json.Valid(body) {
+			return fmt.Errorf("%w: invalid JSON", ErrDecode)
+		}
+		*raw = body
+		return nil
+	}
+
+	// Parse response; unknown fields are deliberately allowed so new
+	// Open-Meteo fields don't break decoding
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return nil
+}
+
+// getSharedBody is getBody with identical concurrent requests sharing one
+// upstream request, for bodies that aren't JSON. The body is shared, so
+// callers must not modify it.
+func (c *Client) getSharedBody(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	shared, err := c.requests.do(ctx, fmt.Sprintf("body %d %s", maxBytes, url), func() (any, error) {
+		body, err := c.getBody(ctx, url, maxBytes)
+		return body, err
+	})
+	body, _ := shared.([]byte)
+	return body, err
+}
+
+// getBody returns a body of at most maxBytes for url, replaying or recording
+// it when configured
+func (c *Client) getBody(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	if c.replayDir != "" {
+		return c.replay(ctx, url)
+	}
+	body, err := c.fetchBodyGuarded(ctx, url, maxBytes)
+	if err == nil && c.recordDir != "" {
+		c.record(ctx, url, body)
+	}
+	return body, err
+}
+
+// fetchBody performs a GET request against url and returns a body of at most maxBytes
+func (c *Client) fetchBody(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build weather request: %w", err)
+	}
+	if err := c.limiters.wait(ctx, req.URL.Host); err != nil {
+		return nil, err
+	}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
 	}
+	if id, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set("X-Request-ID", id)
+	}
+
+	// Make the request conditional when a previous response had validators
+	previous, conditional := c.validators.apply(req)
+
+	// Credentials go last, as they can change the URL validators are kept by
+	c.authorize(req)
 
 	// Make API request
-	resp, err := client.Get(url)
+	ctx, span := c.startRequestSpan(ctx, req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	d := time.Since(start)
+	endRequestSpan(span, resp, err)
+	c.latency.record(d)
 	if err != nil {
-		return nil, fmt.Errorf("weather API call failed: %w", err)
+		c.metrics.observeRequest(req.URL.Host, "error", d)
+		logger.Debugf("%sGET %s failed dur=%s: %v", logPrefix(ctx), url, d, err)
+		return nil, &ProviderError{Host: req.URL.Host, Err: err}
 	}
 	defer resp.Body.Close()
+	c.metrics.observeRequest(req.URL.Host, strconv.Itoa(resp.StatusCode), d)
+	logger.Debugf("%sGET %s status=%d dur=%s", logPrefix(ctx), url, resp.StatusCode, d)
 
+	if resp.StatusCode == http.StatusNotModified && conditional {
+		// The stored body may have been read under a larger limit
+		if int64(len(previous.body)) > maxBytes {
+			return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, maxBytes)
+		}
+		return previous.body, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("weather API returned status %d", resp.StatusCode)
+		return nil, &ProviderError{Host: req.URL.Host, StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
 	}
 
-	// Parse response
-	var apiResp OpenMeteoResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse weather response: %w", err)
+	// Read one byte past the limit so an oversized body is detected rather
+	// than silently truncated
+	body, err := readBody(resp.Body, resp.ContentLength, maxBytes+1)
+	if err != nil {
+		return nil, &ProviderError{Host: req.URL.Host, Err: err}
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, maxBytes)
+	}
+	c.validators.store(url, resp.Header, body)
+	return body, nil
+}
+
+// FetchWeather fetches weather data for a given country using the default Client
+func FetchWeather(country string) (*WeatherData, error) {
+	return defaultClient.FetchWeather(context.Background(), country)
+}
+
+// FetchWeatherContext calls FetchWeather on the default Client, so callers can
+// propagate deadlines and cancellation
+func FetchWeatherContext(ctx context.Context, country string) (*WeatherData, error) {
+	return defaultClient.FetchWeather(ctx, country)
+}
+
+// FetchWeatherAt calls FetchWeatherAt on the default Client
+func FetchWeatherAt(ctx context.Context, coords Coordinates) (*WeatherData, error) {
+	return defaultClient.FetchWeatherAt(ctx, coords)
+}
+
+// FetchWeather fetches weather data for a given country using Open-Meteo API,
+// at the country's representative city
+func (c *Client) FetchWeather(ctx context.Context, country string) (*WeatherData, error) {
+	coords, err := c.coordinatesFor(country)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.FetchWeatherAt(ctx, coords)
+	c.metrics.observeFetch(normalizeCountry(country), err)
+	if err != nil {
+		return nil, err
+	}
+	data.Approximate, data.City = true, c.cityFor(country)
+	return data, nil
+}
+
+// FetchWeatherAt fetches current conditions at exact coordinates, for callers
+// with their own position such as ships, offices or sensors. Out-of-range
+// coordinates fail with *ErrInvalidCoordinates before any request is made.
+func (c *Client) FetchWeatherAt(ctx context.Context, coords Coordinates) (*WeatherData, error) {
+	if err := ValidateCoordinates(coords.Lat, coords.Lon); err != nil {
+		return nil, err
+	}
+	data, err := c.fetchCurrent(ctx, coords)
+	if err != nil {
+		last, ok := c.lastKnownGood(ctx, coords, err)
+		if !ok {
+			return nil, err
+		}
+		data = last
+	} else {
+		c.keepLastGood(coords, data)
+	}
+	return c.localize(ctx, data), nil
+}
+
+// fetchOpenMeteo fetches current conditions at the given coordinates from Open-Meteo
+func (c *Client) fetchOpenMeteo(ctx context.Context, coords Coordinates) (*WeatherData, error) {
+	// Build Open-Meteo API URL
+	wind, err := c.windSpeedQuery()
+	if err != nil {
+		return nil, err
+	}
+	query := "current=temperature_2m,apparent_temperature,weather_code,relative_humidity_2m,wind_speed_10m,uv_index,is_day" + c.weatherFieldsQuery() + wind + "&timezone=auto"
+	if c.narratives {
+		query += narrativeQuery
+	}
+	if len(c.nightDescriptions) > 0 {
+		query += nightQuery
+	}
+	url, err := c.forecastURL(ctx, coords, query)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(url)
+	if c.cacheTTL > 0 && ctx.Value(freshKey{}) == nil {
+		data, ok, err := c.cache.Get(key)
+		switch {
+		case err != nil && c.strictCache:
+			return nil, fmt.Errorf("%w: get: %w", ErrCache, err)
+		case err != nil:
+			logger.Warnf("%scache get failed, fetching: %v", logPrefix(ctx), err)
+		case ok:
+			c.metrics.observeCache(true)
+			c.setCacheHit(ctx, true)
+			// External caches don't round-trip unexported fields
+			data.palette, data.cacheHit = c.colorPalette, true
+			if c.isStale(data) {
+				data.Stale, data.AgeSeconds = true, staleAge(data)
+				c.revalidations.Add(1)
+				go c.revalidate(ctx, key, url)
+			}
+			return data, nil
+		default:
+			c.metrics.observeCache(false)
+			c.setCacheHit(ctx, false)
+		}
+	}
+	return c.fetchCurrentURL(ctx, key, url)
+}
+
+// fetchCurrentURL fetches and caches current conditions for a request URL.
+// Concurrent calls for the same request share one upstream fetch.
+func (c *Client) fetchCurrentURL(ctx context.Context, key, url string) (*WeatherData, error) {
+	return c.flights.do(ctx, key, func() (*WeatherData, error) {
+		var raw json.RawMessage
+		if err := c.getJSON(ctx, url, c.maxCurrentBytes, &raw); err != nil {
+			return nil, err
+		}
+		data, err := c.decodeCurrent(ctx, raw)
+		if err != nil {
+			return nil, err
+		}
+		if c.cacheTTL > 0 {
+			if err := c.cache.Set(key, data, c.cacheTTL+c.staleWindow); err != nil {
+				if c.strictCache {
+					return nil, fmt.Errorf("%w: set: %w", ErrCache, err)
+				}
+				logger.Warnf("%scache set failed: %v", logPrefix(ctx), err)
+			}
+		}
+		return data, nil
+	})
+}
+
+// decodeCurrent builds WeatherData from an Open-Meteo response body. Unknown
+// fields are ignored and missing expected fields are left at zero and
+// reported on MissingFields rather than failing the decode. Present values
+// outside the plausible ranges fail with ErrImplausibleData, and a response
+// with none of the fields, no timestamp or negative wind or UV fails with
+// ErrInvalidUpstreamData.
+func (c *Client) decodeCurrent(ctx context.Context, raw []byte) (*WeatherData, error) {
+	var wire openMeteoWire
+	if c.narratives {
+		// Sized for the hours narrativeQuery asks for, so decoding doesn't
+		// grow them hour by hour
+		wire.Hourly.Time = make([]string, 0, narrativeHours)
+		wire.Hourly.Temperature = make([]*float64, 0, narrativeHours)
+		wire.Hourly.WeatherCode = make([]*int, 0, narrativeHours)
+	}
+	if err := json.Unmarshal(raw, &wire); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	apiResp, has := wire.response(), wire.has
+
+	var missing []string
+	for _, field := range requiredCurrentFields {
+		if !has(field) {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) == len(requiredCurrentFields) {
+		return nil, fmt.Errorf("%w: no current conditions", ErrInvalidUpstreamData)
+	}
+	loc := responseLocation(apiResp.Timezone, apiResp.TimezoneAbbreviation, apiResp.UTCOffsetSeconds)
+	observedAt, err := time.ParseInLocation(openMeteoTimeLayout, apiResp.Current.Time, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: current time %q", ErrInvalidUpstreamData, apiResp.Current.Time)
+	}
+	if apiResp.Current.WindSpeed < 0 || apiResp.Current.UVIndex < 0 {
+		return nil, fmt.Errorf("%w: negative wind speed %g or UV index %g", ErrInvalidUpstreamData, apiResp.Current.WindSpeed, apiResp.Current.UVIndex)
+	}
+	if err := checkOptionalFields(apiResp); err != nil {
+		return nil, err
+	}
+	for _, f := range c.weatherFields {
+		if !has(string(f)) {
+			missing = append(missing, string(f))
+		}
+	}
+
+	humidity := apiResp.Current.RelativeHumidity
+	if !has("relative_humidity_2m") {
+		humidity = math.NaN()
+	}
+
+	// Compute feels-like locally when the API omits it
+	feelsLike := apiResp.Current.ApparentTemperature
+	computed := false
+	if !has("apparent_temperature") && has("temperature_2m") {
+		feelsLike = apparentTemperature(apiResp.Current.Temperature, humidity, c.toKmh(apiResp.Current.WindSpeed))
+		computed = true
+	}
+
+	// Reject physically implausible values, checking only fields that were present
+	temps, humidities := map[string]float64{}, map[string]float64{}
+	if has("temperature_2m") {
+		temps["temperature_2m"] = apiResp.Current.Temperature
+	}
+	if has("apparent_temperature") {
+		temps["apparent_temperature"] = apiResp.Current.ApparentTemperature
+	}
+	if has("relative_humidity_2m") {
+		humidities["relative_humidity_2m"] = apiResp.Current.RelativeHumidity
+	}
+	if err := c.plausible.check(temps, humidities); err != nil {
+		return nil, err
 	}
 
 	// Convert weather code to description
-	description, ok := weatherCodeDescriptions[apiResp.Current.WeatherCode]
-	if !ok {
-		description = "Unknown"
+	description := c.describeWeatherCode(apiResp.Current.WeatherCode)
+	if slices.Contains(missing, "weather_code") {
+		description = c.unknownDescription
+	} else if night, ok := c.nightDescription(apiResp); ok {
+		description = night
+	}
+
+	data := &WeatherData{
+		Summary:           description,
+		WeatherCode:       apiResp.Current.WeatherCode,
+		TemperatureC:      apiResp.Current.Temperature,
+		FeelsLikeC:        feelsLike,
+		WindSpeed:         apiResp.Current.WindSpeed,
+		WindSpeedUnit:     c.windSpeedUnit,
+		UVIndex:           apiResp.Current.UVIndex,
+		FeelsLikeComputed: computed,
+		MissingFields:     missing,
+		Provenance:        c.provenance(c.openMeteoSource(ctx), observedAt),
+		palette:           c.colorPalette,
 	}
+	data.setHeat(humidity)
+	// Without is_day the observation counts as daytime
+	data.setCondition(has("weather_code"), apiResp.Current.IsDay == nil || *apiResp.Current.IsDay != 0)
+	c.setWeatherFields(data, apiResp)
+	if !c.narratives {
+		return data, nil
+	}
+	if o, ok := outlookFrom(apiResp, loc, observedAt); ok {
+		data.Outlook, data.Narrative = o, o.Narrative("en")
+	}
+	return data, nil
+}
 
-	return &WeatherData{
-		Summary:      description,
-		TemperatureC: apiResp.Current.Temperature,
-		FeelsLikeC:   apiResp.Current.ApparentTemperature,
-	}, nil
+// outlookFrom sums up the response's hourly forecast from the observation
+// on. Narratives are extras, so hours that don't parse are skipped rather
+// than failing the decode.
+func outlookFrom(apiResp *OpenMeteoResponse, loc *time.Location, observedAt time.Time) (*Outlook, bool) {
+	h := apiResp.Hourly
+	hours := make([]outlookHour, 0, len(h.Time))
+	for i, s := range h.Time {
+		start, err := time.ParseInLocation(openMeteoTimeLayout, s, loc)
+		if err != nil || i >= len(h.WeatherCode) || h.WeatherCode[i] == nil {
+			continue
+		}
+		condition, _ := outlookConditionFor(*h.WeatherCode[i])
+		hour := outlookHour{start: start, condition: condition}
+		if i < len(h.Temperature) {
+			hour.temperatureC = h.Temperature[i]
+		}
+		hours = append(hours, hour)
+	}
+	return outlookFor(hours, observedAt)
 }
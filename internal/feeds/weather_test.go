@@ -0,0 +1,217 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// decodeSeeds are Open-Meteo current weather responses: the recorded
+// feedstest forecast, and trimmed ones with fields missing or null
+var decodeSeeds = []string{
+	`{"latitude":1.25,"longitude":103.875,"utc_offset_seconds":28800,"timezone":"Asia/Singapore","timezone_abbreviation":"+08",` +
+		`"current":{"time":"2025-01-15T14:00","interval":900,"temperature_2m":31.2,"apparent_temperature":36.8,"weather_code":80,` +
+		`"relative_humidity_2m":66,"wind_speed_10m":12.6,"uv_index":7.1,"is_day":1}}`,
+	`{"utc_offset_seconds":25200,"timezone":"Asia/Bangkok","timezone_abbreviation":"+07",` +
+		`"current":{"time":"2025-01-15T23:00","temperature_2m":24.9,"weather_code":0,"relative_humidity_2m":78,"wind_speed_10m":4.3,"uv_index":0,"is_day":0},` +
+		`"hourly":{"time":["2025-01-15T23:00","2025-01-16T00:00"],"temperature_2m":[24.9,null],"weather_code":[0,1]}}`,
+	`{"utc_offset_seconds":32400,"timezone":"Asia/Tokyo","current":{"time":"2025-01-15T12:00","temperature_2m":null,"weather_code":3}}`,
+	`{"current":{}}`,
+}
+
+func FuzzDecodeCurrent(f *testing.F) {
+	forecast, err := os.ReadFile("feedstest/fixtures/forecast.json")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(forecast)
+	for _, seed := range decodeSeeds {
+		f.Add([]byte(seed))
+	}
+
+	c, err := NewClient()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		data, err := c.decodeCurrent(context.Background(), raw)
+		if err == nil {
+			if data == nil {
+				t.Fatal("decodeCurrent returned neither data nor an error")
+			}
+			return
+		}
+		if errors.Is(err, ErrInvalidUpstreamData) {
+			return
+		}
+		var syntax *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		if errors.Is(err, ErrDecode) && (errors.As(err, &syntax) || errors.As(err, &typ)) {
+			return
+		}
+		t.Fatalf("decodeCurrent(%q) = %v, want ErrInvalidUpstreamData or a JSON error", raw, err)
+	})
+}
+
+// BenchmarkDecodeCurrent decodes the recorded feedstest forecast as each
+// refresh of a country does: its current block alone, and with the day of
+// hourly forecast WithNarratives adds
+func BenchmarkDecodeCurrent(b *testing.B) {
+	raw, err := os.ReadFile("feedstest/fixtures/forecast.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var forecast map[string]any
+	if err := json.Unmarshal(raw, &forecast); err != nil {
+		b.Fatal(err)
+	}
+	delete(forecast, "daily")
+	hourly := forecast["hourly"]
+	delete(forecast, "hourly")
+	current, err := json.Marshal(forecast)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// Repeat the fixture's hours into the narrativeHours a request gets
+	var hours, day struct {
+		Time        []string  `json:"time"`
+		Temperature []float64 `json:"temperature_2m"`
+		WeatherCode []int     `json:"weather_code"`
+	}
+	h, _ := json.Marshal(hourly)
+	if err := json.Unmarshal(h, &hours); err != nil {
+		b.Fatal(err)
+	}
+	start, _ := time.Parse(openMeteoTimeLayout, hours.Time[0])
+	for i := range narrativeHours {
+		day.Time = append(day.Time, start.Add(time.Duration(i)*time.Hour).Format(openMeteoTimeLayout))
+		day.Temperature = append(day.Temperature, hours.Temperature[i%len(hours.Temperature)])
+		day.WeatherCode = append(day.WeatherCode, hours.WeatherCode[i%len(hours.WeatherCode)])
+	}
+	forecast["hourly"] = day
+	narrative, err := json.Marshal(forecast)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, bb := range []struct {
+		name    string
+		raw     []byte
+		options []Option
+	}{
+		{name: "current", raw: current},
+		{name: "narrative", raw: narrative, options: []Option{WithNarratives()}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			c, err := NewClient(bb.options...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bb.raw)))
+			for b.Loop() {
+				if _, err := c.decodeCurrent(ctx, bb.raw); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestNotModifiedBodyTooLarge(t *testing.T) {
+	body := []byte(`{"current":{"time":"2025-01-15T12:00","temperature_2m":9.4}}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(body)
+	}))
+	defer srv.Close()
+	c, err := NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := c.fetchBody(ctx, srv.URL, 1024); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.fetchBody(ctx, srv.URL, 1024); err != nil || string(got) != string(body) {
+		t.Fatalf("304 under the same limit = %q, %v, want the stored body", got, err)
+	}
+	if _, err := c.fetchBody(ctx, srv.URL, int64(len(body)-1)); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("304 under a smaller limit: err = %v, want ErrResponseTooLarge", err)
+	}
+}
+
+func TestDecodeCurrentTolerant(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		wantTemp    float64
+		wantMissing []string
+	}{
+		{
+			name: "unexpected extra fields",
+			raw: `{"timezone":"Asia/Tokyo","utc_offset_seconds":32400,"generationtime_ms":0.1,"elevation":{"m":40},` +
+				`"current":{"time":"2025-01-15T12:00","temperature_2m":9.4,"apparent_temperature":7.1,"weather_code":1,` +
+				`"relative_humidity_2m":45,"uv_index":2,"wind_speed_10m":5,"is_day":1,"snow_depth_cm":[0,1],"new_field":"x"},` +
+				`"current_units":{"temperature_2m":"°C"},"minutely_15":{"time":[]}}`,
+			wantTemp: 9.4,
+		},
+		{
+			name:        "expected fields missing",
+			raw:         `{"current":{"time":"2025-01-15T12:00","temperature_2m":9.4,"apparent_temperature":7.1,"weather_code":1}}`,
+			wantTemp:    9.4,
+			wantMissing: []string{"relative_humidity_2m", "uv_index"},
+		},
+	}
+	c, err := NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := c.decodeCurrent(context.Background(), []byte(tt.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.TemperatureC != tt.wantTemp || data.FeelsLikeC != 7.1 || data.WeatherCode != 1 {
+				t.Errorf("decoded %v°C, feels like %v°C, code %d, want %v°C, 7.1°C, 1", data.TemperatureC, data.FeelsLikeC, data.WeatherCode, tt.wantTemp)
+			}
+			if !slices.Equal(data.MissingFields, tt.wantMissing) {
+				t.Errorf("MissingFields = %q, want %q", data.MissingFields, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestValidateBuiltinCoordinates(t *testing.T) {
+	if err := ValidateBuiltinCoordinates(); err != nil {
+		t.Fatalf("built-in tables: %v", err)
+	}
+
+	for _, tt := range []struct {
+		code   string
+		coords Coordinates
+	}{
+		{code: "ZZ", coords: Coordinates{Lat: 135.6762, Lon: 139.6503}},
+		{code: "jp", coords: Coordinates{Lat: 35.6762, Lon: 139.6503}},
+	} {
+		asiaCountryCoordinates[tt.code] = tt.coords
+		err := ValidateBuiltinCoordinates()
+		delete(asiaCountryCoordinates, tt.code)
+		if err == nil {
+			t.Errorf("%s at %v: ValidateBuiltinCoordinates = nil, want an error", tt.code, tt.coords)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package feeds
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// WindSpeedUnit is an Open-Meteo wind_speed_unit value
+type WindSpeedUnit string
+
+const (
+	KilometresPerHour WindSpeedUnit = "kmh"
+	MetresPerSecond   WindSpeedUnit = "ms"
+	MilesPerHour      WindSpeedUnit = "mph"
+	Knots             WindSpeedUnit = "kn"
+)
+
+// ErrUnknownWindSpeedUnit reports a WithWindSpeedUnit unit Open-Meteo doesn't support
+var ErrUnknownWindSpeedUnit = errors.New("unknown wind speed unit")
+
+// kmhPerUnit converts each unit to km/h
+var kmhPerUnit = map[WindSpeedUnit]float64{
+	KilometresPerHour: 1,
+	MetresPerSecond:   3.6,
+	MilesPerHour:      1.609344,
+	Knots:             1.852,
+}
+
+// WithWindSpeedUnit requests wind speeds in unit (default km/h); the unit is
+// recorded on WeatherData.WindSpeedUnit. Unsupported units fail NewClient
+// with ErrInvalidConfig wrapping ErrUnknownWindSpeedUnit.
+func WithWindSpeedUnit(unit WindSpeedUnit) Option {
+	return func(c *Client) {
+		c.windSpeedUnit = unit
+	}
+}
+
+// windSpeedQuery validates the configured unit and returns its query
+// parameter, or "" for the API default of km/h
+func (c *Client) windSpeedQuery() (string, error) {
+	if _, ok := kmhPerUnit[c.windSpeedUnit]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownWindSpeedUnit, c.windSpeedUnit)
+	}
+	if c.windSpeedUnit == KilometresPerHour {
+		return "", nil
+	}
+	return "&wind_speed_unit=" + string(c.windSpeedUnit), nil
+}
+
+// ConvertWindSpeed converts speed between units; unknown units convert as km/h
+func ConvertWindSpeed(speed float64, from, to WindSpeedUnit) float64 {
+	if from == to {
+		return speed
+	}
+	return speed * cmp.Or(kmhPerUnit[from], 1) / cmp.Or(kmhPerUnit[to], 1)
+}
+
+// WindSpeedIn returns WindSpeed converted to unit; an empty WindSpeedUnit is km/h
+func (w *WeatherData) WindSpeedIn(unit WindSpeedUnit) float64 {
+	return ConvertWindSpeed(w.WindSpeed, cmp.Or(w.WindSpeedUnit, KilometresPerHour), unit)
+}
+
+// FormatWindSpeed formats WindSpeed for display in units, e.g. "12.4 km/h"
+// for Metric or "7.7 mph" for Imperial
+func (w *WeatherData) FormatWindSpeed(units Units) string {
+	if units == Imperial {
+		return fmt.Sprintf("%.1f mph", roundTenth(w.WindSpeedIn(MilesPerHour)))
+	}
+	return fmt.Sprintf("%.1f km/h", roundTenth(w.WindSpeedIn(KilometresPerHour)))
+}
+
+// roundTenth rounds v half away from zero to one decimal
+func roundTenth(v float64) float64 {
+	v = math.Round(v*10) / 10
+	if v == 0 {
+		v = 0 // avoid "-0"
+	}
+	return v
+}
+
+// toKmh converts a wind speed in the Client's unit to km/h
+func (c *Client) toKmh(speed float64) float64 {
+	return speed * kmhPerUnit[c.windSpeedUnit]
+}
@@ -0,0 +1,53 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/feeds/feedstest"
+)
+
+func TestWithWindSpeedUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   []feeds.Option
+		wantParam string
+		wantUnit  feeds.WindSpeedUnit
+	}{
+		{name: "default km/h", wantUnit: feeds.KilometresPerHour},
+		{name: "knots", options: []feeds.Option{feeds.WithWindSpeedUnit(feeds.Knots)}, wantParam: "kn", wantUnit: feeds.Knots},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := feedstest.NewServer(t)
+			data, err := srv.Client(t, tt.options...).FetchWeather(context.Background(), "JP")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := srv.Requests()[0].Query().Get("wind_speed_unit"); got != tt.wantParam {
+				t.Errorf("wind_speed_unit = %q, want %q", got, tt.wantParam)
+			}
+			// The fixture's 11.2 is passed through in whichever unit was asked for
+			if data.WindSpeed != 11.2 || data.WindSpeedUnit != tt.wantUnit {
+				t.Errorf("wind %v %s, want 11.2 %s", data.WindSpeed, data.WindSpeedUnit, tt.wantUnit)
+			}
+		})
+	}
+
+	_, err := feeds.NewClient(feeds.WithWindSpeedUnit("beaufort"))
+	if !errors.Is(err, feeds.ErrInvalidConfig) || !errors.Is(err, feeds.ErrUnknownWindSpeedUnit) {
+		t.Errorf("err = %v, want ErrInvalidConfig wrapping ErrUnknownWindSpeedUnit", err)
+	}
+}
+
+func TestWindSpeedIn(t *testing.T) {
+	w := &feeds.WeatherData{WindSpeed: 10, WindSpeedUnit: feeds.Knots}
+	if got := w.WindSpeedIn(feeds.KilometresPerHour); got != 18.52 {
+		t.Errorf("10 kn = %v km/h, want 18.52", got)
+	}
+	if got := w.FormatWindSpeed(feeds.Imperial); got != "11.5 mph" {
+		t.Errorf("FormatWindSpeed(Imperial) = %q, want 11.5 mph", got)
+	}
+}
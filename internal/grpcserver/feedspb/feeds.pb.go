@@ -0,0 +1,1387 @@
+// Feeds exposes the reef-asia feeds to internal services over gRPC. It serves
+// the same data as the /v1 REST API; field meanings are documented on the Go
+// types in reef-asia/internal/feeds.
+//
+// Regenerate the Go code after editing with protoc-gen-go and
+// protoc-gen-go-grpc, run from the repository root:
+//
+//	protoc --go_out=. --go_opt=module=reef-asia \
+//	  --go-grpc_out=. --go-grpc_opt=module=reef-asia \
+//	  proto/reefasia/feeds/v1/feeds.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: proto/reefasia/feeds/v1/feeds.proto
+
+package feedspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Provenance says where and when data came from
+type Provenance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// observed_at is unset for data without a measurement time
+	ObservedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	FetchedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Provenance) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Provenance) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+func (x *Provenance) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+type GetWeatherRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// country is an ISO 3166-1 alpha-2 code such as "JP"
+	Country string `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	// city optionally picks a gazetteer city instead of the reference city
+	City string `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	// locale optionally localizes the summary, e.g. "ja"
+	Locale string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+}
+
+func (x *GetWeatherRequest) Reset() {
+	*x = GetWeatherRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWeatherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWeatherRequest) ProtoMessage() {}
+
+func (x *GetWeatherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWeatherRequest.ProtoReflect.Descriptor instead.
+func (*GetWeatherRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetWeatherRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *GetWeatherRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetWeatherRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type Weather struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Summary     string `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	WeatherCode int32  `protobuf:"varint,2,opt,name=weather_code,json=weatherCode,proto3" json:"weather_code,omitempty"`
+	// locale is the matched locale of summary, empty for English
+	Locale            string  `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	TemperatureC      float64 `protobuf:"fixed64,4,opt,name=temperature_c,json=temperatureC,proto3" json:"temperature_c,omitempty"`
+	FeelsLikeC        float64 `protobuf:"fixed64,5,opt,name=feels_like_c,json=feelsLikeC,proto3" json:"feels_like_c,omitempty"`
+	FeelsLikeComputed bool    `protobuf:"varint,6,opt,name=feels_like_computed,json=feelsLikeComputed,proto3" json:"feels_like_computed,omitempty"`
+	WindSpeed         float64 `protobuf:"fixed64,7,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	// wind_speed_unit is "kmh", "ms" or "mph"
+	WindSpeedUnit    string      `protobuf:"bytes,8,opt,name=wind_speed_unit,json=windSpeedUnit,proto3" json:"wind_speed_unit,omitempty"`
+	RelativeHumidity float64     `protobuf:"fixed64,9,opt,name=relative_humidity,json=relativeHumidity,proto3" json:"relative_humidity,omitempty"`
+	UvIndex          float64     `protobuf:"fixed64,10,opt,name=uv_index,json=uvIndex,proto3" json:"uv_index,omitempty"`
+	HeatIndexC       float64     `protobuf:"fixed64,11,opt,name=heat_index_c,json=heatIndexC,proto3" json:"heat_index_c,omitempty"`
+	HeatAdvisory     string      `protobuf:"bytes,12,opt,name=heat_advisory,json=heatAdvisory,proto3" json:"heat_advisory,omitempty"`
+	Approximate      bool        `protobuf:"varint,13,opt,name=approximate,proto3" json:"approximate,omitempty"`
+	City             string      `protobuf:"bytes,14,opt,name=city,proto3" json:"city,omitempty"`
+	MissingFields    []string    `protobuf:"bytes,15,rep,name=missing_fields,json=missingFields,proto3" json:"missing_fields,omitempty"`
+	Provenance       *Provenance `protobuf:"bytes,16,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	// stale is set on data older than the cache TTL, such as the last good
+	// result served after a failed fetch; age_seconds is then its age
+	Stale bool `protobuf:"varint,17,opt,name=stale,proto3" json:"stale,omitempty"`
+	// The optional fields are set only on servers configured to fetch them:
+	// degrees the wind blows from, hPa, percent and the preceding hour's mm
+	WindDirection      *float64 `protobuf:"fixed64,18,opt,name=wind_direction,json=windDirection,proto3,oneof" json:"wind_direction,omitempty"`
+	SurfacePressureHpa *float64 `protobuf:"fixed64,19,opt,name=surface_pressure_hpa,json=surfacePressureHpa,proto3,oneof" json:"surface_pressure_hpa,omitempty"`
+	CloudCover         *float64 `protobuf:"fixed64,20,opt,name=cloud_cover,json=cloudCover,proto3,oneof" json:"cloud_cover,omitempty"`
+	PrecipitationMm    *float64 `protobuf:"fixed64,21,opt,name=precipitation_mm,json=precipitationMm,proto3,oneof" json:"precipitation_mm,omitempty"`
+	AgeSeconds         float64  `protobuf:"fixed64,22,opt,name=age_seconds,json=ageSeconds,proto3" json:"age_seconds,omitempty"`
+	// icon is a stable icon identifier such as "clear-day" or "rain", and
+	// severity one of "none", "advisory", "warning" or "severe"
+	Icon     string `protobuf:"bytes,23,opt,name=icon,proto3" json:"icon,omitempty"`
+	Severity string `protobuf:"bytes,24,opt,name=severity,proto3" json:"severity,omitempty"`
+	// narrative sums up the next day in a sentence, such as "Light rain this
+	// afternoon, clearing by evening; highs near 31°C", in the requested
+	// locale where narratives are translated and in English otherwise
+	Narrative string `protobuf:"bytes,25,opt,name=narrative,proto3" json:"narrative,omitempty"`
+}
+
+func (x *Weather) Reset() {
+	*x = Weather{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Weather) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Weather) ProtoMessage() {}
+
+func (x *Weather) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Weather.ProtoReflect.Descriptor instead.
+func (*Weather) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Weather) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Weather) GetWeatherCode() int32 {
+	if x != nil {
+		return x.WeatherCode
+	}
+	return 0
+}
+
+func (x *Weather) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Weather) GetTemperatureC() float64 {
+	if x != nil {
+		return x.TemperatureC
+	}
+	return 0
+}
+
+func (x *Weather) GetFeelsLikeC() float64 {
+	if x != nil {
+		return x.FeelsLikeC
+	}
+	return 0
+}
+
+func (x *Weather) GetFeelsLikeComputed() bool {
+	if x != nil {
+		return x.FeelsLikeComputed
+	}
+	return false
+}
+
+func (x *Weather) GetWindSpeed() float64 {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return 0
+}
+
+func (x *Weather) GetWindSpeedUnit() string {
+	if x != nil {
+		return x.WindSpeedUnit
+	}
+	return ""
+}
+
+func (x *Weather) GetRelativeHumidity() float64 {
+	if x != nil {
+		return x.RelativeHumidity
+	}
+	return 0
+}
+
+func (x *Weather) GetUvIndex() float64 {
+	if x != nil {
+		return x.UvIndex
+	}
+	return 0
+}
+
+func (x *Weather) GetHeatIndexC() float64 {
+	if x != nil {
+		return x.HeatIndexC
+	}
+	return 0
+}
+
+func (x *Weather) GetHeatAdvisory() string {
+	if x != nil {
+		return x.HeatAdvisory
+	}
+	return ""
+}
+
+func (x *Weather) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+func (x *Weather) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Weather) GetMissingFields() []string {
+	if x != nil {
+		return x.MissingFields
+	}
+	return nil
+}
+
+func (x *Weather) GetProvenance() *Provenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+func (x *Weather) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *Weather) GetWindDirection() float64 {
+	if x != nil && x.WindDirection != nil {
+		return *x.WindDirection
+	}
+	return 0
+}
+
+func (x *Weather) GetSurfacePressureHpa() float64 {
+	if x != nil && x.SurfacePressureHpa != nil {
+		return *x.SurfacePressureHpa
+	}
+	return 0
+}
+
+func (x *Weather) GetCloudCover() float64 {
+	if x != nil && x.CloudCover != nil {
+		return *x.CloudCover
+	}
+	return 0
+}
+
+func (x *Weather) GetPrecipitationMm() float64 {
+	if x != nil && x.PrecipitationMm != nil {
+		return *x.PrecipitationMm
+	}
+	return 0
+}
+
+func (x *Weather) GetAgeSeconds() float64 {
+	if x != nil {
+		return x.AgeSeconds
+	}
+	return 0
+}
+
+func (x *Weather) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Weather) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Weather) GetNarrative() string {
+	if x != nil {
+		return x.Narrative
+	}
+	return ""
+}
+
+type GetAirQualityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Country string `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	City    string `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+}
+
+func (x *GetAirQualityRequest) Reset() {
+	*x = GetAirQualityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAirQualityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAirQualityRequest) ProtoMessage() {}
+
+func (x *GetAirQualityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAirQualityRequest.ProtoReflect.Descriptor instead.
+func (*GetAirQualityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAirQualityRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *GetAirQualityRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type AirQuality struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pm25  float64 `protobuf:"fixed64,1,opt,name=pm25,proto3" json:"pm25,omitempty"`
+	Pm10  float64 `protobuf:"fixed64,2,opt,name=pm10,proto3" json:"pm10,omitempty"`
+	Ozone float64 `protobuf:"fixed64,3,opt,name=ozone,proto3" json:"ozone,omitempty"`
+	// aqi is the US EPA air quality index and band its category
+	Aqi         int32             `protobuf:"varint,4,opt,name=aqi,proto3" json:"aqi,omitempty"`
+	Band        string            `protobuf:"bytes,5,opt,name=band,proto3" json:"band,omitempty"`
+	Approximate bool              `protobuf:"varint,6,opt,name=approximate,proto3" json:"approximate,omitempty"`
+	City        string            `protobuf:"bytes,7,opt,name=city,proto3" json:"city,omitempty"`
+	Provenance  *Provenance       `protobuf:"bytes,8,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	Advice      *AirQualityAdvice `protobuf:"bytes,9,opt,name=advice,proto3" json:"advice,omitempty"`
+}
+
+func (x *AirQuality) Reset() {
+	*x = AirQuality{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AirQuality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AirQuality) ProtoMessage() {}
+
+func (x *AirQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AirQuality.ProtoReflect.Descriptor instead.
+func (*AirQuality) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AirQuality) GetPm25() float64 {
+	if x != nil {
+		return x.Pm25
+	}
+	return 0
+}
+
+func (x *AirQuality) GetPm10() float64 {
+	if x != nil {
+		return x.Pm10
+	}
+	return 0
+}
+
+func (x *AirQuality) GetOzone() float64 {
+	if x != nil {
+		return x.Ozone
+	}
+	return 0
+}
+
+func (x *AirQuality) GetAqi() int32 {
+	if x != nil {
+		return x.Aqi
+	}
+	return 0
+}
+
+func (x *AirQuality) GetBand() string {
+	if x != nil {
+		return x.Band
+	}
+	return ""
+}
+
+func (x *AirQuality) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+func (x *AirQuality) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *AirQuality) GetProvenance() *Provenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+func (x *AirQuality) GetAdvice() *AirQualityAdvice {
+	if x != nil {
+		return x.Advice
+	}
+	return nil
+}
+
+// AirQualityAdvice is health guidance by the country's AQI standard
+type AirQualityAdvice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// standard names the index, e.g. "US EPA" or "India NAQI"
+	Standard              string           `protobuf:"bytes,1,opt,name=standard,proto3" json:"standard,omitempty"`
+	Index                 int32            `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Category              string           `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	DominantPollutant     string           `protobuf:"bytes,4,opt,name=dominant_pollutant,json=dominantPollutant,proto3" json:"dominant_pollutant,omitempty"`
+	SubIndices            map[string]int32 `protobuf:"bytes,5,rep,name=sub_indices,json=subIndices,proto3" json:"sub_indices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	SensitiveGroupWarning bool             `protobuf:"varint,6,opt,name=sensitive_group_warning,json=sensitiveGroupWarning,proto3" json:"sensitive_group_warning,omitempty"`
+	LimitOutdoorExercise  bool             `protobuf:"varint,7,opt,name=limit_outdoor_exercise,json=limitOutdoorExercise,proto3" json:"limit_outdoor_exercise,omitempty"`
+	MaskAdvisory          bool             `protobuf:"varint,8,opt,name=mask_advisory,json=maskAdvisory,proto3" json:"mask_advisory,omitempty"`
+	Recommendations       []string         `protobuf:"bytes,9,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+}
+
+func (x *AirQualityAdvice) Reset() {
+	*x = AirQualityAdvice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AirQualityAdvice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AirQualityAdvice) ProtoMessage() {}
+
+func (x *AirQualityAdvice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AirQualityAdvice.ProtoReflect.Descriptor instead.
+func (*AirQualityAdvice) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AirQualityAdvice) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *AirQualityAdvice) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *AirQualityAdvice) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AirQualityAdvice) GetDominantPollutant() string {
+	if x != nil {
+		return x.DominantPollutant
+	}
+	return ""
+}
+
+func (x *AirQualityAdvice) GetSubIndices() map[string]int32 {
+	if x != nil {
+		return x.SubIndices
+	}
+	return nil
+}
+
+func (x *AirQualityAdvice) GetSensitiveGroupWarning() bool {
+	if x != nil {
+		return x.SensitiveGroupWarning
+	}
+	return false
+}
+
+func (x *AirQualityAdvice) GetLimitOutdoorExercise() bool {
+	if x != nil {
+		return x.LimitOutdoorExercise
+	}
+	return false
+}
+
+func (x *AirQualityAdvice) GetMaskAdvisory() bool {
+	if x != nil {
+		return x.MaskAdvisory
+	}
+	return false
+}
+
+func (x *AirQualityAdvice) GetRecommendations() []string {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type ListCountriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCountriesRequest) Reset() {
+	*x = ListCountriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCountriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCountriesRequest) ProtoMessage() {}
+
+func (x *ListCountriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCountriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCountriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{6}
+}
+
+type ListCountriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Countries []*Country `protobuf:"bytes,1,rep,name=countries,proto3" json:"countries,omitempty"`
+}
+
+func (x *ListCountriesResponse) Reset() {
+	*x = ListCountriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCountriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCountriesResponse) ProtoMessage() {}
+
+func (x *ListCountriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCountriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCountriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListCountriesResponse) GetCountries() []*Country {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+type Country struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// timezone is the IANA name, e.g. "Asia/Tokyo"
+	Timezone string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// cities are the gazetteer cities accepted as city in requests
+	Cities []string `protobuf:"bytes,3,rep,name=cities,proto3" json:"cities,omitempty"`
+}
+
+func (x *Country) Reset() {
+	*x = Country{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Country) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Country) ProtoMessage() {}
+
+func (x *Country) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Country.ProtoReflect.Descriptor instead.
+func (*Country) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Country) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Country) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Country) GetCities() []string {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+type WatchFeedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// countries limits the stream to those countries, or every country when
+	// empty; feeds that aren't per country are always sent
+	Countries []string `protobuf:"bytes,1,rep,name=countries,proto3" json:"countries,omitempty"`
+	// feeds limits the stream to those feed names, or every feed when empty
+	Feeds []string `protobuf:"bytes,2,rep,name=feeds,proto3" json:"feeds,omitempty"`
+}
+
+func (x *WatchFeedRequest) Reset() {
+	*x = WatchFeedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchFeedRequest) ProtoMessage() {}
+
+func (x *WatchFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchFeedRequest.ProtoReflect.Descriptor instead.
+func (*WatchFeedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchFeedRequest) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *WatchFeedRequest) GetFeeds() []string {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+type FeedUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Feed string `protobuf:"bytes,1,opt,name=feed,proto3" json:"feed,omitempty"`
+	// country is empty for feeds that aren't per country
+	Country string `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	// value is the feed value as served by the REST API
+	Value     *structpb.Value        `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	FetchedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+}
+
+func (x *FeedUpdate) Reset() {
+	*x = FeedUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedUpdate) ProtoMessage() {}
+
+func (x *FeedUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedUpdate.ProtoReflect.Descriptor instead.
+func (*FeedUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FeedUpdate) GetFeed() string {
+	if x != nil {
+		return x.Feed
+	}
+	return ""
+}
+
+func (x *FeedUpdate) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *FeedUpdate) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *FeedUpdate) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+var File_proto_reefasia_feeds_v1_feeds_proto protoreflect.FileDescriptor
+
+var file_proto_reefasia_feeds_v1_feeds_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61,
+	0x2f, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e,
+	0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x01, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x76,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3b,
+	0x0a, 0x0b, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x65, 0x74,
+	0x63, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22, 0x59, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x57, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x65, 0x22, 0xd6, 0x07, 0x0a, 0x07, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x65, 0x61, 0x74, 0x68,
+	0x65, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x77,
+	0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x12, 0x20, 0x0a, 0x0c, 0x66, 0x65, 0x65, 0x6c, 0x73,
+	0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x66,
+	0x65, 0x65, 0x6c, 0x73, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x12, 0x2e, 0x0a, 0x13, 0x66, 0x65, 0x65,
+	0x6c, 0x73, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x66, 0x65, 0x65, 0x6c, 0x73, 0x4c, 0x69, 0x6b,
+	0x65, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e,
+	0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x77,
+	0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x77, 0x69, 0x6e, 0x64,
+	0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x74,
+	0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x12, 0x19, 0x0a,
+	0x08, 0x75, 0x76, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x75, 0x76, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x74,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x68, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x65,
+	0x61, 0x74, 0x5f, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x68, 0x65, 0x61, 0x74, 0x41, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x79, 0x12,
+	0x20, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x0a, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x6c, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c,
+	0x65, 0x12, 0x2a, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0d, 0x77, 0x69, 0x6e,
+	0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a,
+	0x14, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72,
+	0x65, 0x5f, 0x68, 0x70, 0x61, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x12, 0x73,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x48, 0x70,
+	0x61, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x0a, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6d, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x03, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0a, 0x61, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69,
+	0x63, 0x6f, 0x6e, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x18, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x72, 0x72, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x72, 0x72, 0x61, 0x74, 0x69, 0x76, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x77, 0x69,
+	0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x17, 0x0a, 0x15,
+	0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72,
+	0x65, 0x5f, 0x68, 0x70, 0x61, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6d, 0x22, 0x44, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x41, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79,
+	0x22, 0xa2, 0x02, 0x0a, 0x0a, 0x41, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6d, 0x32, 0x35, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x70,
+	0x6d, 0x32, 0x35, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6d, 0x31, 0x30, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x70, 0x6d, 0x31, 0x30, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x6f, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x61, 0x71, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x61, 0x71, 0x69, 0x12,
+	0x12, 0x0a, 0x04, 0x62, 0x61, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62,
+	0x61, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x61, 0x64, 0x76, 0x69,
+	0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x72, 0x65, 0x65, 0x66, 0x61,
+	0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69, 0x72,
+	0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x52, 0x06, 0x61,
+	0x64, 0x76, 0x69, 0x63, 0x65, 0x22, 0xe1, 0x03, 0x0a, 0x10, 0x41, 0x69, 0x72, 0x51, 0x75, 0x61,
+	0x6c, 0x69, 0x74, 0x79, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x6f, 0x6d, 0x69,
+	0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x64, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x50, 0x6f,
+	0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x12, 0x54, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x5f, 0x69,
+	0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x72,
+	0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x41, 0x64, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x53, 0x75, 0x62, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x36, 0x0a,
+	0x17, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15,
+	0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x57, 0x61,
+	0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x34, 0x0a, 0x16, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x6f,
+	0x75, 0x74, 0x64, 0x6f, 0x6f, 0x72, 0x5f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x4f, 0x75, 0x74, 0x64,
+	0x6f, 0x6f, 0x72, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d,
+	0x61, 0x73, 0x6b, 0x5f, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x6b, 0x41, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x79,
+	0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x53, 0x75,
+	0x62, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x51, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x65, 0x65,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x22,
+	0xa3, 0x01, 0x0a, 0x0a, 0x46, 0x65, 0x65, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x65,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x66, 0x65,
+	0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x41, 0x74, 0x32, 0xe7, 0x02, 0x0a, 0x05, 0x46, 0x65, 0x65, 0x64, 0x73, 0x12,
+	0x4e, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x12, 0x24, 0x2e,
+	0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66,
+	0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x12,
+	0x57, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x27, 0x2e, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x65, 0x66,
+	0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x69,
+	0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x62, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x72, 0x65, 0x65, 0x66,
+	0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x28, 0x2e, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65,
+	0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x09,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x46, 0x65, 0x65, 0x64, 0x12, 0x23, 0x2e, 0x72, 0x65, 0x65, 0x66,
+	0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x42,
+	0x40, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x72, 0x65, 0x65, 0x66, 0x61, 0x73, 0x69, 0x61, 0x2e,
+	0x66, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x25, 0x72, 0x65, 0x65, 0x66,
+	0x2d, 0x61, 0x73, 0x69, 0x61, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x66, 0x65, 0x65, 0x64, 0x73, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_reefasia_feeds_v1_feeds_proto_rawDescOnce sync.Once
+	file_proto_reefasia_feeds_v1_feeds_proto_rawDescData = file_proto_reefasia_feeds_v1_feeds_proto_rawDesc
+)
+
+func file_proto_reefasia_feeds_v1_feeds_proto_rawDescGZIP() []byte {
+	file_proto_reefasia_feeds_v1_feeds_proto_rawDescOnce.Do(func() {
+		file_proto_reefasia_feeds_v1_feeds_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_reefasia_feeds_v1_feeds_proto_rawDescData)
+	})
+	return file_proto_reefasia_feeds_v1_feeds_proto_rawDescData
+}
+
+var file_proto_reefasia_feeds_v1_feeds_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_reefasia_feeds_v1_feeds_proto_goTypes = []any{
+	(*Provenance)(nil),            // 0: reefasia.feeds.v1.Provenance
+	(*GetWeatherRequest)(nil),     // 1: reefasia.feeds.v1.GetWeatherRequest
+	(*Weather)(nil),               // 2: reefasia.feeds.v1.Weather
+	(*GetAirQualityRequest)(nil),  // 3: reefasia.feeds.v1.GetAirQualityRequest
+	(*AirQuality)(nil),            // 4: reefasia.feeds.v1.AirQuality
+	(*AirQualityAdvice)(nil),      // 5: reefasia.feeds.v1.AirQualityAdvice
+	(*ListCountriesRequest)(nil),  // 6: reefasia.feeds.v1.ListCountriesRequest
+	(*ListCountriesResponse)(nil), // 7: reefasia.feeds.v1.ListCountriesResponse
+	(*Country)(nil),               // 8: reefasia.feeds.v1.Country
+	(*WatchFeedRequest)(nil),      // 9: reefasia.feeds.v1.WatchFeedRequest
+	(*FeedUpdate)(nil),            // 10: reefasia.feeds.v1.FeedUpdate
+	nil,                           // 11: reefasia.feeds.v1.AirQualityAdvice.SubIndicesEntry
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*structpb.Value)(nil),        // 13: google.protobuf.Value
+}
+var file_proto_reefasia_feeds_v1_feeds_proto_depIdxs = []int32{
+	12, // 0: reefasia.feeds.v1.Provenance.observed_at:type_name -> google.protobuf.Timestamp
+	12, // 1: reefasia.feeds.v1.Provenance.fetched_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: reefasia.feeds.v1.Weather.provenance:type_name -> reefasia.feeds.v1.Provenance
+	0,  // 3: reefasia.feeds.v1.AirQuality.provenance:type_name -> reefasia.feeds.v1.Provenance
+	5,  // 4: reefasia.feeds.v1.AirQuality.advice:type_name -> reefasia.feeds.v1.AirQualityAdvice
+	11, // 5: reefasia.feeds.v1.AirQualityAdvice.sub_indices:type_name -> reefasia.feeds.v1.AirQualityAdvice.SubIndicesEntry
+	8,  // 6: reefasia.feeds.v1.ListCountriesResponse.countries:type_name -> reefasia.feeds.v1.Country
+	13, // 7: reefasia.feeds.v1.FeedUpdate.value:type_name -> google.protobuf.Value
+	12, // 8: reefasia.feeds.v1.FeedUpdate.fetched_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: reefasia.feeds.v1.Feeds.GetWeather:input_type -> reefasia.feeds.v1.GetWeatherRequest
+	3,  // 10: reefasia.feeds.v1.Feeds.GetAirQuality:input_type -> reefasia.feeds.v1.GetAirQualityRequest
+	6,  // 11: reefasia.feeds.v1.Feeds.ListCountries:input_type -> reefasia.feeds.v1.ListCountriesRequest
+	9,  // 12: reefasia.feeds.v1.Feeds.WatchFeed:input_type -> reefasia.feeds.v1.WatchFeedRequest
+	2,  // 13: reefasia.feeds.v1.Feeds.GetWeather:output_type -> reefasia.feeds.v1.Weather
+	4,  // 14: reefasia.feeds.v1.Feeds.GetAirQuality:output_type -> reefasia.feeds.v1.AirQuality
+	7,  // 15: reefasia.feeds.v1.Feeds.ListCountries:output_type -> reefasia.feeds.v1.ListCountriesResponse
+	10, // 16: reefasia.feeds.v1.Feeds.WatchFeed:output_type -> reefasia.feeds.v1.FeedUpdate
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_proto_reefasia_feeds_v1_feeds_proto_init() }
+func file_proto_reefasia_feeds_v1_feeds_proto_init() {
+	if File_proto_reefasia_feeds_v1_feeds_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Provenance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetWeatherRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Weather); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAirQualityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*AirQuality); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*AirQualityAdvice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ListCountriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ListCountriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Country); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchFeedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*FeedUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_reefasia_feeds_v1_feeds_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_reefasia_feeds_v1_feeds_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_reefasia_feeds_v1_feeds_proto_goTypes,
+		DependencyIndexes: file_proto_reefasia_feeds_v1_feeds_proto_depIdxs,
+		MessageInfos:      file_proto_reefasia_feeds_v1_feeds_proto_msgTypes,
+	}.Build()
+	File_proto_reefasia_feeds_v1_feeds_proto = out.File
+	file_proto_reefasia_feeds_v1_feeds_proto_rawDesc = nil
+	file_proto_reefasia_feeds_v1_feeds_proto_goTypes = nil
+	file_proto_reefasia_feeds_v1_feeds_proto_depIdxs = nil
+}
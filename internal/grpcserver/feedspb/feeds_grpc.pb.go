@@ -0,0 +1,277 @@
+// Feeds exposes the reef-asia feeds to internal services over gRPC. It serves
+// the same data as the /v1 REST API; field meanings are documented on the Go
+// types in reef-asia/internal/feeds.
+//
+// Regenerate the Go code after editing with protoc-gen-go and
+// protoc-gen-go-grpc, run from the repository root:
+//
+//	protoc --go_out=. --go_opt=module=reef-asia \
+//	  --go-grpc_out=. --go-grpc_opt=module=reef-asia \
+//	  proto/reefasia/feeds/v1/feeds.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: proto/reefasia/feeds/v1/feeds.proto
+
+package feedspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Feeds_GetWeather_FullMethodName    = "/reefasia.feeds.v1.Feeds/GetWeather"
+	Feeds_GetAirQuality_FullMethodName = "/reefasia.feeds.v1.Feeds/GetAirQuality"
+	Feeds_ListCountries_FullMethodName = "/reefasia.feeds.v1.Feeds/ListCountries"
+	Feeds_WatchFeed_FullMethodName     = "/reefasia.feeds.v1.Feeds/WatchFeed"
+)
+
+// FeedsClient is the client API for Feeds service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FeedsClient interface {
+	// GetWeather returns current weather. Unknown countries and cities fail
+	// with NOT_FOUND, upstream outages with UNAVAILABLE.
+	GetWeather(ctx context.Context, in *GetWeatherRequest, opts ...grpc.CallOption) (*Weather, error)
+	// GetAirQuality returns current air quality, failing like GetWeather
+	GetAirQuality(ctx context.Context, in *GetAirQualityRequest, opts ...grpc.CallOption) (*AirQuality, error)
+	// ListCountries returns the supported countries sorted by code
+	ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (*ListCountriesResponse, error)
+	// WatchFeed streams the current value of every refreshed feed, then each
+	// change as it is refreshed. It fails with FAILED_PRECONDITION on servers
+	// without background refresh.
+	WatchFeed(ctx context.Context, in *WatchFeedRequest, opts ...grpc.CallOption) (Feeds_WatchFeedClient, error)
+}
+
+type feedsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeedsClient(cc grpc.ClientConnInterface) FeedsClient {
+	return &feedsClient{cc}
+}
+
+func (c *feedsClient) GetWeather(ctx context.Context, in *GetWeatherRequest, opts ...grpc.CallOption) (*Weather, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Weather)
+	err := c.cc.Invoke(ctx, Feeds_GetWeather_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedsClient) GetAirQuality(ctx context.Context, in *GetAirQualityRequest, opts ...grpc.CallOption) (*AirQuality, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AirQuality)
+	err := c.cc.Invoke(ctx, Feeds_GetAirQuality_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedsClient) ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (*ListCountriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCountriesResponse)
+	err := c.cc.Invoke(ctx, Feeds_ListCountries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedsClient) WatchFeed(ctx context.Context, in *WatchFeedRequest, opts ...grpc.CallOption) (Feeds_WatchFeedClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Feeds_ServiceDesc.Streams[0], Feeds_WatchFeed_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedsWatchFeedClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Feeds_WatchFeedClient interface {
+	Recv() (*FeedUpdate, error)
+	grpc.ClientStream
+}
+
+type feedsWatchFeedClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedsWatchFeedClient) Recv() (*FeedUpdate, error) {
+	m := new(FeedUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FeedsServer is the server API for Feeds service.
+// All implementations must embed UnimplementedFeedsServer
+// for forward compatibility
+type FeedsServer interface {
+	// GetWeather returns current weather. Unknown countries and cities fail
+	// with NOT_FOUND, upstream outages with UNAVAILABLE.
+	GetWeather(context.Context, *GetWeatherRequest) (*Weather, error)
+	// GetAirQuality returns current air quality, failing like GetWeather
+	GetAirQuality(context.Context, *GetAirQualityRequest) (*AirQuality, error)
+	// ListCountries returns the supported countries sorted by code
+	ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesResponse, error)
+	// WatchFeed streams the current value of every refreshed feed, then each
+	// change as it is refreshed. It fails with FAILED_PRECONDITION on servers
+	// without background refresh.
+	WatchFeed(*WatchFeedRequest, Feeds_WatchFeedServer) error
+	mustEmbedUnimplementedFeedsServer()
+}
+
+// UnimplementedFeedsServer must be embedded to have forward compatible implementations.
+type UnimplementedFeedsServer struct {
+}
+
+func (UnimplementedFeedsServer) GetWeather(context.Context, *GetWeatherRequest) (*Weather, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWeather not implemented")
+}
+func (UnimplementedFeedsServer) GetAirQuality(context.Context, *GetAirQualityRequest) (*AirQuality, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAirQuality not implemented")
+}
+func (UnimplementedFeedsServer) ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCountries not implemented")
+}
+func (UnimplementedFeedsServer) WatchFeed(*WatchFeedRequest, Feeds_WatchFeedServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFeed not implemented")
+}
+func (UnimplementedFeedsServer) mustEmbedUnimplementedFeedsServer() {}
+
+// UnsafeFeedsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeedsServer will
+// result in compilation errors.
+type UnsafeFeedsServer interface {
+	mustEmbedUnimplementedFeedsServer()
+}
+
+func RegisterFeedsServer(s grpc.ServiceRegistrar, srv FeedsServer) {
+	s.RegisterService(&Feeds_ServiceDesc, srv)
+}
+
+func _Feeds_GetWeather_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWeatherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedsServer).GetWeather(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feeds_GetWeather_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedsServer).GetWeather(ctx, req.(*GetWeatherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feeds_GetAirQuality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAirQualityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedsServer).GetAirQuality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feeds_GetAirQuality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedsServer).GetAirQuality(ctx, req.(*GetAirQualityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feeds_ListCountries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedsServer).ListCountries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feeds_ListCountries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedsServer).ListCountries(ctx, req.(*ListCountriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feeds_WatchFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedsServer).WatchFeed(m, &feedsWatchFeedServer{ServerStream: stream})
+}
+
+type Feeds_WatchFeedServer interface {
+	Send(*FeedUpdate) error
+	grpc.ServerStream
+}
+
+type feedsWatchFeedServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedsWatchFeedServer) Send(m *FeedUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Feeds_ServiceDesc is the grpc.ServiceDesc for Feeds service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Feeds_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reefasia.feeds.v1.Feeds",
+	HandlerType: (*FeedsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWeather",
+			Handler:    _Feeds_GetWeather_Handler,
+		},
+		{
+			MethodName: "GetAirQuality",
+			Handler:    _Feeds_GetAirQuality_Handler,
+		},
+		{
+			MethodName: "ListCountries",
+			Handler:    _Feeds_ListCountries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFeed",
+			Handler:       _Feeds_WatchFeed_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/reefasia/feeds/v1/feeds.proto",
+}
@@ -0,0 +1,284 @@
+// Package grpcserver exposes the feeds over gRPC, as defined in
+// proto/reefasia/feeds/v1/feeds.proto
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/grpcserver/feedspb"
+	"reef-asia/internal/logger"
+)
+
+type opts struct {
+	refresher *feeds.Refresher
+	shutdown  <-chan struct{}
+}
+
+type Option func(*opts)
+
+// WithRefresher enables WatchFeed, which streams refresher updates
+func WithRefresher(r *feeds.Refresher) Option {
+	return func(o *opts) {
+		o.refresher = r
+	}
+}
+
+// WithShutdown ends WatchFeed streams with UNAVAILABLE once ctx is done, so
+// a graceful stop isn't kept waiting by them; clients reconnect elsewhere
+func WithShutdown(ctx context.Context) Option {
+	return func(o *opts) {
+		o.shutdown = ctx.Done()
+	}
+}
+
+// Server implements feedspb.FeedsServer; register it with
+// feedspb.RegisterFeedsServer
+type Server struct {
+	feedspb.UnimplementedFeedsServer
+
+	registry  *feeds.Registry
+	refresher *feeds.Refresher
+	shutdown  <-chan struct{}
+}
+
+// New serves the feeds using client and registry, or the package defaults
+// when nil. Errors carry the same meaning as the REST API's statuses: input
+// problems are NOT_FOUND or INVALID_ARGUMENT and upstream problems
+// UNAVAILABLE.
+func New(client *feeds.Client, registry *feeds.Registry, options ...Option) *Server {
+	o := &opts{}
+	for _, fn := range options {
+		fn(o)
+	}
+	if registry == nil {
+		registry = feeds.NewRegistry(client)
+	}
+	return &Server{registry: registry, refresher: o.refresher, shutdown: o.shutdown}
+}
+
+func (s *Server) GetWeather(ctx context.Context, req *feedspb.GetWeatherRequest) (*feedspb.Weather, error) {
+	ctx = feeds.ContextWithLocale(ctx, req.GetLocale())
+	params := feeds.Params{"country": req.GetCountry()}
+	if city := req.GetCity(); city != "" {
+		params["city"] = city
+	}
+	w, err := fetch[*feeds.WeatherData](ctx, s.registry, "weather", params)
+	if err != nil {
+		return nil, err
+	}
+	return &feedspb.Weather{
+		Summary:           w.Summary,
+		WeatherCode:       int32(w.WeatherCode),
+		Icon:              string(w.Icon),
+		Severity:          w.Severity.String(),
+		Narrative:         w.Narrative,
+		Locale:            w.Locale,
+		TemperatureC:      w.TemperatureC,
+		FeelsLikeC:        w.FeelsLikeC,
+		FeelsLikeComputed: w.FeelsLikeComputed,
+		WindSpeed:         w.WindSpeed,
+		WindSpeedUnit:     string(w.WindSpeedUnit),
+		RelativeHumidity:  w.RelativeHumidity,
+		UvIndex:           w.UVIndex,
+		HeatIndexC:        w.HeatIndexC,
+		HeatAdvisory:      string(w.HeatAdvisory),
+		Approximate:       w.Approximate,
+		City:              w.City,
+		MissingFields:     w.MissingFields,
+		Provenance:        provenanceMessage(w.Provenance),
+		Stale:             w.Stale,
+		AgeSeconds:        w.AgeSeconds,
+
+		WindDirection:      w.WindDirection,
+		SurfacePressureHpa: w.SurfacePressureHPa,
+		CloudCover:         w.CloudCover,
+		PrecipitationMm:    w.PrecipitationMm,
+	}, nil
+}
+
+func (s *Server) GetAirQuality(ctx context.Context, req *feedspb.GetAirQualityRequest) (*feedspb.AirQuality, error) {
+	params := feeds.Params{"country": req.GetCountry()}
+	if city := req.GetCity(); city != "" {
+		params["city"] = city
+	}
+	aq, err := fetch[*feeds.AirQualityData](ctx, s.registry, "airquality", params)
+	if err != nil {
+		return nil, err
+	}
+	return &feedspb.AirQuality{
+		Pm25:        aq.PM25,
+		Pm10:        aq.PM10,
+		Ozone:       aq.Ozone,
+		Aqi:         int32(aq.AQI),
+		Band:        aq.Band,
+		Approximate: aq.Approximate,
+		City:        aq.City,
+		Provenance:  provenanceMessage(aq.Provenance),
+		Advice:      adviceMessage(aq.Advice),
+	}, nil
+}
+
+// adviceMessage converts air quality advice to its message
+func adviceMessage(a feeds.AirQualityAdvice) *feedspb.AirQualityAdvice {
+	msg := &feedspb.AirQualityAdvice{
+		Standard:              a.Standard,
+		Index:                 int32(a.Index),
+		Category:              a.Category,
+		DominantPollutant:     string(a.DominantPollutant),
+		SubIndices:            make(map[string]int32, len(a.SubIndices)),
+		SensitiveGroupWarning: a.SensitiveGroupWarning,
+		LimitOutdoorExercise:  a.LimitOutdoorExercise,
+		MaskAdvisory:          a.MaskAdvisory,
+		Recommendations:       a.Recommendations,
+	}
+	for p, index := range a.SubIndices {
+		msg.SubIndices[string(p)] = int32(index)
+	}
+	return msg
+}
+
+func (s *Server) ListCountries(ctx context.Context, _ *feedspb.ListCountriesRequest) (*feedspb.ListCountriesResponse, error) {
+	clocks, err := fetch[map[string]*feeds.ClockData](ctx, s.registry, "clock", feeds.Params{})
+	if err != nil {
+		return nil, err
+	}
+	resp := &feedspb.ListCountriesResponse{}
+	for _, code := range feeds.SupportedCountries() {
+		country := &feedspb.Country{Code: code, Cities: feeds.Cities(code)}
+		if clock, ok := clocks[code]; ok {
+			country.Timezone = clock.Timezone
+		}
+		resp.Countries = append(resp.Countries, country)
+	}
+	return resp, nil
+}
+
+func (s *Server) WatchFeed(req *feedspb.WatchFeedRequest, stream feedspb.Feeds_WatchFeedServer) error {
+	if s.refresher == nil {
+		return status.Error(codes.FailedPrecondition, "background refresh is not enabled on this server")
+	}
+	for _, country := range req.GetCountries() {
+		if !feeds.IsSupportedCountry(country) {
+			return status.Errorf(codes.NotFound, "%v: %q", feeds.ErrUnsupportedCountry, country)
+		}
+	}
+
+	updates, cancel := s.refresher.Subscribe(req.GetCountries()...)
+	defer cancel()
+	for {
+		select {
+		case u := <-updates:
+			if len(req.GetFeeds()) > 0 && !slices.Contains(req.GetFeeds(), u.Feed) {
+				continue
+			}
+			msg, err := updateMessage(u)
+			if err != nil {
+				logger.Warnf("[grpc] watch %s %s: %v", u.Feed, u.Country, err)
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-s.shutdown:
+			return status.Error(codes.Unavailable, "server shutting down")
+		}
+	}
+}
+
+// fetch fetches a feed expected to hold a T, mapping errors to gRPC statuses
+func fetch[T any](ctx context.Context, registry *feeds.Registry, name string, params feeds.Params) (T, error) {
+	var zero T
+	value, err := registry.Fetch(ctx, name, params)
+	if err != nil {
+		code := codeFor(err)
+		if code == codes.Unavailable {
+			// Upstream details stay in the logs
+			logger.Warnf("[grpc] %s %v: %v", name, params, err)
+			return zero, status.Error(code, "feed upstream unavailable")
+		}
+		return zero, status.Error(code, err.Error())
+	}
+	v, ok := value.(T)
+	if !ok {
+		return zero, status.Errorf(codes.Internal, "feed %s returned %T", name, value)
+	}
+	return v, nil
+}
+
+// codeFor maps feed errors to gRPC codes, matching the REST API's statusFor
+func codeFor(err error) codes.Code {
+	switch {
+	case errors.Is(err, feeds.ErrUnknownFeed),
+		errors.Is(err, feeds.ErrUnsupportedCountry),
+		errors.Is(err, feeds.ErrUnknownCity),
+		errors.Is(err, feeds.ErrUnknownRegion),
+		errors.Is(err, feeds.ErrNoRegions),
+		errors.Is(err, feeds.ErrNoMarineData),
+		errors.Is(err, feeds.ErrNoNewsSources),
+		errors.Is(err, feeds.ErrNoMarketIndex),
+		errors.Is(err, feeds.ErrNoAdvisory):
+		return codes.NotFound
+	case errors.Is(err, feeds.ErrInvalidParams),
+		errors.Is(err, feeds.ErrInvalidForecastDays),
+		errors.Is(err, feeds.ErrInvalidNowcastWindow),
+		errors.Is(err, feeds.ErrUnknownCurrency),
+		errors.Is(err, feeds.ErrInvalidHistoricalDate),
+		errors.Is(err, feeds.ErrUnknownModel),
+		errors.As(err, new(*feeds.ErrInvalidCoordinates)):
+		return codes.InvalidArgument
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unavailable
+	}
+}
+
+// updateMessage converts a refresher update, carrying the value as its
+// JSON form so every feed type fits one message
+func updateMessage(u feeds.Update) (*feedspb.FeedUpdate, error) {
+	data, err := json.Marshal(u.Value)
+	if err != nil {
+		return nil, err
+	}
+	value := &structpb.Value{}
+	if err := protojson.Unmarshal(data, value); err != nil {
+		return nil, err
+	}
+	return &feedspb.FeedUpdate{
+		Feed:      u.Feed,
+		Country:   u.Country,
+		Value:     value,
+		FetchedAt: timestamp(u.FetchedAt),
+	}, nil
+}
+
+func provenanceMessage(p feeds.Provenance) *feedspb.Provenance {
+	return &feedspb.Provenance{
+		Source:     p.Source,
+		ObservedAt: timestamp(p.ObservedAt),
+		FetchedAt:  timestamp(p.FetchedAt),
+	}
+}
+
+// timestamp converts t, leaving zero times unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -51,6 +51,12 @@ func (w *wrap) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, so
+// streaming handlers can still flush
+func (w *wrap) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // optional helper if you later want wildcard skips (not used above)
 func hasPrefixIn(path string, set map[string]struct{}) bool {
 	for p := range set {
@@ -0,0 +1,168 @@
+// Package lifecycle starts the service's background components together and
+// stops them in reverse order within a deadline, so servers drain before
+// the refreshers and caches behind them go away
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"reef-asia/internal/logger"
+)
+
+// ErrStopTimeout is returned by Stop for components still running at its
+// deadline
+var ErrStopTimeout = errors.New("stop deadline exceeded")
+
+// Component is a part of the service with a background task, a shutdown
+// step, or both
+type Component struct {
+	Name string
+	// Run works until ctx is done; nil when there's nothing to run. A Run
+	// returning an error before Stop fails the Manager, see Done.
+	Run func(ctx context.Context) error
+	// Stop winds the component down within ctx's deadline, such as draining
+	// a server, before Run's ctx is canceled; nil only cancels it
+	Stop func(ctx context.Context) error
+}
+
+// Manager runs Components. Start runs them all, and Stop stops them last
+// added first, so components should be added after whatever they depend on.
+type Manager struct {
+	mu         sync.Mutex
+	components []*component
+	ctx        context.Context
+	stopped    bool
+
+	failOnce sync.Once
+	failed   chan struct{}
+	err      error
+}
+
+// component is an added Component and the state of its Run
+type component struct {
+	Component
+	cancel context.CancelFunc
+	// exited is closed when Run returns, and nil until it starts
+	exited chan struct{}
+}
+
+// New creates a Manager without components
+func New() *Manager {
+	return &Manager{failed: make(chan struct{})}
+}
+
+// Add adds a component, running it right away once the Manager has started
+func (m *Manager) Add(c Component) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	comp := &component{Component: c}
+	m.components = append(m.components, comp)
+	if m.ctx != nil && !m.stopped {
+		m.start(comp)
+	}
+}
+
+// Start runs every component's Run in its own goroutine, under a context
+// derived from ctx. Canceling ctx cancels them all at once; use Stop for an
+// orderly shutdown.
+func (m *Manager) Start(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ctx != nil || m.stopped {
+		return
+	}
+	m.ctx = ctx
+	for _, c := range m.components {
+		m.start(c)
+	}
+}
+
+// start runs c; m.mu must be held
+func (m *Manager) start(c *component) {
+	if c.Run == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	c.cancel, c.exited = cancel, make(chan struct{})
+	go func() {
+		defer close(c.exited)
+		logger.Debugf("[lifecycle] starting %s", c.Name)
+		if err := c.Run(ctx); err != nil && ctx.Err() == nil {
+			logger.Errorf("[lifecycle] %s failed: %v", c.Name, err)
+			m.fail(fmt.Errorf("%s: %w", c.Name, err))
+		}
+	}()
+}
+
+func (m *Manager) fail(err error) {
+	m.failOnce.Do(func() {
+		m.err = err
+		close(m.failed)
+	})
+}
+
+// Done is closed when a component's Run fails, so the service can stop the
+// rest instead of running without it
+func (m *Manager) Done() <-chan struct{} {
+	return m.failed
+}
+
+// Err returns the first component failure once Done is closed, or nil
+func (m *Manager) Err() error {
+	select {
+	case <-m.failed:
+		return m.err
+	default:
+		return nil
+	}
+}
+
+// Stop stops the components last added first: each one's Stop is called,
+// then its Run canceled and waited for. Components still running at ctx's
+// deadline are left behind and reported with ErrStopTimeout, while the
+// rest are still asked to stop. Stop returns the components' errors; later
+// calls do nothing.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return nil
+	}
+	m.stopped = true
+	components := m.components
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		start := time.Now()
+		logger.Debugf("[lifecycle] stopping %s", c.Name)
+		if c.Stop != nil {
+			if err := c.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+			}
+		}
+		if c.exited == nil {
+			continue
+		}
+		c.cancel()
+		select {
+		case <-c.exited:
+		case <-ctx.Done():
+		}
+		// Past the deadline, only components that already exited count as
+		// stopped
+		select {
+		case <-c.exited:
+			logger.Infof("[lifecycle] stopped %s in %v", c.Name, time.Since(start).Round(time.Millisecond))
+		default:
+			logger.Warnf("[lifecycle] %s still running at the stop deadline", c.Name)
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, ErrStopTimeout))
+		}
+	}
+	return errors.Join(errs...)
+}
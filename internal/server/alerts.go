@@ -0,0 +1,100 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"reef-asia/internal/alerts"
+)
+
+// maxRuleBytes caps the size of a POST /v1/alerts body
+const maxRuleBytes = 64 << 10
+
+// WithAlerter enables /v1/alerts, which lists, adds and removes the rules
+// of a, the thresholds on refreshed feeds that notify webhooks
+func WithAlerter(a *alerts.Alerter) Option {
+	return func(o *opts) {
+		o.alerter = a
+	}
+}
+
+// WithAdminToken sets the bearer token the /v1/alerts endpoints require in
+// an "Authorization: Bearer" header. Without one they are refused, as a rule
+// makes the service POST to the URL it names.
+func WithAdminToken(token string) Option {
+	return func(o *opts) {
+		o.adminToken = token
+	}
+}
+
+var (
+	// errAdminDisabled is served by admin endpoints without WithAdminToken
+	errAdminDisabled = errors.New("admin endpoints are disabled: no admin token configured")
+	// errUnauthorized is served for a missing or wrong admin token
+	errUnauthorized = errors.New("missing or invalid admin token")
+)
+
+// requireAdmin serves h only to requests carrying the admin token
+func requireAdmin(token string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			writeError(w, http.StatusForbidden, errAdminDisabled)
+			return
+		}
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="reef-asia"`)
+			writeError(w, http.StatusUnauthorized, errUnauthorized)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// handleAlerts registers the alert rule endpoints behind the admin token.
+// Secrets are write-only: they're accepted in POST bodies but never served
+// back.
+func handleAlerts(mux *http.ServeMux, a *alerts.Alerter, token string) {
+	mux.HandleFunc("GET /v1/alerts", requireAdmin(token, func(w http.ResponseWriter, r *http.Request) {
+		rules := a.Rules()
+		for i := range rules {
+			rules[i].Secret = ""
+		}
+		writeUncached(w, http.StatusOK, map[string][]alerts.Rule{"rules": rules})
+	}))
+	mux.HandleFunc("POST /v1/alerts", requireAdmin(token, func(w http.ResponseWriter, r *http.Request) {
+		var rule alerts.Rule
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRuleBytes))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&rule); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		rule, err := a.AddRule(rule)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		rule.Secret = ""
+		writeUncached(w, http.StatusCreated, rule)
+	}))
+	mux.HandleFunc("DELETE /v1/alerts/{id}", requireAdmin(token, func(w http.ResponseWriter, r *http.Request) {
+		err := a.RemoveRule(r.PathValue("id"))
+		if errors.Is(err, alerts.ErrUnknownRule) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+// writeUncached writes value as JSON that caches must not store
+func writeUncached(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net/http"
+
+	"reef-asia/internal/feeds"
+)
+
+// readiness is the JSON body of GET /readyz
+type readiness struct {
+	Ready bool `json:"ready"`
+	// Pending are the refresher feeds not fetched once yet
+	Pending []string `json:"pending,omitempty"`
+}
+
+// Health serves operator endpoints for client, or the default Client when
+// nil, and the store of WithRefresher:
+//
+//	GET /healthz       liveness: always 200, with the overall status and per-provider health
+//	GET /readyz        200 once the refresher has fetched every feed once, 503 until then
+//	GET /debug/feeds   the full feeds.HealthReport: providers, breakers, last fetch per
+//	                   feed and country, cache sizes and request latency
+//
+// Responses are never cached.
+func Health(client *feeds.Client, options ...Option) http.Handler {
+	o := &opts{}
+	for _, fn := range options {
+		fn(o)
+	}
+	report := func() feeds.HealthReport {
+		var store *feeds.Store
+		if o.refresher != nil {
+			store = o.refresher.Store()
+		}
+		if client == nil {
+			return feeds.Health(store)
+		}
+		return client.Health(store)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		h := report()
+		writeUncached(w, http.StatusOK, struct {
+			Status    feeds.HealthStatus     `json:"status"`
+			Providers []feeds.ProviderHealth `json:"providers"`
+		}{h.Status, h.Providers})
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, _ *http.Request) {
+		ready := readiness{Ready: true}
+		if o.refresher != nil {
+			ready.Pending = o.refresher.Pending()
+			ready.Ready = len(ready.Pending) == 0
+		}
+		status := http.StatusOK
+		if !ready.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		writeUncached(w, status, ready)
+	})
+	mux.HandleFunc("GET /debug/feeds", func(w http.ResponseWriter, _ *http.Request) {
+		writeUncached(w, http.StatusOK, report())
+	})
+	return mux
+}
@@ -0,0 +1,264 @@
+// Package server exposes the feeds over a JSON REST API
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"reef-asia/internal/alerts"
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/logger"
+)
+
+type opts struct {
+	maxAge     time.Duration
+	schema     feeds.SchemaVersion
+	refresher  *feeds.Refresher
+	alerter    *alerts.Alerter
+	adminToken string
+	shutdown   <-chan struct{}
+}
+
+type Option func(*opts)
+
+// WithMaxAge sets the Cache-Control max-age of successful responses (default 60s)
+func WithMaxAge(d time.Duration) Option {
+	return func(o *opts) {
+		o.maxAge = d
+	}
+}
+
+// WithSchemaVersion sets the JSON schema served to requests that don't ask
+// for one (default feeds.CurrentSchema). Serving feeds.SchemaV1 keeps clients
+// written before versioning working unchanged while newer ones opt in.
+func WithSchemaVersion(v feeds.SchemaVersion) Option {
+	return func(o *opts) {
+		o.schema = v
+	}
+}
+
+// schemaHeader names the schema version a request asks for and a response
+// is written in
+const schemaHeader = "X-Schema-Version"
+
+// errorResponse is the JSON body of every error response
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// New serves the feeds under /v1 using client and registry, or the package
+// defaults when nil:
+//
+//	GET /v1/weather/{country}     current weather; ?city= picks a gazetteer city, ?lang= or
+//	                              Accept-Language localizes the summary and ?model= picks
+//	                              the Open-Meteo model, e.g. jma_seamless
+//	GET /v1/airquality/{country}  current air quality; ?city= picks a gazetteer city
+//	GET /v1/card/{country}        weather, air quality, fx, local time and holidays in one
+//	                              feeds.CountryCard, with "errors" naming failed sections
+//	GET /v1/regions/{country}     weather and air quality per province or state, with the
+//	                              extremes across them, as a feeds.RegionalSummary
+//	GET /v1/feeds                 registered feed names
+//	GET /v1/feeds/{name}          any registered feed, query parameters as feeds.Params
+//	GET /v1/stream                refresher updates as Server-Sent Events, with WithRefresher
+//	GET /v1/snapshot              every refreshed feed at once as a feeds.StoreSnapshot, with WithRefresher;
+//	                              ?format=csv gives the CSV of feeds.StoreSnapshot.WriteCSV
+//	GET /v1/alerts                alert rules, with WithAlerter; the alert endpoints need
+//	                              the WithAdminToken bearer token
+//	POST /v1/alerts               add an alert rule from a JSON alerts.Rule, under a new ID
+//	DELETE /v1/alerts/{id}        remove an alert rule
+//
+// Successful responses carry an ETag and honor If-None-Match; errors are
+// JSON objects with an "error" field. ?schema= or an X-Schema-Version header
+// picks the feeds.SchemaVersion of JSON bodies and updates, "1" or "2" as in
+// feeds.ParseSchemaVersion, and responses name theirs in X-Schema-Version.
+func New(client *feeds.Client, registry *feeds.Registry, options ...Option) http.Handler {
+	o := &opts{maxAge: 60 * time.Second, schema: feeds.CurrentSchema}
+	for _, fn := range options {
+		fn(o)
+	}
+	if registry == nil {
+		registry = feeds.NewRegistry(client)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/weather/{country}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Accept-Language")
+		ctx := feeds.ContextWithLocale(r.Context(), requestLocale(r))
+		r = r.WithContext(feeds.ContextWithModel(ctx, r.URL.Query().Get("model")))
+		params := feeds.Params{"country": r.PathValue("country")}
+		if city := r.URL.Query().Get("city"); city != "" {
+			params["city"] = city
+		}
+		serveFeed(w, r, o, registry, "weather", params)
+	})
+	mux.HandleFunc("GET /v1/airquality/{country}", func(w http.ResponseWriter, r *http.Request) {
+		params := feeds.Params{"country": r.PathValue("country")}
+		if city := r.URL.Query().Get("city"); city != "" {
+			params["city"] = city
+		}
+		serveFeed(w, r, o, registry, "airquality", params)
+	})
+	mux.HandleFunc("GET /v1/card/{country}", func(w http.ResponseWriter, r *http.Request) {
+		serveFeed(w, r, o, registry, "card", feeds.Params{"country": r.PathValue("country")})
+	})
+	mux.HandleFunc("GET /v1/regions/{country}", func(w http.ResponseWriter, r *http.Request) {
+		serveFeed(w, r, o, registry, "regions", feeds.Params{"country": r.PathValue("country")})
+	})
+	mux.HandleFunc("GET /v1/feeds", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, o, map[string][]string{"feeds": registry.Names()})
+	})
+	mux.HandleFunc("GET /v1/feeds/{name}", func(w http.ResponseWriter, r *http.Request) {
+		params := feeds.Params{}
+		for k, v := range r.URL.Query() {
+			params[k] = v[0]
+		}
+		serveFeed(w, r, o, registry, r.PathValue("name"), params)
+	})
+	if o.refresher != nil {
+		mux.HandleFunc("GET /v1/stream", func(w http.ResponseWriter, r *http.Request) {
+			serveStream(w, r, o, o.refresher)
+		})
+		mux.HandleFunc("GET /v1/snapshot", func(w http.ResponseWriter, r *http.Request) {
+			serveSnapshot(w, r, o, o.refresher.Store().Snapshot())
+		})
+	}
+	if o.alerter != nil {
+		handleAlerts(mux, o.alerter, o.adminToken)
+	}
+	return mux
+}
+
+// serveFeed fetches a feed and writes it or the mapped error
+func serveFeed(w http.ResponseWriter, r *http.Request, o *opts, registry *feeds.Registry, name string, params feeds.Params) {
+	value, err := registry.Fetch(r.Context(), name, params)
+	if err != nil {
+		status := statusFor(err)
+		if status >= http.StatusInternalServerError {
+			// Upstream details stay in the logs
+			logger.Warnf("[server] %s %v: %v", name, params, err)
+			err = errors.New("feed upstream unavailable")
+		}
+		writeError(w, status, err)
+		return
+	}
+	writeJSON(w, r, o, value)
+}
+
+// serveSnapshot writes snap as JSON, or as the CSV of exports for
+// ?format=csv
+func serveSnapshot(w http.ResponseWriter, r *http.Request, o *opts, snap feeds.StoreSnapshot) {
+	switch feeds.ExportFormat(r.URL.Query().Get("format")) {
+	case "", feeds.ExportJSON:
+		writeJSON(w, r, o, snap)
+	case feeds.ExportCSV:
+		var buf bytes.Buffer
+		if err := snap.WriteCSV(&buf); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(o.maxAge.Seconds())))
+		_, _ = w.Write(buf.Bytes())
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %q", feeds.ErrUnknownExportFormat, r.URL.Query().Get("format")))
+	}
+}
+
+// requestLocale returns ?lang, or else the first Accept-Language tag
+func requestLocale(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return lang
+	}
+	tag, _, _ := strings.Cut(r.Header.Get("Accept-Language"), ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	return strings.TrimSpace(tag)
+}
+
+// statusFor maps feed errors to HTTP statuses: input problems are 4xx and
+// upstream problems 5xx
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, feeds.ErrUnknownFeed),
+		errors.Is(err, feeds.ErrUnsupportedCountry),
+		errors.Is(err, feeds.ErrUnknownCity),
+		errors.Is(err, feeds.ErrUnknownRegion),
+		errors.Is(err, feeds.ErrNoRegions),
+		errors.Is(err, feeds.ErrNoMarineData),
+		errors.Is(err, feeds.ErrNoNewsSources),
+		errors.Is(err, feeds.ErrNoMarketIndex),
+		errors.Is(err, feeds.ErrNoAdvisory):
+		return http.StatusNotFound
+	case errors.Is(err, feeds.ErrInvalidParams),
+		errors.Is(err, feeds.ErrUnknownSchemaVersion),
+		errors.Is(err, feeds.ErrInvalidForecastDays),
+		errors.Is(err, feeds.ErrInvalidNowcastWindow),
+		errors.Is(err, feeds.ErrUnknownCurrency),
+		errors.Is(err, feeds.ErrInvalidHistoricalDate),
+		errors.Is(err, feeds.ErrUnknownModel),
+		errors.As(err, new(*feeds.ErrInvalidCoordinates)):
+		return http.StatusBadRequest
+	case errors.Is(err, feeds.ErrCircuitOpen),
+		errors.Is(err, feeds.ErrRateLimited):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// requestSchema returns the schema version r asks for with ?schema= or
+// X-Schema-Version, or the default of WithSchemaVersion
+func requestSchema(r *http.Request, o *opts) (feeds.SchemaVersion, error) {
+	v := r.URL.Query().Get("schema")
+	if v == "" {
+		v = r.Header.Get(schemaHeader)
+	}
+	if v == "" {
+		return o.schema, nil
+	}
+	return feeds.ParseSchemaVersion(v)
+}
+
+// writeJSON writes value in the requested schema version with an ETag and
+// Cache-Control, or 304 when the client's If-None-Match already matches
+func writeJSON(w http.ResponseWriter, r *http.Request, o *opts, value any) {
+	version, err := requestSchema(r, o)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	body, err := feeds.MarshalSchema(value, version)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Add("Vary", schemaHeader)
+	w.Header().Set(schemaHeader, strconv.Itoa(int(version)))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(o.maxAge.Seconds())))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(append(body, '\n'))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(errorResponse{Error: err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"reef-asia/internal/feeds"
+	"reef-asia/internal/logger"
+)
+
+// keepaliveInterval is how often an idle stream sends a comment, so proxies
+// don't close the connection
+const keepaliveInterval = 30 * time.Second
+
+// WithRefresher enables GET /v1/stream, which pushes refresher updates as
+// Server-Sent Events, and GET /v1/snapshot, which returns all of them at once
+func WithRefresher(r *feeds.Refresher) Option {
+	return func(o *opts) {
+		o.refresher = r
+	}
+}
+
+// WithShutdown ends update streams once ctx is done, so an http.Server
+// shutting down isn't kept waiting by them; clients reconnect elsewhere
+func WithShutdown(ctx context.Context) Option {
+	return func(o *opts) {
+		o.shutdown = ctx.Done()
+	}
+}
+
+// serveStream streams refresher updates as "update" events whose data is a
+// JSON feeds.Update in the requested schema version. ?country=JP,SG limits
+// the stream to those countries; feeds that aren't per country are always
+// sent.
+func serveStream(w http.ResponseWriter, r *http.Request, o *opts, refresher *feeds.Refresher) {
+	version, err := requestSchema(r, o)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	var countries []string
+	if q := r.URL.Query().Get("country"); q != "" {
+		countries = strings.Split(q, ",")
+	}
+	for _, country := range countries {
+		if !feeds.IsSupportedCountry(country) {
+			writeError(w, http.StatusNotFound, feeds.ErrUnsupportedCountry)
+			return
+		}
+	}
+
+	updates, cancel := refresher.Subscribe(countries...)
+	defer cancel()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.Header().Set(schemaHeader, strconv.Itoa(int(version)))
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		logger.Warnf("[server] stream unsupported by response writer: %v", err)
+		return
+	}
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case u := <-updates:
+			data, err := feeds.MarshalSchema(u, version)
+			if err != nil {
+				logger.Warnf("[server] stream %s %s: %v", u.Feed, u.Country, err)
+				continue
+			}
+			if _, err := w.Write([]byte("event: update\ndata: " + string(data) + "\n\n")); err != nil {
+				return
+			}
+		case <-keepalive.C:
+			if _, err := w.Write([]byte(": keepalive\n\n")); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		case <-o.shutdown:
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
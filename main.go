@@ -3,16 +3,33 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"reef-asia/internal/alerts"
+	"reef-asia/internal/config"
 	"reef-asia/internal/featureflags"
 	"reef-asia/internal/feeds"
+	"reef-asia/internal/grpcserver"
+	"reef-asia/internal/grpcserver/feedspb"
 	mw "reef-asia/internal/http/middleware"
+	"reef-asia/internal/lifecycle"
 	"reef-asia/internal/logger"
+	"reef-asia/internal/server"
+	"reef-asia/internal/tracing"
 )
 
 // NewsItem represents a single news article
@@ -43,33 +60,89 @@ func main() {
 			featureflags.Values().Offline.IsEnabled(nil),
 			featureflags.Values().LogLevel.GetValue(nil))
 	}
-	defer featureflags.Shutdown()
+	// Background components start together once everything is wired up,
+	// and stop in reverse order on SIGTERM
+	lc := lifecycle.New()
+	lc.Add(lifecycle.Component{
+		Name: "feature flags",
+		Stop: func(context.Context) error {
+			featureflags.Shutdown()
+			return nil
+		},
+	})
 
 	// 2) Initialize levelled logger from flag & watch for flips
 	logger.Init(featureflags.Values().LogLevel.GetValue(nil))
 	logger.Infof("log level set to %s", logger.GetLevel())
 
-	go func() {
-		prev := featureflags.Values().LogLevel.GetValue(nil)
-		for {
-			time.Sleep(5 * time.Second)
-			cur := featureflags.Values().LogLevel.GetValue(nil)
-			if cur != prev {
-				logger.SetLevel(cur)
-				logger.Infof("log level changed to %s", logger.GetLevel())
-				prev = cur
+	lc.Add(lifecycle.Component{
+		Name: "log level",
+		Run: func(ctx context.Context) error {
+			ticker := time.NewTicker(5 * time.Second)
+			defer ticker.Stop()
+			prev := featureflags.Values().LogLevel.GetValue(nil)
+			for {
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return nil
+				}
+				cur := featureflags.Values().LogLevel.GetValue(nil)
+				if cur != prev {
+					logger.SetLevel(cur)
+					logger.Infof("log level changed to %s", logger.GetLevel())
+					prev = cur
+				}
 			}
-		}
-	}()
+		},
+	})
 
-	// 3) Router
+	// 3) Deployment config: which feeds, keys, refresh intervals and cache
+	// TTLs, the Redis cache shared by replicas, plus tracing when an OTLP endpoint is set (non-fatal)
+	cfg, err := config.Load(os.Getenv(config.EnvFile))
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+	clientOptions := cfg.ClientOptions()
+	shared, err := cfg.RedisCache()
+	if err != nil {
+		log.Fatalf("redis: %v", err)
+	}
+	if shared != nil {
+		clientOptions = append(clientOptions, feeds.WithCache(shared))
+	}
+	tp, err := tracing.Init(context.Background())
+	if err != nil {
+		log.Printf("tracing init warning: %v (tracing disabled)", err)
+	} else if tp != nil {
+		clientOptions = append(clientOptions, feeds.WithTracerProvider(tp))
+	}
+	lc.Add(lifecycle.Component{Name: "tracing", Stop: tracing.Shutdown})
+	client, err := feeds.NewClient(clientOptions...)
+	if err != nil {
+		log.Fatalf("feeds client: %v", err)
+	}
+	// Stale cache entries being refreshed reach the cache before it closes
+	lc.Add(lifecycle.Component{
+		Name: "cache",
+		Stop: func(ctx context.Context) error {
+			err := client.Flush(ctx)
+			if shared != nil {
+				err = errors.Join(err, shared.Close())
+			}
+			return err
+		},
+	})
+	registry := cfg.Registry(client)
+
+	// 4) Router
 	r := mux.NewRouter()
 
-	// 4) Offline kill-switch middleware
+	// 5) Offline kill-switch middleware
 	offlineGate := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// always allow health checks
-			if r.URL.Path == "/health" || r.URL.Path == "/ready" {
+			if isProbe(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -83,10 +156,10 @@ func main() {
 	}
 	r.Use(offlineGate)
 
-	// 5) Request logger (skip noisy health endpoints)
-	r.Use(mw.LogRequests(mw.WithSkips("/health", "/ready")))
+	// 6) Request logger (skip noisy health endpoints)
+	r.Use(mw.LogRequests(mw.WithSkips("/health", "/ready", "/healthz", "/readyz")))
 
-	// 6) Health endpoints
+	// 7) Health endpoints
 	r.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
@@ -97,7 +170,7 @@ func main() {
 		_, _ = w.Write([]byte("ready"))
 	}).Methods(http.MethodGet)
 
-	// 7) Inspect current flag values
+	// 8) Inspect current flag values
 	r.HandleFunc("/_flags", func(w http.ResponseWriter, _ *http.Request) {
 		resp := map[string]interface{}{
 			"offline":  featureflags.Values().Offline.IsEnabled(nil),
@@ -107,7 +180,7 @@ func main() {
 		_ = json.NewEncoder(w).Encode(resp)
 	}).Methods(http.MethodGet)
 
-	// 8) Regional feeds endpoint with stub data
+	// 9) Regional feeds endpoint with stub data
 	r.HandleFunc("/regional-feeds", func(w http.ResponseWriter, r *http.Request) {
 		country := r.URL.Query().Get("country")
 		if country == "" {
@@ -117,12 +190,17 @@ func main() {
 		logger.Infof("serving ASIA regional feeds for country: %s", country)
 
 		// Fetch real weather data from Open-Meteo API
-		weather, err := feeds.FetchWeather(country)
+		weather, err := client.FetchWeather(r.Context(), country)
+		if errors.Is(err, feeds.ErrUnsupportedCountry) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			logger.Warnf("failed to fetch weather for %s: %v (using fallback)", country, err)
 			// Fallback to stub data if API fails
 			weather = &feeds.WeatherData{
 				Summary:      "Weather data unavailable",
+				Icon:         feeds.IconUnknown,
 				TemperatureC: 0,
 				FeelsLikeC:   0,
 			}
@@ -166,11 +244,190 @@ func main() {
 		}
 	}).Methods(http.MethodGet)
 
+	// 10) Background refresh for live dashboards, alert rules and exports,
+	// led by one replica per feed when they share Redis
+	refresher := feeds.NewRefresher(registry, cfg.RefreshSpecs()...)
+	if shared != nil {
+		refresher.Share(shared)
+	}
+	lc.Add(lifecycle.Component{
+		Name: "refresher",
+		Run: func(ctx context.Context) error {
+			refresher.Run(ctx)
+			return nil
+		},
+	})
+	alerter := alerts.New(refresher, alerts.WithWebhookHosts(cfg.Alerts.WebhookHosts...))
+	lc.Add(lifecycle.Component{
+		Name: "alerter",
+		Run: func(ctx context.Context) error {
+			alerter.Run(ctx)
+			return nil
+		},
+	})
+	if cfg.Export.Dir != "" {
+		exporter, err := feeds.NewExporter(refresher.Store(), cfg.Export.Dir, cfg.ExportFormats()...)
+		if err != nil {
+			log.Fatalf("export: %v", err)
+		}
+		lc.Add(lifecycle.Component{
+			Name: "exporter",
+			Run: func(ctx context.Context) error {
+				exporter.Run(ctx, cfg.Export.Interval)
+				return nil
+			},
+		})
+	}
+
+	// Update streams never go idle, so they end as soon as the servers
+	// start draining instead of holding the drain to its deadline
+	draining, drain := context.WithCancel(context.Background())
+	defer drain()
+
+	// 11) Feeds REST API, update stream and alert rules, with provider health
+	// for probes; the feed state in /debug/feeds is only served to operators
+	// on the admin listener
+	r.PathPrefix("/v1/").Handler(server.New(client, registry, server.WithRefresher(refresher), server.WithAlerter(alerter), server.WithAdminToken(cfg.Admin.Token), server.WithSchemaVersion(cfg.Schema()), server.WithShutdown(draining)))
+	health := server.Health(client, server.WithRefresher(refresher))
+	for _, path := range []string{"/healthz", "/readyz"} {
+		r.Handle(path, health).Methods(http.MethodGet)
+	}
+	if cfg.Admin.Addr != "" {
+		admin := mux.NewRouter()
+		admin.Handle("/debug/feeds", health).Methods(http.MethodGet)
+		as := &http.Server{
+			Addr:              cfg.Admin.Addr,
+			Handler:           admin,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		lc.Add(lifecycle.Component{
+			Name: "admin",
+			Run: func(context.Context) error {
+				logger.Infof("reef-asia admin listening on %s", as.Addr)
+				if err := as.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+					return err
+				}
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				if err := as.Shutdown(ctx); err != nil {
+					as.Close()
+					return err
+				}
+				return nil
+			},
+		})
+	}
+
+	// 12) gRPC API for internal services, behind the same offline kill-switch
+	offlineErr := status.Error(codes.Unavailable, "service temporarily offline")
+	gs := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if featureflags.Values().Offline.IsEnabled(nil) {
+				return nil, offlineErr
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if featureflags.Values().Offline.IsEnabled(nil) {
+				return offlineErr
+			}
+			return handler(srv, ss)
+		}),
+	)
+	feedspb.RegisterFeedsServer(gs, grpcserver.New(client, registry, grpcserver.WithRefresher(refresher), grpcserver.WithShutdown(draining)))
+	lis, err := net.Listen("tcp", ":9090")
+	if err != nil {
+		log.Fatalf("grpc listen: %v", err)
+	}
+	lc.Add(lifecycle.Component{
+		Name: "grpc",
+		Run: func(context.Context) error {
+			logger.Infof("reef-asia gRPC listening on %s", lis.Addr())
+			return gs.Serve(lis)
+		},
+		Stop: func(ctx context.Context) error {
+			drain()
+			stopped := make(chan struct{})
+			go func() {
+				gs.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				gs.Stop()
+				return ctx.Err()
+			}
+		},
+	})
+
 	s := &http.Server{
 		Addr:              ":8080",
-		Handler:           r,
+		Handler:           traced(r),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
-	logger.Infof("reef-asia listening on %s", s.Addr)
-	log.Fatal(s.ListenAndServe())
+	s.RegisterOnShutdown(drain)
+	lc.Add(lifecycle.Component{
+		Name: "http",
+		Run: func(context.Context) error {
+			logger.Infof("reef-asia listening on %s", s.Addr)
+			if err := s.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if err := s.Shutdown(ctx); err != nil {
+				s.Close()
+				return err
+			}
+			return nil
+		},
+	})
+
+	// 13) Run until SIGTERM (or SIGINT), or until a server fails, then drain
+	// within the shutdown timeout; a second signal exits at once
+	interrupted, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	lc.Start(context.Background())
+	select {
+	case <-interrupted.Done():
+		logger.Infof("shutting down within %v", cfg.ShutdownTimeout)
+	case <-lc.Done():
+		logger.Errorf("shutting down: %v", lc.Err())
+	}
+	stopSignals()
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelStop()
+	if err := lc.Stop(stopCtx); err != nil {
+		logger.Errorf("shutdown: %v", err)
+	}
+	if err := lc.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// traced joins incoming requests to the caller's trace, so feed spans nest
+// under it; health checks aren't traced
+func traced(h http.Handler) http.Handler {
+	return otelhttp.NewHandler(h, "reef-asia",
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !isProbe(r.URL.Path)
+		}),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
+}
+
+// isProbe reports whether path is a liveness or readiness probe
+func isProbe(path string) bool {
+	switch path {
+	case "/health", "/ready", "/healthz", "/readyz":
+		return true
+	}
+	return false
 }